@@ -15,9 +15,59 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/btpn/calculate-installments": {
+        "/admin/tenors": {
+            "get": {
+                "description": "Returns the tenors available on as_of (default today), ordered by tenor length, or the whole tenor master with include_inactive.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenor Admin"
+                ],
+                "summary": "List tenors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Availability date (YYYY-MM-DD)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include inactive and out-of-date tenors",
+                        "name": "include_inactive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ListTenorsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Returns installment calculations for available tenors.",
+                "description": "Adds a tenor to the tenor master. Both margin rates are required. New tenors are active unless active is false.",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,25 +75,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Installments"
+                    "Tenor Admin"
                 ],
-                "summary": "Calculate installment schedule",
+                "summary": "Create a tenor",
                 "parameters": [
                     {
-                        "description": "Installment request",
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Tenor",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CalculateInstallmentRequest"
+                            "$ref": "#/definitions/domain.TenorRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.CalculateInstallmentResponse"
+                            "$ref": "#/definitions/domain.TenorResponse"
                         }
                     },
                     "400": {
@@ -55,6 +112,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -67,9 +142,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/calculate-installments": {
-            "post": {
-                "description": "Returns installment calculations for available tenors.",
+        "/admin/tenors/{id}": {
+            "put": {
+                "description": "Replaces the tenor's rates, frequencies, amount band and effective dates. Both margin rates are required. Active is kept when omitted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,17 +152,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Installments"
+                    "Tenor Admin"
                 ],
-                "summary": "Calculate installment schedule",
+                "summary": "Update a tenor",
                 "parameters": [
                     {
-                        "description": "Installment request",
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tenor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tenor",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CalculateInstallmentRequest"
+                            "$ref": "#/definitions/domain.TenorRequest"
                         }
                     }
                 ],
@@ -95,7 +184,86 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.CalculateInstallmentResponse"
+                            "$ref": "#/definitions/domain.TenorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Withdraws the tenor from new calculations. The tenor stays in the master and can be reactivated with an update.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenor Admin"
+                ],
+                "summary": "Deactivate a tenor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tenor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TenorResponse"
                         }
                     },
                     "400": {
@@ -107,6 +275,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -118,45 +304,4110 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.CalculateInstallmentRequest": {
-            "type": "object",
-            "required": [
-                "amount"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "integer"
+        },
+        "/applications": {
+            "post": {
+                "description": "Starts a draft application for one tenor of an unexpired saved quote. The quoted terms are copied onto the application.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Create a financing application",
+                "parameters": [
+                    {
+                        "description": "Quote, tenor and actor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateApplicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "domain.CalculateInstallmentResponse": {
-            "type": "object",
-            "properties": {
-                "calculations": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.InstallmentCalculation"
+        "/applications/{id}": {
+            "get": {
+                "description": "Returns the application with its current status and the statuses it may move to next.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Get a financing application",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "domain.InstallmentCalculation": {
-            "type": "object",
-            "properties": {
-                "monthly_installment": {
-                    "type": "integer"
-                },
-                "tenor": {
-                    "type": "integer"
-                },
-                "total_margin": {
+        "/applications/{id}/transitions": {
+            "get": {
+                "description": "Returns every status change of the application, oldest first, with the actor and time of each.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "List an application's status history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationTransitionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Moves the application to a new status. Allowed moves: draft to submitted; submitted to under_review; under_review to approved or rejected; approved to disbursed; and any open status to cancelled. Other moves return code invalid_transition, and a concurrent change returns application_conflict.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Change an application's status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status and actor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TransitionApplicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/admin/tenors": {
+            "get": {
+                "description": "Returns the tenors available on as_of (default today), ordered by tenor length, or the whole tenor master with include_inactive.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenor Admin"
+                ],
+                "summary": "List tenors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Availability date (YYYY-MM-DD)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include inactive and out-of-date tenors",
+                        "name": "include_inactive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ListTenorsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a tenor to the tenor master. Both margin rates are required. New tenors are active unless active is false.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenor Admin"
+                ],
+                "summary": "Create a tenor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Tenor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TenorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TenorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/admin/tenors/{id}": {
+            "put": {
+                "description": "Replaces the tenor's rates, frequencies, amount band and effective dates. Both margin rates are required. Active is kept when omitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenor Admin"
+                ],
+                "summary": "Update a tenor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tenor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tenor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TenorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TenorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Withdraws the tenor from new calculations. The tenor stays in the master and can be reactivated with an update.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenor Admin"
+                ],
+                "summary": "Deactivate a tenor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tenor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TenorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/applications": {
+            "post": {
+                "description": "Starts a draft application for one tenor of an unexpired saved quote. The quoted terms are copied onto the application.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Create a financing application",
+                "parameters": [
+                    {
+                        "description": "Quote, tenor and actor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateApplicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/applications/{id}": {
+            "get": {
+                "description": "Returns the application with its current status and the statuses it may move to next.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Get a financing application",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/applications/{id}/transitions": {
+            "get": {
+                "description": "Returns every status change of the application, oldest first, with the actor and time of each.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "List an application's status history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationTransitionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Moves the application to a new status. Allowed moves: draft to submitted; submitted to under_review; under_review to approved or rejected; approved to disbursed; and any open status to cancelled. Other moves return code invalid_transition, and a concurrent change returns application_conflict.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Change an application's status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status and actor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TransitionApplicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ApplicationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/calculate-installments": {
+            "post": {
+                "description": "Returns installment calculations for available tenors. With save_quote, the result is also saved as a quote that can be retrieved by ID until it expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Calculate installment schedule",
+                "parameters": [
+                    {
+                        "description": "Installment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateInstallmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateInstallmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/calculate-installments/early-settlement": {
+            "post": {
+                "description": "Returns the outstanding principal, unearned margin, muqasah rebate and settlement amount for closing a contract before maturity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Quote early settlement",
+                "parameters": [
+                    {
+                        "description": "Early settlement request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.EarlySettlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EarlySettlementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/calculate-installments/max-financing": {
+            "post": {
+                "description": "Returns, per tenor, the largest principal whose installments stay within the target monthly installment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Calculate maximum financing amount",
+                "parameters": [
+                    {
+                        "description": "Max financing request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MaxFinancingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MaxFinancingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/calculate-installments/prepayment": {
+            "post": {
+                "description": "Recomputes the remaining schedule after a lump-sum partial payment, keeping the tenor with a lower installment or keeping the installment with a shorter tenor, and returns the margin saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Simulate a partial prepayment",
+                "parameters": [
+                    {
+                        "description": "Prepayment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PrepaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PrepaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/calculate-installments/schedule": {
+            "post": {
+                "description": "Returns the month-by-month installment schedule for a single tenor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Calculate amortization schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.InstallmentScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/calculate-late-charges": {
+            "post": {
+                "description": "Returns the ta'widh and ta'zir charges, after caps, for each overdue installment under the given rule set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Late Charges"
+                ],
+                "summary": "Calculate late-payment charges",
+                "parameters": [
+                    {
+                        "description": "Late charge request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateLateChargeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateLateChargeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/contracts": {
+            "post": {
+                "description": "Opens the contract ledger of a disbursed application. The schedule is calculated from the quoted request for the application's tenor, starting on disbursement_date (default today).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "Open a repayment ledger",
+                "parameters": [
+                    {
+                        "description": "Application and disbursement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenContractRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ContractResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/contracts/{id}": {
+            "get": {
+                "description": "Returns the outstanding principal, margin and charges, the overdue amount and the paid status of every installment as of as_of (default today).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "Get a contract's outstanding balance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Balance date (YYYY-MM-DD)",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ContractResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/contracts/{id}/charges": {
+            "post": {
+                "description": "Adds a charge, such as a late-payment charge, to an installment that is overdue today. The contract's credit balance pays the charge first; a settled contract is reopened for the rest.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "Assess a charge",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Charge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AssessChargeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ContractResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/contracts/{id}/payments": {
+            "get": {
+                "description": "Returns every payment posted to the contract with its allocations, in posting order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "List a contract's payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ListPaymentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Allocates the payment over the installments due on paid_on in the contract's allocation order, then over later installments, oldest first. Anything beyond the outstanding balance is kept as credit balance. A reference can only be posted once per contract.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "Post a payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PostPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PostPaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/quotes": {
+            "get": {
+                "description": "Returns the newest unexpired quotes created between from and to (inclusive dates), optionally for one channel.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quotes"
+                ],
+                "summary": "List saved quotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First creation date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last creation date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of quotes (default 100, at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ListQuotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/btpn/quotes/{id}": {
+            "get": {
+                "description": "Returns a quote saved by calculate-installments with save_quote, including the original request, the pricing version and the calculations. Expired quotes are not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quotes"
+                ],
+                "summary": "Get a saved quote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.QuoteResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-installments": {
+            "post": {
+                "description": "Returns installment calculations for available tenors. With save_quote, the result is also saved as a quote that can be retrieved by ID until it expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Calculate installment schedule",
+                "parameters": [
+                    {
+                        "description": "Installment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateInstallmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateInstallmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-installments/early-settlement": {
+            "post": {
+                "description": "Returns the outstanding principal, unearned margin, muqasah rebate and settlement amount for closing a contract before maturity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Quote early settlement",
+                "parameters": [
+                    {
+                        "description": "Early settlement request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.EarlySettlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EarlySettlementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-installments/max-financing": {
+            "post": {
+                "description": "Returns, per tenor, the largest principal whose installments stay within the target monthly installment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Calculate maximum financing amount",
+                "parameters": [
+                    {
+                        "description": "Max financing request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MaxFinancingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MaxFinancingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-installments/prepayment": {
+            "post": {
+                "description": "Recomputes the remaining schedule after a lump-sum partial payment, keeping the tenor with a lower installment or keeping the installment with a shorter tenor, and returns the margin saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Simulate a partial prepayment",
+                "parameters": [
+                    {
+                        "description": "Prepayment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PrepaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PrepaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-installments/schedule": {
+            "post": {
+                "description": "Returns the month-by-month installment schedule for a single tenor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Installments"
+                ],
+                "summary": "Calculate amortization schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.InstallmentScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-late-charges": {
+            "post": {
+                "description": "Returns the ta'widh and ta'zir charges, after caps, for each overdue installment under the given rule set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Late Charges"
+                ],
+                "summary": "Calculate late-payment charges",
+                "parameters": [
+                    {
+                        "description": "Late charge request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateLateChargeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CalculateLateChargeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts": {
+            "post": {
+                "description": "Opens the contract ledger of a disbursed application. The schedule is calculated from the quoted request for the application's tenor, starting on disbursement_date (default today).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "Open a repayment ledger",
+                "parameters": [
+                    {
+                        "description": "Application and disbursement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenContractRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ContractResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}": {
+            "get": {
+                "description": "Returns the outstanding principal, margin and charges, the overdue amount and the paid status of every installment as of as_of (default today).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "Get a contract's outstanding balance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Balance date (YYYY-MM-DD)",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ContractResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/charges": {
+            "post": {
+                "description": "Adds a charge, such as a late-payment charge, to an installment that is overdue today. The contract's credit balance pays the charge first; a settled contract is reopened for the rest.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "Assess a charge",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Charge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AssessChargeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ContractResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/contracts/{id}/payments": {
+            "get": {
+                "description": "Returns every payment posted to the contract with its allocations, in posting order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "List a contract's payments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ListPaymentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Allocates the payment over the installments due on paid_on in the contract's allocation order, then over later installments, oldest first. Anything beyond the outstanding balance is kept as credit balance. A reference can only be posted once per contract.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Contracts"
+                ],
+                "summary": "Post a payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contract ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PostPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PostPaymentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes": {
+            "get": {
+                "description": "Returns the newest unexpired quotes created between from and to (inclusive dates), optionally for one channel.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quotes"
+                ],
+                "summary": "List saved quotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First creation date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last creation date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of quotes (default 100, at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ListQuotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes/{id}": {
+            "get": {
+                "description": "Returns a quote saved by calculate-installments with save_quote, including the original request, the pricing version and the calculations. Expired quotes are not found.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Quotes"
+                ],
+                "summary": "Get a saved quote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quote ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.QuoteResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AffordabilitySummary": {
+            "type": "object",
+            "properties": {
+                "existing_obligations": {
+                    "type": "integer"
+                },
+                "max_dsr": {
+                    "type": "number"
+                },
+                "monthly_income": {
+                    "type": "integer"
+                },
+                "recommended_tenor": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ApplicationResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "financed_amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "monthly_installment": {
+                    "type": "integer"
+                },
+                "next_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pricing_version_id": {
+                    "type": "integer"
+                },
+                "quote_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenor": {
+                    "type": "integer"
+                },
+                "total_payment": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ApplicationTransitionResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ApplicationTransitionsResponse": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ApplicationTransitionResponse"
+                    }
+                }
+            }
+        },
+        "domain.AssessChargeRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "installment_number"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 25000
+                },
+                "installment_number": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "domain.BrokenPeriod": {
+            "type": "object",
+            "properties": {
+                "day_count_convention": {
+                    "type": "string"
+                },
+                "days": {
+                    "type": "integer"
+                },
+                "disbursement_date": {
+                    "type": "string"
+                },
+                "margin": {
+                    "type": "integer"
+                },
+                "regular_start_date": {
+                    "type": "string"
+                },
+                "treatment": {
+                    "type": "string"
+                },
+                "year_fraction": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.CalculateInstallmentRequest": {
+            "type": "object",
+            "properties": {
+                "admin_fee": {
+                    "$ref": "#/definitions/domain.FinancingCharge"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "as_of": {
+                    "description": "AsOf prices with the tenor master as it stood at the close of that\ndate, or at that instant when given as an RFC 3339 timestamp; empty\nmeans now.",
+                    "type": "string",
+                    "example": "2026-09-15"
+                },
+                "asset_price": {
+                    "type": "integer"
+                },
+                "channel": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "mobile"
+                },
+                "down_payment": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "down_payment_rate": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.2
+                },
+                "existing_obligations": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "grace_mode": {
+                    "type": "string",
+                    "example": "margin_only"
+                },
+                "grace_periods": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "installment_profile": {
+                    "$ref": "#/definitions/domain.InstallmentProfile"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "flat"
+                },
+                "monthly_income": {
+                    "type": "integer"
+                },
+                "payment_frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "pricing_version_id": {
+                    "description": "PricingVersionID prices with that recorded version of the tenors and\nproducts instead of the one in effect on the pricing date.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "product_code": {
+                    "type": "string",
+                    "example": "murabahah"
+                },
+                "rate_reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RateReview"
+                    }
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_up"
+                },
+                "rounding_unit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "save_quote": {
+                    "description": "SaveQuote stores the result as a quote that can be retrieved by ID\nuntil it expires; Channel tags it for listing.",
+                    "type": "boolean"
+                },
+                "stamp_duty": {
+                    "$ref": "#/definitions/domain.FinancingCharge"
+                },
+                "takaful_contribution": {
+                    "$ref": "#/definitions/domain.FinancingCharge"
+                },
+                "tenors": {
+                    "description": "Tenors limits the quote to these tenors in months; empty quotes every\ntenor in the tenor master.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        18
+                    ]
+                }
+            }
+        },
+        "domain.CalculateInstallmentResponse": {
+            "type": "object",
+            "properties": {
+                "accepted_tenors": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "affordability": {
+                    "$ref": "#/definitions/domain.AffordabilitySummary"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "calculations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.InstallmentCalculation"
+                    }
+                },
+                "excluded_tenors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ExcludedTenor"
+                    }
+                },
+                "financing": {
+                    "$ref": "#/definitions/domain.FinancingBreakdown"
+                },
+                "pricing_version_id": {
+                    "type": "integer"
+                },
+                "quote": {
+                    "$ref": "#/definitions/domain.QuoteReference"
+                }
+            }
+        },
+        "domain.CalculateLateChargeRequest": {
+            "type": "object",
+            "required": [
+                "installments"
+            ],
+            "properties": {
+                "calculation_date": {
+                    "type": "string",
+                    "example": "2026-03-01"
+                },
+                "installments": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.LateChargeInstallment"
+                    }
+                },
+                "rule_set": {
+                    "type": "string",
+                    "example": "default"
+                }
+            }
+        },
+        "domain.CalculateLateChargeResponse": {
+            "type": "object",
+            "properties": {
+                "calculation_date": {
+                    "type": "string"
+                },
+                "charges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LateCharge"
+                    }
+                },
+                "rule_set": {
+                    "type": "string"
+                },
+                "total_charges": {
+                    "type": "integer"
+                },
+                "total_tawidh": {
+                    "type": "integer"
+                },
+                "total_tazir": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CalculateScheduleRequest": {
+            "type": "object",
+            "required": [
+                "tenor"
+            ],
+            "properties": {
+                "admin_fee": {
+                    "$ref": "#/definitions/domain.FinancingCharge"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "asset_price": {
+                    "type": "integer"
+                },
+                "broken_period_margin": {
+                    "type": "string",
+                    "example": "first_installment"
+                },
+                "business_day_convention": {
+                    "type": "string",
+                    "example": "modified_following"
+                },
+                "day_count_convention": {
+                    "type": "string",
+                    "example": "act/365"
+                },
+                "down_payment": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "down_payment_rate": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.2
+                },
+                "grace_mode": {
+                    "type": "string",
+                    "example": "margin_only"
+                },
+                "grace_periods": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "installment_profile": {
+                    "$ref": "#/definitions/domain.InstallmentProfile"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "flat"
+                },
+                "offered_on": {
+                    "description": "OfferedOn checks that the tenor was offered on that date instead of\non start_date, for terms agreed before disbursement.",
+                    "type": "string",
+                    "example": "2026-01-10"
+                },
+                "payment_day": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1,
+                    "example": 25
+                },
+                "payment_frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "pricing_version_id": {
+                    "description": "PricingVersionID prices with that recorded version of the tenors and\nproducts instead of the one in effect on the pricing date.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "product_code": {
+                    "type": "string",
+                    "example": "murabahah"
+                },
+                "rate_reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RateReview"
+                    }
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_up"
+                },
+                "rounding_unit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "stamp_duty": {
+                    "$ref": "#/definitions/domain.FinancingCharge"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-01-15"
+                },
+                "takaful_contribution": {
+                    "$ref": "#/definitions/domain.FinancingCharge"
+                },
+                "tenor": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ContractBalance": {
+            "type": "object",
+            "properties": {
+                "charges": {
+                    "type": "integer"
+                },
+                "margin": {
+                    "type": "integer"
+                },
+                "overdue": {
+                    "description": "Overdue is the part of Total that fell due before the as-of date.",
+                    "type": "integer"
+                },
+                "principal": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ContractInstallmentResponse": {
+            "type": "object",
+            "properties": {
+                "charge_due": {
+                    "type": "integer"
+                },
+                "charge_paid": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "installment_number": {
+                    "type": "integer"
+                },
+                "margin_due": {
+                    "type": "integer"
+                },
+                "margin_paid": {
+                    "type": "integer"
+                },
+                "outstanding": {
+                    "type": "integer"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "paid_on": {
+                    "type": "string"
+                },
+                "principal_due": {
+                    "type": "integer"
+                },
+                "principal_paid": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContractResponse": {
+            "type": "object",
+            "properties": {
+                "allocation_order": {
+                    "type": "string"
+                },
+                "application_id": {
+                    "type": "integer"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "capitalized_margin": {
+                    "type": "integer"
+                },
+                "credit_balance": {
+                    "type": "integer"
+                },
+                "disbursement_date": {
+                    "type": "string"
+                },
+                "financed_amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "installments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ContractInstallmentResponse"
+                    }
+                },
+                "outstanding": {
+                    "$ref": "#/definitions/domain.ContractBalance"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateApplicationRequest": {
+            "type": "object",
+            "required": [
+                "actor",
+                "quote_id",
+                "tenor"
+            ],
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "customer:8812"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "quote_id": {
+                    "type": "string",
+                    "example": "4f1c6d1e-9a0b-4c55-8d2e-3b7f9e6a1c20"
+                },
+                "tenor": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "domain.EarlySettlementRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "start_date",
+                "tenor"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "broken_period_margin": {
+                    "type": "string",
+                    "example": "first_installment"
+                },
+                "business_day_convention": {
+                    "type": "string",
+                    "example": "modified_following"
+                },
+                "day_count_convention": {
+                    "type": "string",
+                    "example": "act/365"
+                },
+                "grace_mode": {
+                    "type": "string",
+                    "example": "margin_only"
+                },
+                "grace_periods": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "installment_profile": {
+                    "$ref": "#/definitions/domain.InstallmentProfile"
+                },
+                "installments_paid": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "method": {
+                    "type": "string",
+                    "example": "flat"
+                },
+                "payment_day": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1,
+                    "example": 25
+                },
+                "payment_frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "pricing_version_id": {
+                    "description": "PricingVersionID prices with that recorded version of the tenors and\nproducts instead of the one in effect on the pricing date.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "product_code": {
+                    "type": "string",
+                    "example": "murabahah"
+                },
+                "rate_reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RateReview"
+                    }
+                },
+                "rebate_rate": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_up"
+                },
+                "rounding_unit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "settlement_date": {
+                    "type": "string",
+                    "example": "2026-07-01"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-01-15"
+                },
+                "tenor": {
+                    "type": "integer"
+                },
+                "unearned_margin_method": {
+                    "type": "string",
+                    "example": "schedule"
+                }
+            }
+        },
+        "domain.EarlySettlementResponse": {
+            "type": "object",
+            "properties": {
+                "earned_margin": {
+                    "type": "integer"
+                },
+                "installment_count": {
+                    "type": "integer"
+                },
+                "installments_due": {
+                    "type": "integer"
+                },
+                "installments_paid": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "outstanding_margin": {
+                    "type": "integer"
+                },
+                "outstanding_principal": {
+                    "type": "integer"
+                },
+                "payment_frequency": {
+                    "type": "string"
+                },
+                "pricing_version_id": {
+                    "type": "integer"
+                },
+                "rebate": {
+                    "type": "integer"
+                },
+                "rebate_rate": {
+                    "type": "number"
+                },
+                "settlement_amount": {
+                    "type": "integer"
+                },
+                "settlement_date": {
+                    "type": "string"
+                },
+                "tenor": {
+                    "type": "integer"
+                },
+                "unearned_margin": {
+                    "type": "integer"
+                },
+                "unearned_margin_method": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ExcludedTenor": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "amount_below_minimum"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "tenor 24 requires a financed amount of at least 3000000"
+                },
+                "tenor": {
+                    "type": "integer",
+                    "example": 24
+                }
+            }
+        },
+        "domain.FinancingBreakdown": {
+            "type": "object",
+            "properties": {
+                "asset_price": {
+                    "type": "integer"
+                },
+                "down_payment": {
+                    "type": "integer"
+                },
+                "financed_amount": {
+                    "type": "integer"
+                },
+                "financed_charges": {
+                    "type": "integer"
+                },
+                "product_fee": {
+                    "type": "integer"
+                },
+                "upfront_charges": {
+                    "type": "integer"
+                },
+                "upfront_payment": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FinancingCharge": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "financed": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.InstallmentCalculation": {
+            "type": "object",
+            "properties": {
+                "affordability": {
+                    "$ref": "#/definitions/domain.TenorAffordability"
+                },
+                "annual_margin_rate": {
+                    "type": "number"
+                },
+                "broken_period": {
+                    "$ref": "#/definitions/domain.BrokenPeriod"
+                },
+                "contract_type": {
+                    "type": "string"
+                },
+                "effective_annual_rate": {
+                    "type": "number"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "grace_mode": {
+                    "type": "string"
+                },
+                "grace_periods": {
+                    "type": "integer"
+                },
+                "installment_count": {
+                    "type": "integer"
+                },
+                "installment_profile": {
+                    "type": "string"
+                },
+                "last_installment": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "monthly_installment": {
+                    "type": "integer"
+                },
+                "partnership": {
+                    "$ref": "#/definitions/domain.PartnershipSummary"
+                },
+                "payment_frequency": {
+                    "type": "string"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "profit_type": {
+                    "type": "string"
+                },
+                "tenor": {
+                    "type": "integer"
+                },
+                "total_margin": {
+                    "type": "integer"
+                },
+                "total_payment": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.InstallmentProfile": {
+            "type": "object",
+            "properties": {
+                "balloon_rate": {
+                    "type": "number",
+                    "example": 0.3
+                },
+                "high_factor": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "high_months": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "low_factor": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "low_months": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "step_months": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "step_rate": {
+                    "type": "number",
+                    "example": 0.1
+                },
+                "type": {
+                    "type": "string",
+                    "example": "step_up"
+                }
+            }
+        },
+        "domain.InstallmentScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "annual_margin_rate": {
+                    "type": "number"
+                },
+                "broken_period": {
+                    "$ref": "#/definitions/domain.BrokenPeriod"
+                },
+                "business_day_convention": {
+                    "type": "string"
+                },
+                "contract_type": {
+                    "type": "string"
+                },
+                "effective_annual_rate": {
+                    "type": "number"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "financing": {
+                    "$ref": "#/definitions/domain.FinancingBreakdown"
+                },
+                "grace_mode": {
+                    "type": "string"
+                },
+                "grace_periods": {
+                    "type": "integer"
+                },
+                "installment_count": {
+                    "type": "integer"
+                },
+                "installment_profile": {
+                    "type": "string"
+                },
+                "last_installment": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "monthly_installment": {
+                    "type": "integer"
+                },
+                "partnership": {
+                    "$ref": "#/definitions/domain.PartnershipSummary"
+                },
+                "payment_day": {
+                    "type": "integer"
+                },
+                "payment_frequency": {
+                    "type": "string"
+                },
+                "pricing_version_id": {
+                    "type": "integer"
+                },
+                "principal": {
+                    "type": "integer"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "profit_type": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.InstallmentScheduleRow"
+                    }
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "tenor": {
+                    "type": "integer"
+                },
+                "total_margin": {
+                    "type": "integer"
+                },
+                "total_payment": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.InstallmentScheduleRow": {
+            "type": "object",
+            "properties": {
+                "balloon": {
+                    "type": "integer"
+                },
+                "broken_period_margin": {
+                    "type": "integer"
+                },
+                "capitalized_margin": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "grace": {
+                    "type": "boolean"
+                },
+                "installment": {
+                    "type": "integer"
+                },
+                "installment_number": {
+                    "type": "integer"
+                },
+                "margin": {
+                    "type": "integer"
+                },
+                "ownership": {
+                    "$ref": "#/definitions/domain.Ownership"
+                },
+                "principal": {
+                    "type": "integer"
+                },
+                "remaining_balance": {
+                    "type": "integer"
+                },
+                "rent_rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.LateCharge": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "capped": {
+                    "type": "boolean"
+                },
+                "charge_type": {
+                    "type": "string"
+                },
+                "days_late": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "installment_number": {
+                    "type": "integer"
+                },
+                "uncapped_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LateChargeInstallment": {
+            "type": "object",
+            "required": [
+                "due_date"
+            ],
+            "properties": {
+                "due_date": {
+                    "type": "string",
+                    "example": "2026-02-15"
+                },
+                "installment": {
+                    "type": "integer"
+                },
+                "installment_number": {
+                    "type": "integer"
+                },
+                "paid_date": {
+                    "type": "string",
+                    "example": "2026-02-20"
+                }
+            }
+        },
+        "domain.ListPaymentsResponse": {
+            "type": "object",
+            "properties": {
+                "contract_id": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PaymentResponse"
+                    }
+                }
+            }
+        },
+        "domain.ListQuotesResponse": {
+            "type": "object",
+            "properties": {
+                "quotes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QuoteSummary"
+                    }
+                }
+            }
+        },
+        "domain.ListTenorsResponse": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string"
+                },
+                "tenors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TenorResponse"
+                    }
+                }
+            }
+        },
+        "domain.MaxFinancingCalculation": {
+            "type": "object",
+            "properties": {
+                "affordability": {
+                    "$ref": "#/definitions/domain.TenorAffordability"
+                },
+                "annual_margin_rate": {
+                    "type": "number"
+                },
+                "broken_period": {
+                    "$ref": "#/definitions/domain.BrokenPeriod"
+                },
+                "contract_type": {
+                    "type": "string"
+                },
+                "effective_annual_rate": {
+                    "type": "number"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "grace_mode": {
+                    "type": "string"
+                },
+                "grace_periods": {
+                    "type": "integer"
+                },
+                "installment_count": {
+                    "type": "integer"
+                },
+                "installment_profile": {
+                    "type": "string"
+                },
+                "last_installment": {
+                    "type": "integer"
+                },
+                "max_amount": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "monthly_installment": {
+                    "type": "integer"
+                },
+                "partnership": {
+                    "$ref": "#/definitions/domain.PartnershipSummary"
+                },
+                "payment_frequency": {
+                    "type": "string"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "profit_type": {
+                    "type": "string"
+                },
+                "tenor": {
+                    "type": "integer"
+                },
+                "total_margin": {
+                    "type": "integer"
+                },
+                "total_payment": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MaxFinancingRequest": {
+            "type": "object",
+            "required": [
+                "target_installment"
+            ],
+            "properties": {
+                "grace_mode": {
+                    "type": "string",
+                    "example": "margin_only"
+                },
+                "grace_periods": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "installment_profile": {
+                    "$ref": "#/definitions/domain.InstallmentProfile"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "flat"
+                },
+                "payment_frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "pricing_version_id": {
+                    "description": "PricingVersionID prices with that recorded version of the tenors and\nproducts instead of the one in effect on the pricing date.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "product_code": {
+                    "type": "string",
+                    "example": "murabahah"
+                },
+                "rate_reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RateReview"
+                    }
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_up"
+                },
+                "rounding_unit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "target_installment": {
+                    "type": "integer"
+                },
+                "tenors": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.MaxFinancingResponse": {
+            "type": "object",
+            "properties": {
+                "accepted_tenors": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "calculations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.MaxFinancingCalculation"
+                    }
+                },
+                "excluded_tenors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ExcludedTenor"
+                    }
+                },
+                "pricing_version_id": {
+                    "type": "integer"
+                },
+                "target_installment": {
                     "type": "integer"
+                }
+            }
+        },
+        "domain.OpenContractRequest": {
+            "type": "object",
+            "required": [
+                "application_id"
+            ],
+            "properties": {
+                "allocation_order": {
+                    "type": "string",
+                    "example": "charges_margin_principal"
                 },
-                "total_payment": {
+                "application_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "broken_period_margin": {
+                    "type": "string",
+                    "example": "first_installment"
+                },
+                "business_day_convention": {
+                    "type": "string",
+                    "example": "modified_following"
+                },
+                "day_count_convention": {
+                    "type": "string",
+                    "example": "act/365"
+                },
+                "disbursement_date": {
+                    "type": "string",
+                    "example": "2026-10-20"
+                },
+                "payment_day": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1,
+                    "example": 25
+                }
+            }
+        },
+        "domain.Ownership": {
+            "type": "object",
+            "properties": {
+                "bank_share": {
+                    "type": "number"
+                },
+                "customer_share": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.PartnershipSummary": {
+            "type": "object",
+            "properties": {
+                "asset_value": {
+                    "type": "integer"
+                },
+                "customer_contribution": {
+                    "type": "integer"
+                },
+                "initial_bank_share": {
+                    "type": "number"
+                },
+                "total_acquisition": {
+                    "type": "integer"
+                },
+                "total_cost": {
+                    "type": "integer"
+                },
+                "total_rent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PaymentAllocationResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "component": {
+                    "type": "string"
+                },
+                "installment_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "allocated": {
+                    "type": "integer"
+                },
+                "allocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PaymentAllocationResponse"
+                    }
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "contract_id": {
+                    "type": "integer"
+                },
+                "excess": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paid_on": {
+                    "type": "string"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PostPaymentRequest": {
+            "type": "object",
+            "required": [
+                "actor",
+                "amount",
+                "reference"
+            ],
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "channel:virtual-account"
+                },
+                "amount": {
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "paid_on": {
+                    "type": "string",
+                    "example": "2026-11-20"
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "VA-20261120-0001"
+                }
+            }
+        },
+        "domain.PostPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "contract": {
+                    "$ref": "#/definitions/domain.ContractResponse"
+                },
+                "payment": {
+                    "$ref": "#/definitions/domain.PaymentResponse"
+                }
+            }
+        },
+        "domain.PrepaymentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "prepayment_amount",
+                "start_date",
+                "tenor"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "broken_period_margin": {
+                    "type": "string",
+                    "example": "first_installment"
+                },
+                "business_day_convention": {
+                    "type": "string",
+                    "example": "modified_following"
+                },
+                "day_count_convention": {
+                    "type": "string",
+                    "example": "act/365"
+                },
+                "grace_mode": {
+                    "type": "string",
+                    "example": "margin_only"
+                },
+                "grace_periods": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "installment_profile": {
+                    "$ref": "#/definitions/domain.InstallmentProfile"
+                },
+                "installments_paid": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "method": {
+                    "type": "string",
+                    "example": "flat"
+                },
+                "option": {
+                    "type": "string",
+                    "example": "reduce_installment"
+                },
+                "payment_day": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1,
+                    "example": 25
+                },
+                "payment_frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "prepayment_amount": {
+                    "type": "integer",
+                    "example": 3000000
+                },
+                "prepayment_date": {
+                    "type": "string",
+                    "example": "2026-07-20"
+                },
+                "pricing_version_id": {
+                    "description": "PricingVersionID prices with that recorded version of the tenors and\nproducts instead of the one in effect on the pricing date.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "product_code": {
+                    "type": "string",
+                    "example": "murabahah"
+                },
+                "rate_reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RateReview"
+                    }
+                },
+                "rounding_mode": {
+                    "type": "string",
+                    "example": "half_up"
+                },
+                "rounding_unit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-01-15"
+                },
+                "tenor": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PrepaymentResponse": {
+            "type": "object",
+            "properties": {
+                "accrued_margin": {
+                    "description": "AccruedMargin is the running installment's margin on the prepaid\nprincipal earned up to the prepayment date, due with the prepayment.",
+                    "type": "integer"
+                },
+                "installments_paid": {
+                    "type": "integer"
+                },
+                "margin_saved": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "option": {
+                    "type": "string"
+                },
+                "original": {
+                    "$ref": "#/definitions/domain.PrepaymentTerms"
+                },
+                "outstanding_principal": {
+                    "type": "integer"
+                },
+                "payment_frequency": {
+                    "type": "string"
+                },
+                "prepayment_amount": {
+                    "type": "integer"
+                },
+                "prepayment_date": {
+                    "type": "string"
+                },
+                "pricing_version_id": {
+                    "type": "integer"
+                },
+                "remaining_principal": {
+                    "type": "integer"
+                },
+                "revised": {
+                    "$ref": "#/definitions/domain.PrepaymentTerms"
+                },
+                "schedule": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.InstallmentScheduleRow"
+                    }
+                },
+                "tenor": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PrepaymentTerms": {
+            "type": "object",
+            "properties": {
+                "installment": {
+                    "type": "integer"
+                },
+                "last_installment": {
+                    "type": "integer"
+                },
+                "maturity_date": {
+                    "type": "string"
+                },
+                "remaining_installments": {
+                    "type": "integer"
+                },
+                "remaining_margin": {
+                    "type": "integer"
+                },
+                "remaining_payment": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.QuoteReference": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "quote_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "pricing_version_id": {
+                    "type": "integer"
+                },
+                "quote_id": {
+                    "type": "string"
+                },
+                "request": {
+                    "$ref": "#/definitions/domain.CalculateInstallmentRequest"
+                },
+                "result": {
+                    "$ref": "#/definitions/domain.CalculateInstallmentResponse"
+                }
+            }
+        },
+        "domain.QuoteSummary": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "financed_amount": {
+                    "type": "integer"
+                },
+                "pricing_version_id": {
                     "type": "integer"
+                },
+                "quote_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RateReview": {
+            "type": "object",
+            "properties": {
+                "annual_rate": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.12
+                },
+                "from_month": {
+                    "type": "integer",
+                    "example": 37
+                }
+            }
+        },
+        "domain.TenorAffordability": {
+            "type": "object",
+            "properties": {
+                "debt_service_ratio": {
+                    "type": "number"
+                },
+                "exceeds_max_dsr": {
+                    "type": "boolean"
+                },
+                "monthly_obligation": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.TenorRequest": {
+            "type": "object",
+            "required": [
+                "tenor_value"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "2026-11-01"
+                },
+                "effective_margin_rate": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 0.25
+                },
+                "effective_to": {
+                    "type": "string",
+                    "example": "2027-12-31"
+                },
+                "flat_margin_rate": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0,
+                    "example": 0.25
+                },
+                "max_amount": {
+                    "type": "integer"
+                },
+                "min_amount": {
+                    "type": "integer",
+                    "example": 5000000
+                },
+                "payment_frequencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monthly"
+                    ]
+                },
+                "tenor_value": {
+                    "type": "integer",
+                    "example": 48
+                }
+            }
+        },
+        "domain.TenorResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "effective_margin_rate": {
+                    "type": "number"
+                },
+                "effective_to": {
+                    "type": "string"
+                },
+                "flat_margin_rate": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_amount": {
+                    "type": "integer"
+                },
+                "min_amount": {
+                    "type": "integer"
+                },
+                "payment_frequencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenor_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.TransitionApplicationRequest": {
+            "type": "object",
+            "required": [
+                "actor",
+                "status"
+            ],
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "officer:017"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Documents complete"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "submitted"
                 }
             }
         }
@@ -0,0 +1,19 @@
+package domain
+
+type AffordabilityInput struct {
+	MonthlyIncome       int64 `json:"monthly_income" binding:"omitempty,gt=0"`
+	ExistingObligations int64 `json:"existing_obligations" binding:"gte=0"`
+}
+
+type TenorAffordability struct {
+	MonthlyObligation int64   `json:"monthly_obligation"`
+	DebtServiceRatio  float64 `json:"debt_service_ratio"`
+	ExceedsMaxDSR     bool    `json:"exceeds_max_dsr"`
+}
+
+type AffordabilitySummary struct {
+	MonthlyIncome       int64   `json:"monthly_income"`
+	ExistingObligations int64   `json:"existing_obligations"`
+	MaxDSR              float64 `json:"max_dsr"`
+	RecommendedTenor    *int    `json:"recommended_tenor"`
+}
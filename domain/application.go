@@ -0,0 +1,98 @@
+package domain
+
+const (
+	ApplicationDraft       = "draft"
+	ApplicationSubmitted   = "submitted"
+	ApplicationUnderReview = "under_review"
+	ApplicationApproved    = "approved"
+	ApplicationRejected    = "rejected"
+	ApplicationDisbursed   = "disbursed"
+	ApplicationCancelled   = "cancelled"
+)
+
+const (
+	CodeQuoteExpired        = "quote_expired"
+	CodeTenorNotQuoted      = "tenor_not_quoted"
+	CodeInvalidTransition   = "invalid_transition"
+	CodeApplicationConflict = "application_conflict"
+)
+
+// Application is a financing application for one tenor of a saved quote.
+// The quoted terms are copied so the application outlives the quote.
+type Application struct {
+	ID                 int64  `gorm:"primaryKey"`
+	QuoteID            string `gorm:"column:quote_id;not null"`
+	Channel            string `gorm:"column:channel"`
+	Tenor              int    `gorm:"column:tenor;not null"`
+	PricingVersionID   int64  `gorm:"column:pricing_version_id"`
+	FinancedAmount     int64  `gorm:"column:financed_amount;not null"`
+	MonthlyInstallment int64  `gorm:"column:monthly_installment;not null"`
+	TotalPayment       int64  `gorm:"column:total_payment;not null"`
+	Status             string `gorm:"column:status;not null"`
+	CreatedBy          string `gorm:"column:created_by;not null"`
+	CreatedAt          int64  `gorm:"column:created_at;not null"`
+	UpdatedAt          int64  `gorm:"column:updated_at;not null"`
+}
+
+func (Application) TableName() string {
+	return "applications"
+}
+
+// ApplicationTransition records one status change. The first transition of
+// every application has an empty FromStatus and creates it as a draft.
+type ApplicationTransition struct {
+	ID            int64  `gorm:"primaryKey"`
+	ApplicationID int64  `gorm:"column:application_id;not null"`
+	FromStatus    string `gorm:"column:from_status"`
+	ToStatus      string `gorm:"column:to_status;not null"`
+	Actor         string `gorm:"column:actor;not null"`
+	Note          string `gorm:"column:note"`
+	CreatedAt     int64  `gorm:"column:created_at;not null"`
+}
+
+func (ApplicationTransition) TableName() string {
+	return "application_transitions"
+}
+
+type CreateApplicationRequest struct {
+	QuoteID string `json:"quote_id" binding:"required" example:"4f1c6d1e-9a0b-4c55-8d2e-3b7f9e6a1c20"`
+	Tenor   int    `json:"tenor" binding:"required,gt=0" example:"12"`
+	Actor   string `json:"actor" binding:"required,max=64" example:"customer:8812"`
+	Note    string `json:"note" binding:"max=255"`
+}
+
+type TransitionApplicationRequest struct {
+	Status string `json:"status" binding:"required" example:"submitted"`
+	Actor  string `json:"actor" binding:"required,max=64" example:"officer:017"`
+	Note   string `json:"note" binding:"max=255" example:"Documents complete"`
+}
+
+type ApplicationResponse struct {
+	ID                 int64    `json:"id"`
+	QuoteID            string   `json:"quote_id"`
+	Channel            string   `json:"channel,omitempty"`
+	Tenor              int      `json:"tenor"`
+	PricingVersionID   int64    `json:"pricing_version_id,omitempty"`
+	FinancedAmount     int64    `json:"financed_amount"`
+	MonthlyInstallment int64    `json:"monthly_installment"`
+	TotalPayment       int64    `json:"total_payment"`
+	Status             string   `json:"status"`
+	NextStatuses       []string `json:"next_statuses"`
+	CreatedBy          string   `json:"created_by"`
+	CreatedAt          string   `json:"created_at"`
+	UpdatedAt          string   `json:"updated_at"`
+}
+
+type ApplicationTransitionResponse struct {
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	Actor      string `json:"actor"`
+	Note       string `json:"note,omitempty"`
+	At         string `json:"at"`
+}
+
+type ApplicationTransitionsResponse struct {
+	ApplicationID int64                           `json:"application_id"`
+	Status        string                          `json:"status"`
+	Transitions   []ApplicationTransitionResponse `json:"transitions"`
+}
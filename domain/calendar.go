@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+const (
+	HolidayNational    = "national"
+	HolidayCutiBersama = "cuti_bersama"
+)
+
+// Business-day conventions move a due date that falls on a weekend or
+// holiday. With modified following the date moves forward unless that
+// leaves the month, in which case it moves back.
+const (
+	BusinessDayNone              = "none"
+	BusinessDayFollowing         = "following"
+	BusinessDayModifiedFollowing = "modified_following"
+	BusinessDayPreceding         = "preceding"
+)
+
+const (
+	DayCount30360        = "30/360"
+	DayCountActual365    = "act/365"
+	DayCountActualActual = "act/act"
+)
+
+// Broken-period margin treatments: charge the margin of an irregular first
+// period with the first installment, spread it over every installment, or
+// ignore it and price the first period as a regular one.
+const (
+	BrokenPeriodNone             = "none"
+	BrokenPeriodFirstInstallment = "first_installment"
+	BrokenPeriodSpread           = "spread"
+)
+
+type Holiday struct {
+	ID        int64     `gorm:"primaryKey"`
+	Date      time.Time `gorm:"column:holiday_date;type:date;not null"`
+	Name      string    `gorm:"column:name;not null"`
+	Type      string    `gorm:"column:holiday_type;not null"`
+	CreatedAt int64     `gorm:"autoCreateTime:milli"`
+	UpdatedAt int64     `gorm:"autoUpdateTime:milli"`
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
+
+// DueDateOptions place the installments of a schedule on the calendar.
+// PaymentDay fixes the day of the month for monthly payments; days 29 to 31
+// fall on the last day of shorter months.
+type DueDateOptions struct {
+	PaymentDay            int    `json:"payment_day" binding:"omitempty,min=1,max=31" example:"25"`
+	BusinessDayConvention string `json:"business_day_convention" example:"modified_following"`
+	BrokenPeriodMargin    string `json:"broken_period_margin" example:"first_installment"`
+	DayCountConvention    string `json:"day_count_convention" example:"act/365"`
+}
+
+// BrokenPeriod is the stretch between disbursement and the start of the first
+// regular period. Days and Margin are negative when the first period is
+// shorter than a regular one.
+type BrokenPeriod struct {
+	DisbursementDate   string  `json:"disbursement_date"`
+	RegularStartDate   string  `json:"regular_start_date"`
+	Days               int     `json:"days"`
+	DayCountConvention string  `json:"day_count_convention"`
+	YearFraction       float64 `json:"year_fraction"`
+	Margin             int64   `json:"margin"`
+	Treatment          string  `json:"treatment"`
+}
@@ -0,0 +1,203 @@
+package domain
+
+import "time"
+
+const (
+	// AllocationChargesMarginPrincipal settles every due charge first, then
+	// every due margin, then every due principal, oldest installment first
+	// within each component.
+	AllocationChargesMarginPrincipal = "charges_margin_principal"
+	// AllocationOldestInstallment settles each due installment in full
+	// (charges, margin, principal) before moving to the next.
+	AllocationOldestInstallment = "oldest_installment"
+)
+
+const (
+	ContractActive  = "active"
+	ContractSettled = "settled"
+)
+
+const (
+	ComponentCharge    = "charge"
+	ComponentMargin    = "margin"
+	ComponentPrincipal = "principal"
+)
+
+const (
+	InstallmentPaid          = "paid"
+	InstallmentPartiallyPaid = "partially_paid"
+	InstallmentUnpaid        = "unpaid"
+)
+
+const (
+	CodeApplicationNotDisbursed = "application_not_disbursed"
+	CodeContractExists          = "contract_exists"
+	CodeContractSettled         = "contract_settled"
+	CodeContractConflict        = "contract_conflict"
+	CodeDuplicatePayment        = "duplicate_payment"
+	CodeTermsChanged            = "terms_changed"
+)
+
+// Contract is the repayment ledger of a disbursed application. Version is
+// bumped by every posting so concurrent postings can detect each other.
+type Contract struct {
+	ID               int64     `gorm:"primaryKey"`
+	ApplicationID    int64     `gorm:"column:application_id;not null"`
+	AllocationOrder  string    `gorm:"column:allocation_order;not null"`
+	DisbursementDate time.Time `gorm:"column:disbursement_date;type:date;not null"`
+	FinancedAmount   int64     `gorm:"column:financed_amount;not null"`
+	// CapitalizedMargin is the grace-period margin added to the principal
+	// the installments repay.
+	CapitalizedMargin int64  `gorm:"column:capitalized_margin;not null"`
+	CreditBalance     int64  `gorm:"column:credit_balance;not null"`
+	Status            string `gorm:"column:status;not null"`
+	Version           int64  `gorm:"column:version;not null"`
+	CreatedAt         int64  `gorm:"column:created_at;not null"`
+	UpdatedAt         int64  `gorm:"column:updated_at;not null"`
+}
+
+func (Contract) TableName() string {
+	return "contracts"
+}
+
+type ContractInstallment struct {
+	ID                int64      `gorm:"primaryKey"`
+	ContractID        int64      `gorm:"column:contract_id;not null"`
+	InstallmentNumber int        `gorm:"column:installment_number;not null"`
+	DueDate           time.Time  `gorm:"column:due_date;type:date;not null"`
+	PrincipalDue      int64      `gorm:"column:principal_due;not null"`
+	MarginDue         int64      `gorm:"column:margin_due;not null"`
+	ChargeDue         int64      `gorm:"column:charge_due;not null"`
+	PrincipalPaid     int64      `gorm:"column:principal_paid;not null"`
+	MarginPaid        int64      `gorm:"column:margin_paid;not null"`
+	ChargePaid        int64      `gorm:"column:charge_paid;not null"`
+	PaidOn            *time.Time `gorm:"column:paid_on;type:date"`
+}
+
+func (ContractInstallment) TableName() string {
+	return "contract_installments"
+}
+
+// Outstanding returns what is still owed on the installment.
+func (i ContractInstallment) Outstanding() int64 {
+	return i.PrincipalDue + i.MarginDue + i.ChargeDue - i.PrincipalPaid - i.MarginPaid - i.ChargePaid
+}
+
+type Payment struct {
+	ID          int64               `gorm:"primaryKey"`
+	ContractID  int64               `gorm:"column:contract_id;not null"`
+	Reference   string              `gorm:"column:reference;not null"`
+	Amount      int64               `gorm:"column:amount;not null"`
+	PaidOn      time.Time           `gorm:"column:paid_on;type:date;not null"`
+	Allocated   int64               `gorm:"column:allocated;not null"`
+	Excess      int64               `gorm:"column:excess;not null"`
+	Actor       string              `gorm:"column:actor;not null"`
+	CreatedAt   int64               `gorm:"column:created_at;not null"`
+	Allocations []PaymentAllocation `gorm:"foreignKey:PaymentID"`
+}
+
+func (Payment) TableName() string {
+	return "contract_payments"
+}
+
+type PaymentAllocation struct {
+	ID                int64  `gorm:"primaryKey"`
+	PaymentID         int64  `gorm:"column:payment_id;not null"`
+	InstallmentNumber int    `gorm:"column:installment_number;not null"`
+	Component         string `gorm:"column:component;not null"`
+	Amount            int64  `gorm:"column:amount;not null"`
+}
+
+func (PaymentAllocation) TableName() string {
+	return "payment_allocations"
+}
+
+type OpenContractRequest struct {
+	ApplicationID    int64  `json:"application_id" binding:"required,gt=0" example:"1"`
+	DisbursementDate string `json:"disbursement_date" example:"2026-10-20"`
+	AllocationOrder  string `json:"allocation_order" example:"charges_margin_principal"`
+	DueDateOptions
+}
+
+type ContractQuery struct {
+	AsOf string `form:"as_of" example:"2026-12-01"`
+}
+
+type PostPaymentRequest struct {
+	Amount    int64  `json:"amount" binding:"required,gt=0" example:"1000000"`
+	PaidOn    string `json:"paid_on" example:"2026-11-20"`
+	Reference string `json:"reference" binding:"required,max=64" example:"VA-20261120-0001"`
+	Actor     string `json:"actor" binding:"required,max=64" example:"channel:virtual-account"`
+}
+
+type AssessChargeRequest struct {
+	InstallmentNumber int   `json:"installment_number" binding:"required,gt=0" example:"1"`
+	Amount            int64 `json:"amount" binding:"required,gt=0" example:"25000"`
+}
+
+type ContractBalance struct {
+	Principal int64 `json:"principal"`
+	Margin    int64 `json:"margin"`
+	Charges   int64 `json:"charges"`
+	Total     int64 `json:"total"`
+	// Overdue is the part of Total that fell due before the as-of date.
+	Overdue int64 `json:"overdue"`
+}
+
+type ContractInstallmentResponse struct {
+	InstallmentNumber int    `json:"installment_number"`
+	DueDate           string `json:"due_date"`
+	PrincipalDue      int64  `json:"principal_due"`
+	MarginDue         int64  `json:"margin_due"`
+	ChargeDue         int64  `json:"charge_due"`
+	PrincipalPaid     int64  `json:"principal_paid"`
+	MarginPaid        int64  `json:"margin_paid"`
+	ChargePaid        int64  `json:"charge_paid"`
+	Outstanding       int64  `json:"outstanding"`
+	Status            string `json:"status"`
+	Overdue           bool   `json:"overdue"`
+	PaidOn            string `json:"paid_on,omitempty"`
+}
+
+type ContractResponse struct {
+	ID                int64                         `json:"id"`
+	ApplicationID     int64                         `json:"application_id"`
+	Status            string                        `json:"status"`
+	AllocationOrder   string                        `json:"allocation_order"`
+	DisbursementDate  string                        `json:"disbursement_date"`
+	FinancedAmount    int64                         `json:"financed_amount"`
+	CapitalizedMargin int64                         `json:"capitalized_margin,omitempty"`
+	AsOf              string                        `json:"as_of"`
+	Outstanding       ContractBalance               `json:"outstanding"`
+	CreditBalance     int64                         `json:"credit_balance"`
+	Installments      []ContractInstallmentResponse `json:"installments"`
+}
+
+type PaymentAllocationResponse struct {
+	InstallmentNumber int    `json:"installment_number"`
+	Component         string `json:"component"`
+	Amount            int64  `json:"amount"`
+}
+
+type PaymentResponse struct {
+	ID          int64                       `json:"id"`
+	ContractID  int64                       `json:"contract_id"`
+	Reference   string                      `json:"reference"`
+	Amount      int64                       `json:"amount"`
+	PaidOn      string                      `json:"paid_on"`
+	Allocated   int64                       `json:"allocated"`
+	Excess      int64                       `json:"excess"`
+	Actor       string                      `json:"actor"`
+	PostedAt    string                      `json:"posted_at"`
+	Allocations []PaymentAllocationResponse `json:"allocations,omitempty"`
+}
+
+type PostPaymentResponse struct {
+	Payment  PaymentResponse  `json:"payment"`
+	Contract ContractResponse `json:"contract"`
+}
+
+type ListPaymentsResponse struct {
+	ContractID int64             `json:"contract_id"`
+	Payments   []PaymentResponse `json:"payments"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+const (
+	UnearnedMarginSchedule = "schedule"
+	UnearnedMarginRuleOf78 = "rule_of_78"
+)
+
+type EarlySettlementRequest struct {
+	Amount               int64    `json:"amount" binding:"required,gt=0"`
+	Tenor                int      `json:"tenor" binding:"required,gt=0"`
+	StartDate            string   `json:"start_date" binding:"required" example:"2026-01-15"`
+	InstallmentsPaid     int      `json:"installments_paid" binding:"gte=0"`
+	SettlementDate       string   `json:"settlement_date" example:"2026-07-01"`
+	UnearnedMarginMethod string   `json:"unearned_margin_method" example:"schedule"`
+	RebateRate           *float64 `json:"rebate_rate" binding:"omitempty,gte=0,lte=1" example:"1"`
+	PricingOptions
+	DueDateOptions
+}
+
+type EarlySettlementResponse struct {
+	Tenor                int     `json:"tenor"`
+	PricingVersionID     int64   `json:"pricing_version_id,omitempty"`
+	Method               string  `json:"method"`
+	PaymentFrequency     string  `json:"payment_frequency"`
+	InstallmentCount     int     `json:"installment_count"`
+	InstallmentsPaid     int     `json:"installments_paid"`
+	InstallmentsDue      int     `json:"installments_due"`
+	SettlementDate       string  `json:"settlement_date"`
+	OutstandingPrincipal int64   `json:"outstanding_principal"`
+	OutstandingMargin    int64   `json:"outstanding_margin"`
+	EarnedMargin         int64   `json:"earned_margin"`
+	UnearnedMargin       int64   `json:"unearned_margin"`
+	UnearnedMarginMethod string  `json:"unearned_margin_method"`
+	RebateRate           float64 `json:"rebate_rate"`
+	Rebate               int64   `json:"rebate"`
+	SettlementAmount     int64   `json:"settlement_amount"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+// Codes carried by validation errors and excluded tenors.
+const (
+	CodeAmountBelowMinimum  = "amount_below_minimum"
+	CodeAmountAboveMaximum  = "amount_above_maximum"
+	CodeFrequencyNotAllowed = "frequency_not_allowed"
+	CodeGraceTooLong        = "grace_too_long"
+	CodeTenorNotAvailable   = "tenor_not_available"
+	CodeTenorOutOfRange     = "tenor_out_of_range"
+	CodeTenorOffStep        = "tenor_off_step"
+)
+
+// ExcludedTenor is a master tenor left out of a quote, with the reason.
+type ExcludedTenor struct {
+	Tenor  int    `json:"tenor" example:"24"`
+	Code   string `json:"code" example:"amount_below_minimum"`
+	Reason string `json:"reason" example:"tenor 24 requires a financed amount of at least 3000000"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+type FinancingCharge struct {
+	Amount   int64 `json:"amount" binding:"gte=0"`
+	Financed bool  `json:"financed"`
+}
+
+type FinancingComponents struct {
+	DownPayment         int64            `json:"down_payment" binding:"gte=0"`
+	DownPaymentRate     float64          `json:"down_payment_rate" binding:"gte=0,lt=1" example:"0.2"`
+	AdminFee            *FinancingCharge `json:"admin_fee"`
+	TakafulContribution *FinancingCharge `json:"takaful_contribution"`
+	StampDuty           *FinancingCharge `json:"stamp_duty"`
+}
+
+type FinancingBreakdown struct {
+	AssetPrice      int64 `json:"asset_price"`
+	DownPayment     int64 `json:"down_payment"`
+	FinancedCharges int64 `json:"financed_charges"`
+	UpfrontCharges  int64 `json:"upfront_charges"`
+	ProductFee      int64 `json:"product_fee"`
+	FinancedAmount  int64 `json:"financed_amount"`
+	UpfrontPayment  int64 `json:"upfront_payment"`
+}
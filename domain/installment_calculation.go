@@ -1,16 +1,54 @@
 package domain
 
 type CalculateInstallmentRequest struct {
-	Amount int64 `json:"amount" binding:"required,gt=0"`
+	Amount     int64 `json:"amount" binding:"required_without=AssetPrice,omitempty,gt=0"`
+	AssetPrice int64 `json:"asset_price" binding:"omitempty,gt=0"`
+	// Tenors limits the quote to these tenors in months; empty quotes every
+	// tenor in the tenor master.
+	Tenors []int `json:"tenors" binding:"omitempty,dive,gt=0" example:"18"`
+	// AsOf prices with the tenor master as it stood at the close of that
+	// date, or at that instant when given as an RFC 3339 timestamp; empty
+	// means now.
+	AsOf string `json:"as_of" example:"2026-09-15"`
+	// SaveQuote stores the result as a quote that can be retrieved by ID
+	// until it expires; Channel tags it for listing.
+	SaveQuote bool   `json:"save_quote"`
+	Channel   string `json:"channel" binding:"omitempty,max=32" example:"mobile"`
+	PricingOptions
+	FinancingComponents
+	AffordabilityInput
 }
 
 type InstallmentCalculation struct {
-	Tenor              int   `json:"tenor"`
-	MonthlyInstallment int64 `json:"monthly_installment"`
-	TotalMargin        int64 `json:"total_margin"`
-	TotalPayment       int64 `json:"total_payment"`
+	Tenor               int                 `json:"tenor"`
+	Product             string              `json:"product,omitempty"`
+	ContractType        string              `json:"contract_type"`
+	ProfitType          string              `json:"profit_type"`
+	Method              string              `json:"method"`
+	Profile             string              `json:"installment_profile,omitempty"`
+	PaymentFrequency    string              `json:"payment_frequency"`
+	InstallmentCount    int                 `json:"installment_count"`
+	GracePeriods        int                 `json:"grace_periods"`
+	GraceMode           string              `json:"grace_mode,omitempty"`
+	AnnualMarginRate    float64             `json:"annual_margin_rate"`
+	EffectiveAnnualRate float64             `json:"effective_annual_rate"`
+	MonthlyInstallment  int64               `json:"monthly_installment"`
+	LastInstallment     int64               `json:"last_installment"`
+	TotalMargin         int64               `json:"total_margin"`
+	TotalPayment        int64               `json:"total_payment"`
+	Fee                 int64               `json:"fee"`
+	Partnership         *PartnershipSummary `json:"partnership,omitempty"`
+	BrokenPeriod        *BrokenPeriod       `json:"broken_period,omitempty"`
+	Affordability       *TenorAffordability `json:"affordability,omitempty"`
 }
 
 type CalculateInstallmentResponse struct {
-	Calculations []InstallmentCalculation `json:"calculations"`
+	AsOf             string                   `json:"as_of"`
+	PricingVersionID int64                    `json:"pricing_version_id,omitempty"`
+	Financing        FinancingBreakdown       `json:"financing"`
+	Affordability    *AffordabilitySummary    `json:"affordability,omitempty"`
+	Calculations     []InstallmentCalculation `json:"calculations"`
+	AcceptedTenors   []int                    `json:"accepted_tenors"`
+	ExcludedTenors   []ExcludedTenor          `json:"excluded_tenors"`
+	Quote            *QuoteReference          `json:"quote,omitempty"`
 }
@@ -0,0 +1,24 @@
+package domain
+
+const (
+	ProfileLevel    = "level"
+	ProfileStepUp   = "step_up"
+	ProfileSeasonal = "seasonal"
+	ProfileBalloon  = "balloon"
+)
+
+// InstallmentProfile shapes the installments of a schedule.
+//   - step_up raises the installment by StepRate every StepMonths months.
+//   - seasonal multiplies installments due in HighMonths by HighFactor and
+//     those due in LowMonths by LowFactor (calendar months, 1-12).
+//   - balloon leaves BalloonRate of the principal to a final lump sum.
+type InstallmentProfile struct {
+	Type        string  `json:"type" example:"step_up"`
+	StepRate    float64 `json:"step_rate,omitempty" example:"0.1"`
+	StepMonths  int     `json:"step_months,omitempty" example:"12"`
+	HighMonths  []int   `json:"high_months,omitempty"`
+	LowMonths   []int   `json:"low_months,omitempty"`
+	HighFactor  float64 `json:"high_factor,omitempty" example:"1.5"`
+	LowFactor   float64 `json:"low_factor,omitempty" example:"0.5"`
+	BalloonRate float64 `json:"balloon_rate,omitempty" example:"0.3"`
+}
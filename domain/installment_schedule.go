@@ -0,0 +1,58 @@
+package domain
+
+type CalculateScheduleRequest struct {
+	Amount     int64  `json:"amount" binding:"required_without=AssetPrice,omitempty,gt=0"`
+	AssetPrice int64  `json:"asset_price" binding:"omitempty,gt=0"`
+	Tenor      int    `json:"tenor" binding:"required,gt=0"`
+	StartDate  string `json:"start_date" example:"2026-01-15"`
+	// OfferedOn checks that the tenor was offered on that date instead of
+	// on start_date, for terms agreed before disbursement.
+	OfferedOn string `json:"offered_on" example:"2026-01-10"`
+	PricingOptions
+	FinancingComponents
+	DueDateOptions
+}
+
+type InstallmentScheduleRow struct {
+	InstallmentNumber  int        `json:"installment_number"`
+	DueDate            string     `json:"due_date"`
+	Principal          int64      `json:"principal"`
+	Margin             int64      `json:"margin"`
+	Installment        int64      `json:"installment"`
+	RemainingBalance   int64      `json:"remaining_balance"`
+	CapitalizedMargin  int64      `json:"capitalized_margin,omitempty"`
+	Grace              bool       `json:"grace,omitempty"`
+	Balloon            int64      `json:"balloon,omitempty"`
+	BrokenPeriodMargin int64      `json:"broken_period_margin,omitempty"`
+	RentRate           float64    `json:"rent_rate,omitempty"`
+	Ownership          *Ownership `json:"ownership,omitempty"`
+}
+
+type InstallmentScheduleResponse struct {
+	Tenor                 int                      `json:"tenor"`
+	PricingVersionID      int64                    `json:"pricing_version_id,omitempty"`
+	StartDate             string                   `json:"start_date"`
+	PaymentDay            int                      `json:"payment_day,omitempty"`
+	BusinessDayConvention string                   `json:"business_day_convention"`
+	Product               string                   `json:"product,omitempty"`
+	ContractType          string                   `json:"contract_type"`
+	ProfitType            string                   `json:"profit_type"`
+	Method                string                   `json:"method"`
+	Profile               string                   `json:"installment_profile,omitempty"`
+	PaymentFrequency      string                   `json:"payment_frequency"`
+	InstallmentCount      int                      `json:"installment_count"`
+	GracePeriods          int                      `json:"grace_periods"`
+	GraceMode             string                   `json:"grace_mode,omitempty"`
+	AnnualMarginRate      float64                  `json:"annual_margin_rate"`
+	EffectiveAnnualRate   float64                  `json:"effective_annual_rate"`
+	Financing             FinancingBreakdown       `json:"financing"`
+	Principal             int64                    `json:"principal"`
+	MonthlyInstallment    int64                    `json:"monthly_installment"`
+	LastInstallment       int64                    `json:"last_installment"`
+	TotalMargin           int64                    `json:"total_margin"`
+	TotalPayment          int64                    `json:"total_payment"`
+	Fee                   int64                    `json:"fee"`
+	Partnership           *PartnershipSummary      `json:"partnership,omitempty"`
+	BrokenPeriod          *BrokenPeriod            `json:"broken_period,omitempty"`
+	Schedule              []InstallmentScheduleRow `json:"schedule"`
+}
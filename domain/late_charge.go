@@ -0,0 +1,61 @@
+package domain
+
+const (
+	LateChargeTawidh = "tawidh"
+	LateChargeTazir  = "tazir"
+
+	DefaultLateChargeRuleSet = "default"
+)
+
+// LateChargeRule is one charge component of a rule set. Ta'widh compensates
+// the actual loss and is recognised as income; ta'zir is a penalty that is
+// channelled to the social fund.
+type LateChargeRule struct {
+	ID          int64    `gorm:"primaryKey"`
+	RuleSet     string   `gorm:"column:rule_set;not null"`
+	ChargeType  string   `gorm:"column:charge_type;not null"`
+	GraceDays   int      `gorm:"column:grace_days;not null"`
+	FlatAmount  int64    `gorm:"column:flat_amount;not null"`
+	DailyRate   *float64 `gorm:"column:daily_rate"`
+	CapAmount   int64    `gorm:"column:cap_amount;not null"`
+	CapRate     *float64 `gorm:"column:cap_rate"`
+	Description string   `gorm:"column:description"`
+	CreatedAt   int64    `gorm:"autoCreateTime:milli"`
+	UpdatedAt   int64    `gorm:"autoUpdateTime:milli"`
+}
+
+func (LateChargeRule) TableName() string {
+	return "late_charge_rules"
+}
+
+type LateChargeInstallment struct {
+	InstallmentNumber int    `json:"installment_number" binding:"gt=0"`
+	DueDate           string `json:"due_date" binding:"required" example:"2026-02-15"`
+	Installment       int64  `json:"installment" binding:"gt=0"`
+	PaidDate          string `json:"paid_date" example:"2026-02-20"`
+}
+
+type CalculateLateChargeRequest struct {
+	RuleSet         string                  `json:"rule_set" example:"default"`
+	CalculationDate string                  `json:"calculation_date" example:"2026-03-01"`
+	Installments    []LateChargeInstallment `json:"installments" binding:"required,min=1,dive"`
+}
+
+type LateCharge struct {
+	InstallmentNumber int    `json:"installment_number"`
+	DueDate           string `json:"due_date"`
+	DaysLate          int    `json:"days_late"`
+	ChargeType        string `json:"charge_type"`
+	UncappedAmount    int64  `json:"uncapped_amount"`
+	Amount            int64  `json:"amount"`
+	Capped            bool   `json:"capped"`
+}
+
+type CalculateLateChargeResponse struct {
+	RuleSet         string       `json:"rule_set"`
+	CalculationDate string       `json:"calculation_date"`
+	Charges         []LateCharge `json:"charges"`
+	TotalTawidh     int64        `json:"total_tawidh"`
+	TotalTazir      int64        `json:"total_tazir"`
+	TotalCharges    int64        `json:"total_charges"`
+}
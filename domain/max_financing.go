@@ -0,0 +1,20 @@
+package domain
+
+type MaxFinancingRequest struct {
+	TargetInstallment int64 `json:"target_installment" binding:"required,gt=0"`
+	Tenors            []int `json:"tenors"`
+	PricingOptions
+}
+
+type MaxFinancingCalculation struct {
+	MaxAmount int64 `json:"max_amount"`
+	InstallmentCalculation
+}
+
+type MaxFinancingResponse struct {
+	TargetInstallment int64                     `json:"target_installment"`
+	PricingVersionID  int64                     `json:"pricing_version_id,omitempty"`
+	Calculations      []MaxFinancingCalculation `json:"calculations"`
+	AcceptedTenors    []int                     `json:"accepted_tenors"`
+	ExcludedTenors    []ExcludedTenor           `json:"excluded_tenors"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+// Ownership is the split of the asset after an installment, as fractions.
+type Ownership struct {
+	BankShare     float64 `json:"bank_share"`
+	CustomerShare float64 `json:"customer_share"`
+}
+
+// PartnershipSummary describes a musyarakah mutanaqisah quote. The customer's
+// contribution is the down payment; the bank funds the financed amount.
+type PartnershipSummary struct {
+	AssetValue           int64   `json:"asset_value"`
+	CustomerContribution int64   `json:"customer_contribution"`
+	InitialBankShare     float64 `json:"initial_bank_share"`
+	TotalAcquisition     int64   `json:"total_acquisition"`
+	TotalRent            int64   `json:"total_rent"`
+	TotalCost            int64   `json:"total_cost"`
+}
@@ -0,0 +1,49 @@
+package domain
+
+const (
+	PrepaymentReduceInstallment = "reduce_installment"
+	PrepaymentReduceTenor       = "reduce_tenor"
+)
+
+type PrepaymentRequest struct {
+	Amount           int64  `json:"amount" binding:"required,gt=0"`
+	Tenor            int    `json:"tenor" binding:"required,gt=0"`
+	StartDate        string `json:"start_date" binding:"required" example:"2026-01-15"`
+	InstallmentsPaid int    `json:"installments_paid" binding:"gte=0"`
+	PrepaymentAmount int64  `json:"prepayment_amount" binding:"required,gt=0" example:"3000000"`
+	PrepaymentDate   string `json:"prepayment_date" example:"2026-07-20"`
+	Option           string `json:"option" example:"reduce_installment"`
+	PricingOptions
+	DueDateOptions
+}
+
+type PrepaymentResponse struct {
+	Tenor                int             `json:"tenor"`
+	PricingVersionID     int64           `json:"pricing_version_id,omitempty"`
+	Method               string          `json:"method"`
+	PaymentFrequency     string          `json:"payment_frequency"`
+	Option               string          `json:"option"`
+	InstallmentsPaid     int             `json:"installments_paid"`
+	PrepaymentDate       string          `json:"prepayment_date"`
+	PrepaymentAmount     int64           `json:"prepayment_amount"`
+	OutstandingPrincipal int64           `json:"outstanding_principal"`
+	RemainingPrincipal   int64           `json:"remaining_principal"`
+	Original             PrepaymentTerms `json:"original"`
+	Revised              PrepaymentTerms `json:"revised"`
+	// AccruedMargin is the running installment's margin on the prepaid
+	// principal earned up to the prepayment date, due with the prepayment.
+	AccruedMargin int64                    `json:"accrued_margin"`
+	MarginSaved   int64                    `json:"margin_saved"`
+	Schedule      []InstallmentScheduleRow `json:"schedule"`
+}
+
+// PrepaymentTerms summarizes the installments still to be paid after the
+// prepayment date, before or after the prepayment.
+type PrepaymentTerms struct {
+	RemainingInstallments int    `json:"remaining_installments"`
+	Installment           int64  `json:"installment"`
+	LastInstallment       int64  `json:"last_installment"`
+	MaturityDate          string `json:"maturity_date"`
+	RemainingMargin       int64  `json:"remaining_margin"`
+	RemainingPayment      int64  `json:"remaining_payment"`
+}
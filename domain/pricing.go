@@ -0,0 +1,71 @@
+package domain
+
+const (
+	MethodFlat      = "flat"
+	MethodEffective = "effective"
+	// MethodDiminishing buys out an equal share of the asset every period and
+	// charges rent on the share still owned by the bank.
+	MethodDiminishing = "diminishing"
+)
+
+const (
+	FrequencyMonthly  = "monthly"
+	FrequencyBiWeekly = "biweekly"
+	FrequencyWeekly   = "weekly"
+)
+
+const (
+	RoundingHalfUp   = "half_up"
+	RoundingHalfEven = "half_even"
+	RoundingFloor    = "floor"
+	RoundingCeiling  = "ceiling"
+)
+
+const (
+	GraceMarginOnly  = "margin_only"
+	GraceCapitalized = "capitalized"
+)
+
+type PricingOptions struct {
+	ProductCode      string              `json:"product_code" example:"murabahah"`
+	Method           string              `json:"method" example:"flat"`
+	PaymentFrequency string              `json:"payment_frequency" example:"monthly"`
+	RoundingMode     string              `json:"rounding_mode" example:"half_up"`
+	RoundingUnit     int64               `json:"rounding_unit" example:"1"`
+	GracePeriods     int                 `json:"grace_periods" binding:"gte=0"`
+	GraceMode        string              `json:"grace_mode" example:"margin_only"`
+	RateReviews      []RateReview        `json:"rate_reviews" binding:"omitempty,dive"`
+	Profile          *InstallmentProfile `json:"installment_profile"`
+	// PricingVersionID prices with that recorded version of the tenors and
+	// products instead of the one in effect on the pricing date.
+	PricingVersionID int64 `json:"pricing_version_id" binding:"gte=0" example:"3"`
+}
+
+// RateReview reprices the rent from the given month of the tenor onwards.
+type RateReview struct {
+	FromMonth  int     `json:"from_month" binding:"gt=1" example:"37"`
+	AnnualRate float64 `json:"annual_rate" binding:"gte=0" example:"0.12"`
+}
+
+type RoundingPolicy struct {
+	Mode string
+	Unit int64
+}
+
+type PricingInput struct {
+	Principal        int64
+	Installments     int
+	PeriodsPerYear   int
+	AnnualMarginRate float64
+	Rounding         RoundingPolicy
+	GracePeriods     int
+	GraceMode        string
+	RateReviews      []RateReview
+	// Weights are the relative sizes of the installments; nil means level.
+	Weights []float64
+	// Balloon is principal left to the final installment as a lump sum.
+	Balloon int64
+	// PeriodOffset is the number of periods that precede this schedule, so
+	// rate reviews land on the right installments after a grace period.
+	PeriodOffset int
+}
@@ -0,0 +1,116 @@
+package domain
+
+import "time"
+
+// PricingVersion is an immutable snapshot of the tenor master and the product
+// catalog. A new version is recorded whenever a tenor changes and applies
+// from EffectiveAt (Unix milliseconds) until the next version.
+type PricingVersion struct {
+	ID          int64                   `gorm:"primaryKey"`
+	EffectiveAt int64                   `gorm:"column:effective_at;not null"`
+	Note        string                  `gorm:"column:note"`
+	CreatedAt   int64                   `gorm:"autoCreateTime:milli"`
+	Tenors      []PricingVersionTenor   `gorm:"foreignKey:PricingVersionID"`
+	Products    []PricingVersionProduct `gorm:"foreignKey:PricingVersionID"`
+}
+
+func (PricingVersion) TableName() string {
+	return "pricing_versions"
+}
+
+// PricingVersionTenor is one tenor as it stood in a pricing version.
+type PricingVersionTenor struct {
+	ID                  int64      `gorm:"primaryKey"`
+	PricingVersionID    int64      `gorm:"column:pricing_version_id;not null"`
+	TenorID             int64      `gorm:"column:tenor_id;not null"`
+	TenorValue          int        `gorm:"column:tenor_value;not null"`
+	FlatMarginRate      *float64   `gorm:"column:flat_margin_rate"`
+	EffectiveMarginRate *float64   `gorm:"column:effective_margin_rate"`
+	PaymentFrequencies  string     `gorm:"column:payment_frequencies"`
+	MinAmount           *int64     `gorm:"column:min_amount"`
+	MaxAmount           *int64     `gorm:"column:max_amount"`
+	Active              bool       `gorm:"column:active;not null"`
+	EffectiveFrom       *time.Time `gorm:"column:effective_from;type:date"`
+	EffectiveTo         *time.Time `gorm:"column:effective_to;type:date"`
+}
+
+func (PricingVersionTenor) TableName() string {
+	return "pricing_version_tenors"
+}
+
+func NewPricingVersionTenor(tenor Tenor) PricingVersionTenor {
+	return PricingVersionTenor{
+		TenorID:             tenor.ID,
+		TenorValue:          tenor.TenorValue,
+		FlatMarginRate:      tenor.FlatMarginRate,
+		EffectiveMarginRate: tenor.EffectiveMarginRate,
+		PaymentFrequencies:  tenor.PaymentFrequencies,
+		MinAmount:           tenor.MinAmount,
+		MaxAmount:           tenor.MaxAmount,
+		Active:              tenor.Active,
+		EffectiveFrom:       tenor.EffectiveFrom,
+		EffectiveTo:         tenor.EffectiveTo,
+	}
+}
+
+// Tenor returns the tenor as it stood in the version.
+func (t PricingVersionTenor) Tenor() Tenor {
+	return Tenor{
+		ID:                  t.TenorID,
+		TenorValue:          t.TenorValue,
+		FlatMarginRate:      t.FlatMarginRate,
+		EffectiveMarginRate: t.EffectiveMarginRate,
+		PaymentFrequencies:  t.PaymentFrequencies,
+		MinAmount:           t.MinAmount,
+		MaxAmount:           t.MaxAmount,
+		Active:              t.Active,
+		EffectiveFrom:       t.EffectiveFrom,
+		EffectiveTo:         t.EffectiveTo,
+	}
+}
+
+// PricingVersionProduct is one product, with its fee and plafond, as it stood
+// in a pricing version.
+type PricingVersionProduct struct {
+	ID               int64    `gorm:"primaryKey"`
+	PricingVersionID int64    `gorm:"column:pricing_version_id;not null"`
+	ProductID        int64    `gorm:"column:product_id;not null"`
+	Code             string   `gorm:"column:code;not null"`
+	Name             string   `gorm:"column:name;not null"`
+	ContractType     string   `gorm:"column:contract_type;not null"`
+	FeeAmount        int64    `gorm:"column:fee_amount;not null"`
+	FeeRate          *float64 `gorm:"column:fee_rate"`
+	MinAmount        *int64   `gorm:"column:min_amount"`
+	MaxAmount        *int64   `gorm:"column:max_amount"`
+}
+
+func (PricingVersionProduct) TableName() string {
+	return "pricing_version_products"
+}
+
+func NewPricingVersionProduct(product Product) PricingVersionProduct {
+	return PricingVersionProduct{
+		ProductID:    product.ID,
+		Code:         product.Code,
+		Name:         product.Name,
+		ContractType: product.ContractType,
+		FeeAmount:    product.FeeAmount,
+		FeeRate:      product.FeeRate,
+		MinAmount:    product.MinAmount,
+		MaxAmount:    product.MaxAmount,
+	}
+}
+
+// Product returns the product as it stood in the version.
+func (p PricingVersionProduct) Product() Product {
+	return Product{
+		ID:           p.ProductID,
+		Code:         p.Code,
+		Name:         p.Name,
+		ContractType: p.ContractType,
+		FeeAmount:    p.FeeAmount,
+		FeeRate:      p.FeeRate,
+		MinAmount:    p.MinAmount,
+		MaxAmount:    p.MaxAmount,
+	}
+}
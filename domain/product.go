@@ -0,0 +1,31 @@
+package domain
+
+const (
+	ContractMurabahah = "murabahah"
+	ContractIjarah    = "ijarah"
+	ContractMMQ       = "mmq"
+	ContractQardh     = "qardh"
+)
+
+const (
+	ProfitMargin = "margin"
+	ProfitUjrah  = "ujrah"
+	ProfitNone   = "none"
+)
+
+type Product struct {
+	ID           int64    `gorm:"primaryKey"`
+	Code         string   `gorm:"column:code;not null"`
+	Name         string   `gorm:"column:name;not null"`
+	ContractType string   `gorm:"column:contract_type;not null"`
+	FeeAmount    int64    `gorm:"column:fee_amount;not null"`
+	FeeRate      *float64 `gorm:"column:fee_rate"`
+	MinAmount    *int64   `gorm:"column:min_amount"`
+	MaxAmount    *int64   `gorm:"column:max_amount"`
+	CreatedAt    int64    `gorm:"autoCreateTime:milli"`
+	UpdatedAt    int64    `gorm:"autoUpdateTime:milli"`
+}
+
+func (Product) TableName() string {
+	return "products"
+}
@@ -0,0 +1,63 @@
+package domain
+
+// Quote is a saved /calculate-installments result. Request and result are
+// stored as JSON so a quote reads back exactly as it was given.
+type Quote struct {
+	ID               string `gorm:"primaryKey;column:id"`
+	Channel          string `gorm:"column:channel"`
+	FinancedAmount   int64  `gorm:"column:financed_amount;not null"`
+	PricingVersionID int64  `gorm:"column:pricing_version_id"`
+	RequestBody      string `gorm:"column:request_body;not null"`
+	ResultBody       string `gorm:"column:result_body;not null"`
+	CreatedAt        int64  `gorm:"column:created_at;not null"`
+	ExpiresAt        int64  `gorm:"column:expires_at;not null"`
+}
+
+func (Quote) TableName() string {
+	return "quotes"
+}
+
+// QuoteFilter selects unexpired quotes created between From and To, in Unix
+// milliseconds; zero bounds are open.
+type QuoteFilter struct {
+	From    int64
+	To      int64
+	Channel string
+	Now     int64
+	Limit   int
+}
+
+type QuoteReference struct {
+	QuoteID   string `json:"quote_id"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type QuoteResponse struct {
+	QuoteID          string                       `json:"quote_id"`
+	Channel          string                       `json:"channel,omitempty"`
+	PricingVersionID int64                        `json:"pricing_version_id,omitempty"`
+	CreatedAt        string                       `json:"created_at"`
+	ExpiresAt        string                       `json:"expires_at"`
+	Request          CalculateInstallmentRequest  `json:"request"`
+	Result           CalculateInstallmentResponse `json:"result"`
+}
+
+type ListQuotesRequest struct {
+	From    string `form:"from" example:"2026-10-01"`
+	To      string `form:"to" example:"2026-10-18"`
+	Channel string `form:"channel" example:"mobile"`
+	Limit   int    `form:"limit" binding:"omitempty,gt=0" example:"100"`
+}
+
+type QuoteSummary struct {
+	QuoteID          string `json:"quote_id"`
+	Channel          string `json:"channel,omitempty"`
+	FinancedAmount   int64  `json:"financed_amount"`
+	PricingVersionID int64  `json:"pricing_version_id,omitempty"`
+	CreatedAt        string `json:"created_at"`
+	ExpiresAt        string `json:"expires_at"`
+}
+
+type ListQuotesResponse struct {
+	Quotes []QuoteSummary `json:"quotes"`
+}
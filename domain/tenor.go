@@ -1,12 +1,74 @@
 package domain
 
+import "time"
+
+// CodeTenorExists rejects a tenor master entry whose effective window
+// overlaps an active tenor of the same length.
+const CodeTenorExists = "tenor_exists"
+
 type Tenor struct {
-	ID         int64 `gorm:"primaryKey"`
-	TenorValue int   `gorm:"column:tenor_value;not null"`
-	CreatedAt  int64 `gorm:"autoCreateTime:milli"`
-	UpdatedAt  int64 `gorm:"autoUpdateTime:milli"`
+	ID                  int64      `gorm:"primaryKey"`
+	TenorValue          int        `gorm:"column:tenor_value;not null"`
+	FlatMarginRate      *float64   `gorm:"column:flat_margin_rate"`
+	EffectiveMarginRate *float64   `gorm:"column:effective_margin_rate"`
+	PaymentFrequencies  string     `gorm:"column:payment_frequencies"`
+	MinAmount           *int64     `gorm:"column:min_amount"`
+	MaxAmount           *int64     `gorm:"column:max_amount"`
+	Active              bool       `gorm:"column:active;not null"`
+	EffectiveFrom       *time.Time `gorm:"column:effective_from;type:date"`
+	EffectiveTo         *time.Time `gorm:"column:effective_to;type:date"`
+	CreatedAt           int64      `gorm:"autoCreateTime:milli"`
+	UpdatedAt           int64      `gorm:"autoUpdateTime:milli"`
 }
 
 func (Tenor) TableName() string {
 	return "tenors"
 }
+
+// AvailableOn reports whether the tenor is active and within its effective
+// dates, both inclusive, on the given day.
+func (t Tenor) AvailableOn(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	if !t.Active {
+		return false
+	}
+	if t.EffectiveFrom != nil && t.EffectiveFrom.Format("2006-01-02") > day {
+		return false
+	}
+	return t.EffectiveTo == nil || t.EffectiveTo.Format("2006-01-02") >= day
+}
+
+type TenorRequest struct {
+	TenorValue          int      `json:"tenor_value" binding:"required,gt=0" example:"48"`
+	FlatMarginRate      *float64 `json:"flat_margin_rate" binding:"omitempty,gte=0,lte=1" example:"0.25"`
+	EffectiveMarginRate *float64 `json:"effective_margin_rate" binding:"omitempty,gte=0,lte=1" example:"0.25"`
+	PaymentFrequencies  []string `json:"payment_frequencies" example:"monthly"`
+	MinAmount           *int64   `json:"min_amount" binding:"omitempty,gt=0" example:"5000000"`
+	MaxAmount           *int64   `json:"max_amount" binding:"omitempty,gt=0"`
+	Active              *bool    `json:"active" example:"true"`
+	EffectiveFrom       string   `json:"effective_from" example:"2026-11-01"`
+	EffectiveTo         string   `json:"effective_to" example:"2027-12-31"`
+}
+
+type TenorResponse struct {
+	ID                  int64    `json:"id"`
+	TenorValue          int      `json:"tenor_value"`
+	FlatMarginRate      *float64 `json:"flat_margin_rate"`
+	EffectiveMarginRate *float64 `json:"effective_margin_rate"`
+	PaymentFrequencies  []string `json:"payment_frequencies"`
+	MinAmount           *int64   `json:"min_amount"`
+	MaxAmount           *int64   `json:"max_amount"`
+	Active              bool     `json:"active"`
+	EffectiveFrom       string   `json:"effective_from,omitempty"`
+	EffectiveTo         string   `json:"effective_to,omitempty"`
+}
+
+type ListTenorsRequest struct {
+	AsOf            string `form:"as_of" example:"2026-10-18"`
+	IncludeInactive bool   `form:"include_inactive"`
+}
+
+type ListTenorsResponse struct {
+	AsOf   string          `json:"as_of,omitempty"`
+	Tenors []TenorResponse `json:"tenors"`
+}
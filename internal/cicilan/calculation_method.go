@@ -0,0 +1,8 @@
+package cicilan
+
+import "btpntest/domain"
+
+type CalculationMethod interface {
+	Name() string
+	BuildSchedule(input domain.PricingInput) ([]domain.InstallmentScheduleRow, error)
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries the key admin endpoints that change data require.
+const APIKeyHeader = "X-API-Key"
+
+// requireAPIKey rejects requests whose X-API-Key header is not apiKey. With no
+// key configured every request is rejected, so the endpoints are never open
+// by default.
+func requireAPIKey(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader(APIKeyHeader)
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid API key"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApplicationHandler struct {
+	usecase cicilan.ApplicationUsecase
+}
+
+func NewApplicationHandler(usecaseImpl cicilan.ApplicationUsecase) *ApplicationHandler {
+	return &ApplicationHandler{usecase: usecaseImpl}
+}
+
+// CreateApplication godoc
+// @Summary Create a financing application
+// @Description Starts a draft application for one tenor of an unexpired saved quote. The quoted terms are copied onto the application.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateApplicationRequest true "Quote, tenor and actor"
+// @Success 201 {object} domain.ApplicationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /applications [post]
+// @Router /btpn/applications [post]
+func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
+	var req domain.CreateApplicationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.CreateApplication(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetApplication godoc
+// @Summary Get a financing application
+// @Description Returns the application with its current status and the statuses it may move to next.
+// @Tags Applications
+// @Produce json
+// @Param id path int true "Application ID"
+// @Success 200 {object} domain.ApplicationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /applications/{id} [get]
+// @Router /btpn/applications/{id} [get]
+func (h *ApplicationHandler) GetApplication(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	response, err := h.usecase.GetApplication(id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// TransitionApplication godoc
+// @Summary Change an application's status
+// @Description Moves the application to a new status. Allowed moves: draft to submitted; submitted to under_review; under_review to approved or rejected; approved to disbursed; and any open status to cancelled. Other moves return code invalid_transition, and a concurrent change returns application_conflict.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Param id path int true "Application ID"
+// @Param request body domain.TransitionApplicationRequest true "Target status and actor"
+// @Success 200 {object} domain.ApplicationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /applications/{id}/transitions [post]
+// @Router /btpn/applications/{id}/transitions [post]
+func (h *ApplicationHandler) TransitionApplication(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	var req domain.TransitionApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.TransitionApplication(id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListApplicationTransitions godoc
+// @Summary List an application's status history
+// @Description Returns every status change of the application, oldest first, with the actor and time of each.
+// @Tags Applications
+// @Produce json
+// @Param id path int true "Application ID"
+// @Success 200 {object} domain.ApplicationTransitionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /applications/{id}/transitions [get]
+// @Router /btpn/applications/{id}/transitions [get]
+func (h *ApplicationHandler) ListApplicationTransitions(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid application ID"})
+		return
+	}
+
+	response, err := h.usecase.ListApplicationTransitions(id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ApplicationHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/applications", h.CreateApplication)
+	router.GET("/applications/:id", h.GetApplication)
+	router.POST("/applications/:id/transitions", h.TransitionApplication)
+	router.GET("/applications/:id/transitions", h.ListApplicationTransitions)
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"btpntest/domain"
+	"btpntest/internal/cicilan/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockApplicationUsecase struct {
+	err error
+	id  int64
+	req domain.TransitionApplicationRequest
+}
+
+func (m *MockApplicationUsecase) CreateApplication(req *domain.CreateApplicationRequest) (*domain.ApplicationResponse, error) {
+	return &domain.ApplicationResponse{ID: 1, Status: domain.ApplicationDraft}, m.err
+}
+
+func (m *MockApplicationUsecase) GetApplication(id int64) (*domain.ApplicationResponse, error) {
+	m.id = id
+	return &domain.ApplicationResponse{ID: id}, m.err
+}
+
+func (m *MockApplicationUsecase) TransitionApplication(id int64, req *domain.TransitionApplicationRequest) (*domain.ApplicationResponse, error) {
+	m.id = id
+	m.req = *req
+	return &domain.ApplicationResponse{ID: id, Status: req.Status}, m.err
+}
+
+func (m *MockApplicationUsecase) ListApplicationTransitions(id int64) (*domain.ApplicationTransitionsResponse, error) {
+	m.id = id
+	return &domain.ApplicationTransitionsResponse{ApplicationID: id}, m.err
+}
+
+func TestApplicationHandler_Routes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockApplicationUsecase{}
+	router := gin.New()
+	NewApplicationHandler(mockUsecase).RegisterRoutes(router)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"Create", http.MethodPost, "/applications", `{"quote_id": "q", "tenor": 12, "actor": "customer:8812"}`, http.StatusCreated},
+		{"CreateMissingActor", http.MethodPost, "/applications", `{"quote_id": "q", "tenor": 12}`, http.StatusBadRequest},
+		{"Get", http.MethodGet, "/applications/5", "", http.StatusOK},
+		{"GetBadID", http.MethodGet, "/applications/abc", "", http.StatusBadRequest},
+		{"History", http.MethodGet, "/applications/5/transitions", "", http.StatusOK},
+		{"TransitionMissingStatus", http.MethodPost, "/applications/5/transitions", `{"actor": "officer:017"}`, http.StatusBadRequest},
+		{"Transition", http.MethodPost, "/applications/7/transitions", `{"status": "submitted", "actor": "officer:017"}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if mockUsecase.id != 7 || mockUsecase.req.Status != "submitted" || mockUsecase.req.Actor != "officer:017" {
+		t.Errorf("Expected application 7 moved to submitted by officer:017, got %d %+v", mockUsecase.id, mockUsecase.req)
+	}
+}
+
+func TestApplicationHandler_InvalidTransition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockApplicationUsecase{err: &usecase.ValidationError{Field: "status", Code: domain.CodeInvalidTransition, Message: "application 7 cannot move from draft to approved"}}
+	router := gin.New()
+	NewApplicationHandler(mockUsecase).RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/applications/7/transitions", strings.NewReader(`{"status": "approved", "actor": "officer:017"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), domain.CodeInvalidTransition) {
+		t.Errorf("Expected 400 with code %s, got %d: %s", domain.CodeInvalidTransition, rec.Code, rec.Body.String())
+	}
+}
+
+func TestApplicationHandler_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockApplicationUsecase{err: &usecase.ConflictError{Field: "status", Code: domain.CodeApplicationConflict, Message: "application 7 changed while moving to approved; reload and retry"}}
+	router := gin.New()
+	NewApplicationHandler(mockUsecase).RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/applications/7/transitions", strings.NewReader(`{"status": "approved", "actor": "officer:017"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), domain.CodeApplicationConflict) {
+		t.Errorf("Expected 409 with code %s, got %d: %s", domain.CodeApplicationConflict, rec.Code, rec.Body.String())
+	}
+}
@@ -20,7 +20,7 @@ func NewCicilanHandler(usecaseImpl cicilan.CicilanUsecase) *CicilanHandler {
 
 // CalculateInstallments godoc
 // @Summary Calculate installment schedule
-// @Description Returns installment calculations for available tenors.
+// @Description Returns installment calculations for available tenors. With save_quote, the result is also saved as a quote that can be retrieved by ID until it expires.
 // @Tags Installments
 // @Accept json
 // @Produce json
@@ -40,11 +40,123 @@ func (h *CicilanHandler) CalculateInstallments(c *gin.Context) {
 
 	response, err := h.usecase.CalculateInstallments(&req)
 	if err != nil {
-		if _, ok := err.(*usecase.ValidationError); ok {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CalculateSchedule godoc
+// @Summary Calculate amortization schedule
+// @Description Returns the month-by-month installment schedule for a single tenor.
+// @Tags Installments
+// @Accept json
+// @Produce json
+// @Param request body domain.CalculateScheduleRequest true "Schedule request"
+// @Success 200 {object} domain.InstallmentScheduleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /calculate-installments/schedule [post]
+// @Router /btpn/calculate-installments/schedule [post]
+func (h *CicilanHandler) CalculateSchedule(c *gin.Context) {
+	var req domain.CalculateScheduleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.CalculateSchedule(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CalculateMaxFinancing godoc
+// @Summary Calculate maximum financing amount
+// @Description Returns, per tenor, the largest principal whose installments stay within the target monthly installment.
+// @Tags Installments
+// @Accept json
+// @Produce json
+// @Param request body domain.MaxFinancingRequest true "Max financing request"
+// @Success 200 {object} domain.MaxFinancingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /calculate-installments/max-financing [post]
+// @Router /btpn/calculate-installments/max-financing [post]
+func (h *CicilanHandler) CalculateMaxFinancing(c *gin.Context) {
+	var req domain.MaxFinancingRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.CalculateMaxFinancing(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CalculateEarlySettlement godoc
+// @Summary Quote early settlement
+// @Description Returns the outstanding principal, unearned margin, muqasah rebate and settlement amount for closing a contract before maturity.
+// @Tags Installments
+// @Accept json
+// @Produce json
+// @Param request body domain.EarlySettlementRequest true "Early settlement request"
+// @Success 200 {object} domain.EarlySettlementResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /calculate-installments/early-settlement [post]
+// @Router /btpn/calculate-installments/early-settlement [post]
+func (h *CicilanHandler) CalculateEarlySettlement(c *gin.Context) {
+	var req domain.EarlySettlementRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.CalculateEarlySettlement(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SimulatePrepayment godoc
+// @Summary Simulate a partial prepayment
+// @Description Recomputes the remaining schedule after a lump-sum partial payment, keeping the tenor with a lower installment or keeping the installment with a shorter tenor, and returns the margin saved.
+// @Tags Installments
+// @Accept json
+// @Produce json
+// @Param request body domain.PrepaymentRequest true "Prepayment request"
+// @Success 200 {object} domain.PrepaymentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /calculate-installments/prepayment [post]
+// @Router /btpn/calculate-installments/prepayment [post]
+func (h *CicilanHandler) SimulatePrepayment(c *gin.Context) {
+	var req domain.PrepaymentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.SimulatePrepayment(&req)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
@@ -53,4 +165,35 @@ func (h *CicilanHandler) CalculateInstallments(c *gin.Context) {
 
 func (h *CicilanHandler) RegisterRoutes(router *gin.Engine) {
 	router.POST("/calculate-installments", h.CalculateInstallments)
+	router.POST("/calculate-installments/schedule", h.CalculateSchedule)
+	router.POST("/calculate-installments/max-financing", h.CalculateMaxFinancing)
+	router.POST("/calculate-installments/early-settlement", h.CalculateEarlySettlement)
+	router.POST("/calculate-installments/prepayment", h.SimulatePrepayment)
+}
+
+func writeError(c *gin.Context, err error) {
+	if validationErr, ok := err.(*usecase.ValidationError); ok {
+		c.JSON(http.StatusBadRequest, errorBody(validationErr.Message, validationErr.Code, validationErr.Field))
+		return
+	}
+	if conflictErr, ok := err.(*usecase.ConflictError); ok {
+		c.JSON(http.StatusConflict, errorBody(conflictErr.Message, conflictErr.Code, conflictErr.Field))
+		return
+	}
+	if notFoundErr, ok := err.(*usecase.NotFoundError); ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": notFoundErr.Message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+}
+
+func errorBody(message, code, field string) gin.H {
+	body := gin.H{"error": message}
+	if code != "" {
+		body["code"] = code
+	}
+	if field != "" {
+		body["field"] = field
+	}
+	return body
 }
@@ -1,21 +1,48 @@
 package http
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"btpntest/domain"
+	"btpntest/internal/cicilan/usecase"
+
+	"github.com/gin-gonic/gin"
 )
 
 // MockUsecase for testing the handler
 type MockUsecase struct {
-	response *domain.CalculateInstallmentResponse
-	err      error
+	response           *domain.CalculateInstallmentResponse
+	scheduleResponse   *domain.InstallmentScheduleResponse
+	maxResponse        *domain.MaxFinancingResponse
+	settlementResponse *domain.EarlySettlementResponse
+	prepaymentResponse *domain.PrepaymentResponse
+	err                error
 }
 
 func (m *MockUsecase) CalculateInstallments(req *domain.CalculateInstallmentRequest) (*domain.CalculateInstallmentResponse, error) {
 	return m.response, m.err
 }
 
+func (m *MockUsecase) CalculateSchedule(req *domain.CalculateScheduleRequest) (*domain.InstallmentScheduleResponse, error) {
+	return m.scheduleResponse, m.err
+}
+
+func (m *MockUsecase) CalculateMaxFinancing(req *domain.MaxFinancingRequest) (*domain.MaxFinancingResponse, error) {
+	return m.maxResponse, m.err
+}
+
+func (m *MockUsecase) CalculateEarlySettlement(req *domain.EarlySettlementRequest) (*domain.EarlySettlementResponse, error) {
+	return m.settlementResponse, m.err
+}
+
+func (m *MockUsecase) SimulatePrepayment(req *domain.PrepaymentRequest) (*domain.PrepaymentResponse, error) {
+	return m.prepaymentResponse, m.err
+}
+
 func TestNewCicilanHandler(t *testing.T) {
 	mockUsecase := &MockUsecase{}
 	handler := NewCicilanHandler(mockUsecase)
@@ -44,3 +71,66 @@ func TestCicilanHandlerInit(t *testing.T) {
 		t.Fatal("Handler usecase is nil")
 	}
 }
+
+func TestCalculateInstallments_RequestBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockUsecase{response: &domain.CalculateInstallmentResponse{}}
+	router := gin.New()
+	NewCicilanHandler(mockUsecase).RegisterRoutes(router)
+
+	tests := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"Amount", `{"amount": 10000000}`, http.StatusOK},
+		{"AssetPriceWithDownPayment", `{"asset_price": 15000000, "down_payment_rate": 0.2, "admin_fee": {"amount": 500000, "financed": true}}`, http.StatusOK},
+		{"MissingAmount", `{"down_payment": 1000000}`, http.StatusBadRequest},
+		{"NegativeCharge", `{"amount": 10000000, "stamp_duty": {"amount": -10000}}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/calculate-installments", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCalculateInstallments_StructuredValidationError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockUsecase{err: &usecase.ValidationError{
+		Field:   "amount",
+		Code:    domain.CodeAmountBelowMinimum,
+		Message: "financed amount 1 is below the minimum financing of 1000000",
+	}}
+	router := gin.New()
+	NewCicilanHandler(mockUsecase).RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/calculate-installments", strings.NewReader(`{"amount": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected JSON body, got %s", rec.Body.String())
+	}
+	if body["code"] != domain.CodeAmountBelowMinimum || body["field"] != "amount" || body["error"] == "" {
+		t.Errorf("Expected structured validation error, got %v", body)
+	}
+}
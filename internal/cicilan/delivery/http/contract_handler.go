@@ -0,0 +1,195 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ContractHandler struct {
+	usecase cicilan.ContractUsecase
+}
+
+func NewContractHandler(usecaseImpl cicilan.ContractUsecase) *ContractHandler {
+	return &ContractHandler{usecase: usecaseImpl}
+}
+
+// OpenContract godoc
+// @Summary Open a repayment ledger
+// @Description Opens the contract ledger of a disbursed application. The schedule is calculated from the quoted request for the application's tenor, starting on disbursement_date (default today).
+// @Tags Contracts
+// @Accept json
+// @Produce json
+// @Param request body domain.OpenContractRequest true "Application and disbursement"
+// @Success 201 {object} domain.ContractResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contracts [post]
+// @Router /btpn/contracts [post]
+func (h *ContractHandler) OpenContract(c *gin.Context) {
+	var req domain.OpenContractRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.OpenContract(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetContract godoc
+// @Summary Get a contract's outstanding balance
+// @Description Returns the outstanding principal, margin and charges, the overdue amount and the paid status of every installment as of as_of (default today).
+// @Tags Contracts
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param as_of query string false "Balance date (YYYY-MM-DD)"
+// @Success 200 {object} domain.ContractResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contracts/{id} [get]
+// @Router /btpn/contracts/{id} [get]
+func (h *ContractHandler) GetContract(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contract ID"})
+		return
+	}
+
+	var query domain.ContractQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := h.usecase.GetContract(id, &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PostPayment godoc
+// @Summary Post a payment
+// @Description Allocates the payment over the installments due on paid_on in the contract's allocation order, then over later installments, oldest first. Anything beyond the outstanding balance is kept as credit balance. A reference can only be posted once per contract.
+// @Tags Contracts
+// @Accept json
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param request body domain.PostPaymentRequest true "Payment"
+// @Success 201 {object} domain.PostPaymentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contracts/{id}/payments [post]
+// @Router /btpn/contracts/{id}/payments [post]
+func (h *ContractHandler) PostPayment(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contract ID"})
+		return
+	}
+
+	var req domain.PostPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.PostPayment(id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListPayments godoc
+// @Summary List a contract's payments
+// @Description Returns every payment posted to the contract with its allocations, in posting order.
+// @Tags Contracts
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Success 200 {object} domain.ListPaymentsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contracts/{id}/payments [get]
+// @Router /btpn/contracts/{id}/payments [get]
+func (h *ContractHandler) ListPayments(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contract ID"})
+		return
+	}
+
+	response, err := h.usecase.ListPayments(id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AssessCharge godoc
+// @Summary Assess a charge
+// @Description Adds a charge, such as a late-payment charge, to an installment that is overdue today. The contract's credit balance pays the charge first; a settled contract is reopened for the rest.
+// @Tags Contracts
+// @Accept json
+// @Produce json
+// @Param id path int true "Contract ID"
+// @Param request body domain.AssessChargeRequest true "Charge"
+// @Success 200 {object} domain.ContractResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contracts/{id}/charges [post]
+// @Router /btpn/contracts/{id}/charges [post]
+func (h *ContractHandler) AssessCharge(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid contract ID"})
+		return
+	}
+
+	var req domain.AssessChargeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.AssessCharge(id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ContractHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/contracts", h.OpenContract)
+	router.GET("/contracts/:id", h.GetContract)
+	router.POST("/contracts/:id/payments", h.PostPayment)
+	router.GET("/contracts/:id/payments", h.ListPayments)
+	router.POST("/contracts/:id/charges", h.AssessCharge)
+}
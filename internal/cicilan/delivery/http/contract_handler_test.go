@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"btpntest/domain"
+	"btpntest/internal/cicilan/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockContractUsecase struct {
+	err     error
+	id      int64
+	asOf    string
+	payment domain.PostPaymentRequest
+}
+
+func (m *MockContractUsecase) OpenContract(req *domain.OpenContractRequest) (*domain.ContractResponse, error) {
+	return &domain.ContractResponse{ID: 1, ApplicationID: req.ApplicationID}, m.err
+}
+
+func (m *MockContractUsecase) GetContract(id int64, query *domain.ContractQuery) (*domain.ContractResponse, error) {
+	m.id = id
+	m.asOf = query.AsOf
+	return &domain.ContractResponse{ID: id}, m.err
+}
+
+func (m *MockContractUsecase) PostPayment(id int64, req *domain.PostPaymentRequest) (*domain.PostPaymentResponse, error) {
+	m.id = id
+	m.payment = *req
+	return &domain.PostPaymentResponse{}, m.err
+}
+
+func (m *MockContractUsecase) ListPayments(id int64) (*domain.ListPaymentsResponse, error) {
+	m.id = id
+	return &domain.ListPaymentsResponse{ContractID: id}, m.err
+}
+
+func (m *MockContractUsecase) AssessCharge(id int64, req *domain.AssessChargeRequest) (*domain.ContractResponse, error) {
+	m.id = id
+	return &domain.ContractResponse{ID: id}, m.err
+}
+
+func TestContractHandler_Routes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockContractUsecase{}
+	router := gin.New()
+	NewContractHandler(mockUsecase).RegisterRoutes(router)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"Open", http.MethodPost, "/contracts", `{"application_id": 1, "disbursement_date": "2026-10-20"}`, http.StatusCreated},
+		{"OpenMissingApplication", http.MethodPost, "/contracts", `{}`, http.StatusBadRequest},
+		{"Get", http.MethodGet, "/contracts/3?as_of=2027-01-05", "", http.StatusOK},
+		{"GetBadID", http.MethodGet, "/contracts/abc", "", http.StatusBadRequest},
+		{"Payments", http.MethodGet, "/contracts/3/payments", "", http.StatusOK},
+		{"Charge", http.MethodPost, "/contracts/3/charges", `{"installment_number": 1, "amount": 25000}`, http.StatusOK},
+		{"PaymentMissingReference", http.MethodPost, "/contracts/3/payments", `{"amount": 1000, "actor": "teller"}`, http.StatusBadRequest},
+		{"PaymentZeroAmount", http.MethodPost, "/contracts/3/payments", `{"amount": 0, "reference": "VA-1", "actor": "teller"}`, http.StatusBadRequest},
+		{"Payment", http.MethodPost, "/contracts/7/payments", `{"amount": 1200000, "paid_on": "2026-11-20", "reference": "VA-1", "actor": "teller"}`, http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if mockUsecase.asOf != "2027-01-05" {
+		t.Errorf("Expected as_of 2027-01-05, got %q", mockUsecase.asOf)
+	}
+	expected := domain.PostPaymentRequest{Amount: 1200000, PaidOn: "2026-11-20", Reference: "VA-1", Actor: "teller"}
+	if mockUsecase.id != 7 || mockUsecase.payment != expected {
+		t.Errorf("Expected payment %+v on contract 7, got %d %+v", expected, mockUsecase.id, mockUsecase.payment)
+	}
+}
+
+func TestContractHandler_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockContractUsecase{err: &usecase.ConflictError{Code: domain.CodeContractConflict, Message: "contract 7 is being updated by other requests; retry"}}
+	router := gin.New()
+	NewContractHandler(mockUsecase).RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/contracts/7/payments", strings.NewReader(`{"amount": 1000, "reference": "VA-1", "actor": "teller"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), domain.CodeContractConflict) {
+		t.Errorf("Expected 409 with code %s, got %d: %s", domain.CodeContractConflict, rec.Code, rec.Body.String())
+	}
+}
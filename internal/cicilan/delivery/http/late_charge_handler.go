@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LateChargeHandler struct {
+	usecase cicilan.LateChargeUsecase
+}
+
+func NewLateChargeHandler(usecaseImpl cicilan.LateChargeUsecase) *LateChargeHandler {
+	return &LateChargeHandler{usecase: usecaseImpl}
+}
+
+// CalculateLateCharges godoc
+// @Summary Calculate late-payment charges
+// @Description Returns the ta'widh and ta'zir charges, after caps, for each overdue installment under the given rule set.
+// @Tags Late Charges
+// @Accept json
+// @Produce json
+// @Param request body domain.CalculateLateChargeRequest true "Late charge request"
+// @Success 200 {object} domain.CalculateLateChargeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /calculate-late-charges [post]
+// @Router /btpn/calculate-late-charges [post]
+func (h *LateChargeHandler) CalculateLateCharges(c *gin.Context) {
+	var req domain.CalculateLateChargeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.CalculateLateCharges(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *LateChargeHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/calculate-late-charges", h.CalculateLateCharges)
+}
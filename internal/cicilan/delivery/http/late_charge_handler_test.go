@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"btpntest/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockLateChargeUsecase struct {
+	response *domain.CalculateLateChargeResponse
+	err      error
+}
+
+func (m *MockLateChargeUsecase) CalculateLateCharges(req *domain.CalculateLateChargeRequest) (*domain.CalculateLateChargeResponse, error) {
+	return m.response, m.err
+}
+
+func TestCalculateLateCharges_RequestBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockLateChargeUsecase{response: &domain.CalculateLateChargeResponse{}}
+	router := gin.New()
+	NewLateChargeHandler(mockUsecase).RegisterRoutes(router)
+
+	tests := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"Valid", `{"installments": [{"installment_number": 1, "due_date": "2026-02-15", "installment": 1200000}]}`, http.StatusOK},
+		{"NoInstallments", `{"installments": []}`, http.StatusBadRequest},
+		{"MissingDueDate", `{"installments": [{"installment_number": 1, "installment": 1200000}]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/calculate-late-charges", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"net/http"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuoteHandler struct {
+	usecase cicilan.QuoteUsecase
+}
+
+func NewQuoteHandler(usecaseImpl cicilan.QuoteUsecase) *QuoteHandler {
+	return &QuoteHandler{usecase: usecaseImpl}
+}
+
+// GetQuote godoc
+// @Summary Get a saved quote
+// @Description Returns a quote saved by calculate-installments with save_quote, including the original request, the pricing version and the calculations. Expired quotes are not found.
+// @Tags Quotes
+// @Produce json
+// @Param id path string true "Quote ID"
+// @Success 200 {object} domain.QuoteResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /quotes/{id} [get]
+// @Router /btpn/quotes/{id} [get]
+func (h *QuoteHandler) GetQuote(c *gin.Context) {
+	response, err := h.usecase.GetQuote(c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListQuotes godoc
+// @Summary List saved quotes
+// @Description Returns the newest unexpired quotes created between from and to (inclusive dates), optionally for one channel.
+// @Tags Quotes
+// @Produce json
+// @Param from query string false "First creation date (YYYY-MM-DD)"
+// @Param to query string false "Last creation date (YYYY-MM-DD)"
+// @Param channel query string false "Channel"
+// @Param limit query int false "Maximum number of quotes (default 100, at most 500)"
+// @Success 200 {object} domain.ListQuotesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /quotes [get]
+// @Router /btpn/quotes [get]
+func (h *QuoteHandler) ListQuotes(c *gin.Context) {
+	var req domain.ListQuotesRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := h.usecase.ListQuotes(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *QuoteHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/quotes", h.ListQuotes)
+	router.GET("/quotes/:id", h.GetQuote)
+}
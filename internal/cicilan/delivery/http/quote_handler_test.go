@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"btpntest/domain"
+	"btpntest/internal/cicilan/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockQuoteUsecase struct {
+	err error
+	id  string
+	req domain.ListQuotesRequest
+}
+
+func (m *MockQuoteUsecase) GetQuote(id string) (*domain.QuoteResponse, error) {
+	m.id = id
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.QuoteResponse{QuoteID: id}, nil
+}
+
+func (m *MockQuoteUsecase) ListQuotes(req *domain.ListQuotesRequest) (*domain.ListQuotesResponse, error) {
+	m.req = *req
+	return &domain.ListQuotesResponse{Quotes: []domain.QuoteSummary{}}, m.err
+}
+
+func (m *MockQuoteUsecase) PurgeExpiredQuotes() (int64, error) {
+	return 0, m.err
+}
+
+func TestQuoteHandler_Routes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockQuoteUsecase{}
+	router := gin.New()
+	NewQuoteHandler(mockUsecase).RegisterRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected int
+	}{
+		{"Get", "/quotes/4f1c6d1e-9a0b-4c55-8d2e-3b7f9e6a1c20", http.StatusOK},
+		{"List", "/quotes?from=2026-10-01&to=2026-10-18&channel=mobile&limit=20", http.StatusOK},
+		{"ListBadLimit", "/quotes?limit=abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if mockUsecase.id != "4f1c6d1e-9a0b-4c55-8d2e-3b7f9e6a1c20" {
+		t.Errorf("Expected quote ID from the path, got %q", mockUsecase.id)
+	}
+	expected := domain.ListQuotesRequest{From: "2026-10-01", To: "2026-10-18", Channel: "mobile", Limit: 20}
+	if mockUsecase.req != expected {
+		t.Errorf("Expected query %+v, got %+v", expected, mockUsecase.req)
+	}
+}
+
+func TestQuoteHandler_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockQuoteUsecase{err: &usecase.NotFoundError{Message: "quote x not found"}}
+	router := gin.New()
+	NewQuoteHandler(mockUsecase).RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/quotes/x", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TenorAdminHandler struct {
+	usecase cicilan.TenorAdminUsecase
+	apiKey  string
+}
+
+// NewTenorAdminHandler serves the tenor master. Creating, updating and
+// deactivating tenors require apiKey in the X-API-Key header.
+func NewTenorAdminHandler(usecaseImpl cicilan.TenorAdminUsecase, apiKey string) *TenorAdminHandler {
+	return &TenorAdminHandler{usecase: usecaseImpl, apiKey: apiKey}
+}
+
+// ListTenors godoc
+// @Summary List tenors
+// @Description Returns the tenors available on as_of (default today), ordered by tenor length, or the whole tenor master with include_inactive.
+// @Tags Tenor Admin
+// @Produce json
+// @Param as_of query string false "Availability date (YYYY-MM-DD)"
+// @Param include_inactive query bool false "Include inactive and out-of-date tenors"
+// @Success 200 {object} domain.ListTenorsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/tenors [get]
+// @Router /btpn/admin/tenors [get]
+func (h *TenorAdminHandler) ListTenors(c *gin.Context) {
+	var req domain.ListTenorsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+
+	response, err := h.usecase.ListTenors(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateTenor godoc
+// @Summary Create a tenor
+// @Description Adds a tenor to the tenor master. Both margin rates are required. New tenors are active unless active is false.
+// @Tags Tenor Admin
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "Admin API key"
+// @Param request body domain.TenorRequest true "Tenor"
+// @Success 201 {object} domain.TenorResponse
+// @Failure 401 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/tenors [post]
+// @Router /btpn/admin/tenors [post]
+func (h *TenorAdminHandler) CreateTenor(c *gin.Context) {
+	var req domain.TenorRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.CreateTenor(&req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// UpdateTenor godoc
+// @Summary Update a tenor
+// @Description Replaces the tenor's rates, frequencies, amount band and effective dates. Both margin rates are required. Active is kept when omitted.
+// @Tags Tenor Admin
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "Admin API key"
+// @Param id path int true "Tenor ID"
+// @Param request body domain.TenorRequest true "Tenor"
+// @Success 200 {object} domain.TenorResponse
+// @Failure 401 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/tenors/{id} [put]
+// @Router /btpn/admin/tenors/{id} [put]
+func (h *TenorAdminHandler) UpdateTenor(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenor ID"})
+		return
+	}
+
+	var req domain.TenorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	response, err := h.usecase.UpdateTenor(id, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeactivateTenor godoc
+// @Summary Deactivate a tenor
+// @Description Withdraws the tenor from new calculations. The tenor stays in the master and can be reactivated with an update.
+// @Tags Tenor Admin
+// @Produce json
+// @Param X-API-Key header string true "Admin API key"
+// @Param id path int true "Tenor ID"
+// @Success 200 {object} domain.TenorResponse
+// @Failure 401 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/tenors/{id} [delete]
+// @Router /btpn/admin/tenors/{id} [delete]
+func (h *TenorAdminHandler) DeactivateTenor(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenor ID"})
+		return
+	}
+
+	response, err := h.usecase.DeactivateTenor(id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TenorAdminHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/admin/tenors", h.ListTenors)
+
+	admin := router.Group("/admin/tenors", requireAPIKey(h.apiKey))
+	admin.POST("", h.CreateTenor)
+	admin.PUT("/:id", h.UpdateTenor)
+	admin.DELETE("/:id", h.DeactivateTenor)
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"btpntest/domain"
+	"btpntest/internal/cicilan/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MockTenorAdminUsecase struct {
+	response *domain.TenorResponse
+	err      error
+	id       int64
+}
+
+func (m *MockTenorAdminUsecase) ListTenors(req *domain.ListTenorsRequest) (*domain.ListTenorsResponse, error) {
+	return &domain.ListTenorsResponse{AsOf: req.AsOf}, m.err
+}
+
+func (m *MockTenorAdminUsecase) CreateTenor(req *domain.TenorRequest) (*domain.TenorResponse, error) {
+	return m.response, m.err
+}
+
+func (m *MockTenorAdminUsecase) UpdateTenor(id int64, req *domain.TenorRequest) (*domain.TenorResponse, error) {
+	m.id = id
+	return m.response, m.err
+}
+
+func (m *MockTenorAdminUsecase) DeactivateTenor(id int64) (*domain.TenorResponse, error) {
+	m.id = id
+	return m.response, m.err
+}
+
+func TestTenorAdminHandler_Routes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockTenorAdminUsecase{response: &domain.TenorResponse{ID: 7, TenorValue: 48}}
+	router := gin.New()
+	NewTenorAdminHandler(mockUsecase, "secret").RegisterRoutes(router)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"List", http.MethodGet, "/admin/tenors?as_of=2026-11-01", "", http.StatusOK},
+		{"Create", http.MethodPost, "/admin/tenors", `{"tenor_value": 48, "flat_margin_rate": 0.26, "effective_margin_rate": 0.26}`, http.StatusCreated},
+		{"CreateMissingTenor", http.MethodPost, "/admin/tenors", `{"flat_margin_rate": 0.2}`, http.StatusBadRequest},
+		{"Update", http.MethodPut, "/admin/tenors/7", `{"tenor_value": 48, "flat_margin_rate": 0.26, "effective_margin_rate": 0.26}`, http.StatusOK},
+		{"UpdateBadID", http.MethodPut, "/admin/tenors/abc", `{"tenor_value": 48, "flat_margin_rate": 0.26, "effective_margin_rate": 0.26}`, http.StatusBadRequest},
+		{"Deactivate", http.MethodDelete, "/admin/tenors/7", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(APIKeyHeader, "secret")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if mockUsecase.id != 7 {
+		t.Errorf("Expected tenor ID 7 from the path, got %d", mockUsecase.id)
+	}
+}
+
+func TestTenorAdminHandler_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockTenorAdminUsecase{err: &usecase.NotFoundError{Message: "tenor 99 not found"}}
+	router := gin.New()
+	NewTenorAdminHandler(mockUsecase, "secret").RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/tenors/99", nil)
+	req.Header.Set(APIKeyHeader, "secret")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestTenorAdminHandler_RequiresAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		method   string
+		path     string
+		header   string
+		expected int
+	}{
+		{"ListIsOpen", "secret", http.MethodGet, "/admin/tenors", "", http.StatusOK},
+		{"CreateWithoutKey", "secret", http.MethodPost, "/admin/tenors", "", http.StatusUnauthorized},
+		{"UpdateWithWrongKey", "secret", http.MethodPut, "/admin/tenors/7", "guess", http.StatusUnauthorized},
+		{"DeactivateWithWrongKey", "secret", http.MethodDelete, "/admin/tenors/7", "secre", http.StatusUnauthorized},
+		{"DeactivateWithKey", "secret", http.MethodDelete, "/admin/tenors/7", "secret", http.StatusOK},
+		{"DeactivateWithoutConfiguredKey", "", http.MethodDelete, "/admin/tenors/7", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &MockTenorAdminUsecase{response: &domain.TenorResponse{ID: 7, TenorValue: 48}}
+			router := gin.New()
+			NewTenorAdminHandler(mockUsecase, tt.apiKey).RegisterRoutes(router)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{"tenor_value": 48, "flat_margin_rate": 0.26, "effective_margin_rate": 0.26}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set(APIKeyHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+			if tt.expected == http.StatusUnauthorized && mockUsecase.id != 0 {
+				t.Errorf("Expected the usecase not to be called, got tenor ID %d", mockUsecase.id)
+			}
+		})
+	}
+}
+
+func TestTenorAdminHandler_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := &MockTenorAdminUsecase{err: &usecase.ConflictError{Field: "tenor_value", Code: domain.CodeTenorExists, Message: "tenor 12 is already offered in an overlapping effective window"}}
+	router := gin.New()
+	NewTenorAdminHandler(mockUsecase, "secret").RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/tenors", strings.NewReader(`{"tenor_value": 12, "flat_margin_rate": 0.2, "effective_margin_rate": 0.2}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(APIKeyHeader, "secret")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), domain.CodeTenorExists) {
+		t.Errorf("Expected 409 with code %s, got %d: %s", domain.CodeTenorExists, rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,16 @@
+package cicilan
+
+import "btpntest/domain"
+
+// ProductCalculator prices one contract type. Methods names the calculation
+// methods the contract may be priced with, the first being the default.
+// SupportsRateReviews reports whether the profit rate may be repriced during
+// the tenor.
+type ProductCalculator interface {
+	ContractType() string
+	ProfitType() string
+	Methods() []string
+	SupportsRateReviews() bool
+	AnnualRate(tenor domain.Tenor, method string) float64
+	BuildSchedule(method CalculationMethod, input domain.PricingInput) ([]domain.InstallmentScheduleRow, error)
+}
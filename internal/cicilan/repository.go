@@ -1,7 +1,91 @@
 package cicilan
 
-import "btpntest/domain"
+import (
+	"errors"
+	"time"
+
+	"btpntest/domain"
+)
 
 type CicilanRepository interface {
-	GetAllTenors() ([]domain.Tenor, error)
+	// GetAllTenors returns the tenors available on asOf, by tenor length.
+	GetAllTenors(asOf time.Time) ([]domain.Tenor, error)
+	GetProductByCode(code string) (*domain.Product, error)
+}
+
+// ErrDuplicate is returned when a write breaks a uniqueness rule the
+// database enforces, typically because a concurrent request won the race.
+var ErrDuplicate = errors.New("duplicate record")
+
+type TenorRepository interface {
+	ListTenors() ([]domain.Tenor, error)
+	GetTenorByID(id int64) (*domain.Tenor, error)
+	// CreateTenor and UpdateTenor return ErrDuplicate when another tenor of
+	// the same length already starts on the same effective_from.
+	CreateTenor(tenor *domain.Tenor) error
+	UpdateTenor(tenor *domain.Tenor) error
+}
+
+// PricingHistoryRepository reads the immutable pricing versions recorded
+// whenever the tenor master changes.
+type PricingHistoryRepository interface {
+	// GetPricingVersion returns the version in effect at the given instant,
+	// or nil when none was recorded by then.
+	GetPricingVersion(at time.Time) (*domain.PricingVersion, error)
+	// GetPricingVersionByID returns nil when no version has the ID.
+	GetPricingVersionByID(id int64) (*domain.PricingVersion, error)
+}
+
+type QuoteRepository interface {
+	CreateQuote(quote *domain.Quote) error
+	// GetQuote returns nil when no quote has the ID.
+	GetQuote(id string) (*domain.Quote, error)
+	ListQuotes(filter domain.QuoteFilter) ([]domain.Quote, error)
+	// DeleteExpiredQuotes removes quotes that expired before now, except
+	// those an application was created from, and returns how many were
+	// removed.
+	DeleteExpiredQuotes(now int64) (int64, error)
+}
+
+type ApplicationRepository interface {
+	// CreateApplication inserts the application and its first transition
+	// together.
+	CreateApplication(application *domain.Application, transition *domain.ApplicationTransition) error
+	// GetApplication returns nil when no application has the ID.
+	GetApplication(id int64) (*domain.Application, error)
+	// TransitionApplication moves the application to transition.ToStatus and
+	// records the transition, but only while its status is still
+	// transition.FromStatus. It reports false when another transition got
+	// there first.
+	TransitionApplication(transition *domain.ApplicationTransition) (bool, error)
+	ListApplicationTransitions(applicationID int64) ([]domain.ApplicationTransition, error)
+}
+
+type ContractRepository interface {
+	CreateContract(contract *domain.Contract, installments []domain.ContractInstallment) error
+	// GetContract and GetContractByApplication return nil when there is no
+	// such contract.
+	GetContract(id int64) (*domain.Contract, error)
+	GetContractByApplication(applicationID int64) (*domain.Contract, error)
+	ListContractInstallments(contractID int64) ([]domain.ContractInstallment, error)
+	// GetPaymentByReference returns nil when the contract has no payment
+	// with the reference.
+	GetPaymentByReference(contractID int64, reference string) (*domain.Payment, error)
+	ListPayments(contractID int64) ([]domain.Payment, error)
+	// PostPayment saves the contract, the changed installments and the
+	// payment with its allocations in one transaction, but only while the
+	// stored contract is still at contract.Version. It reports false when
+	// another posting got there first.
+	PostPayment(contract *domain.Contract, installments []domain.ContractInstallment, payment *domain.Payment) (bool, error)
+	// AssessCharge saves the contract and the installment's charge under the
+	// same version check as PostPayment.
+	AssessCharge(contract *domain.Contract, installment *domain.ContractInstallment) (bool, error)
+}
+
+type LateChargeRepository interface {
+	GetLateChargeRules(ruleSet string) ([]domain.LateChargeRule, error)
+}
+
+type HolidayRepository interface {
+	GetHolidays() ([]domain.Holiday, error)
 }
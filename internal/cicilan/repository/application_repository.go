@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+
+	"btpntest/domain"
+
+	"gorm.io/gorm"
+)
+
+// ApplicationRepository stores financing applications and their status
+// history.
+type ApplicationRepository struct {
+	db *gorm.DB
+}
+
+func NewApplicationRepository(db *gorm.DB) *ApplicationRepository {
+	return &ApplicationRepository{db: db}
+}
+
+func (r *ApplicationRepository) CreateApplication(application *domain.Application, transition *domain.ApplicationTransition) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(application).Error; err != nil {
+			return err
+		}
+		transition.ApplicationID = application.ID
+		return tx.Create(transition).Error
+	})
+}
+
+// GetApplication returns nil without an error when no application has the
+// ID.
+func (r *ApplicationRepository) GetApplication(id int64) (*domain.Application, error) {
+	var application domain.Application
+	err := r.db.First(&application, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// TransitionApplication updates the status only where it still matches the
+// transition's starting status, so of two concurrent transitions from the
+// same status exactly one succeeds.
+func (r *ApplicationRepository) TransitionApplication(transition *domain.ApplicationTransition) (bool, error) {
+	applied := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Application{}).
+			Where("id = ? AND status = ?", transition.ApplicationID, transition.FromStatus).
+			Updates(map[string]interface{}{"status": transition.ToStatus, "updated_at": transition.CreatedAt})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		applied = true
+		return tx.Create(transition).Error
+	})
+	return applied && err == nil, err
+}
+
+func (r *ApplicationRepository) ListApplicationTransitions(applicationID int64) ([]domain.ApplicationTransition, error) {
+	var transitions []domain.ApplicationTransition
+	err := r.db.
+		Where("application_id = ?", applicationID).
+		Order("created_at").
+		Order("id").
+		Find(&transitions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"btpntest/domain"
 
 	"gorm.io/gorm"
@@ -14,10 +17,41 @@ func NewCicilanRepository(db *gorm.DB) *CicilanRepository {
 	return &CicilanRepository{db: db}
 }
 
-func (r *CicilanRepository) GetAllTenors() ([]domain.Tenor, error) {
+// GetAllTenors filters in Go rather than SQL: the tenor master is small and
+// this keeps boolean and date comparisons out of dialect-specific SQL.
+func (r *CicilanRepository) GetAllTenors(asOf time.Time) ([]domain.Tenor, error) {
+	tenors, err := listTenors(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]domain.Tenor, 0, len(tenors))
+	for _, tenor := range tenors {
+		if tenor.AvailableOn(asOf) {
+			available = append(available, tenor)
+		}
+	}
+	return available, nil
+}
+
+// listTenors returns every tenor, ordered by length and then by ID.
+func listTenors(db *gorm.DB) ([]domain.Tenor, error) {
 	var tenors []domain.Tenor
-	if err := r.db.Find(&tenors).Error; err != nil {
+	if err := db.Order("tenor_value").Order("id").Find(&tenors).Error; err != nil {
 		return nil, err
 	}
 	return tenors, nil
 }
+
+// GetProductByCode returns nil without an error when no product has the code.
+func (r *CicilanRepository) GetProductByCode(code string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.db.Where("code = ?", code).First(&product).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
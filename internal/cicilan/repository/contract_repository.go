@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"btpntest/domain"
+
+	"gorm.io/gorm"
+)
+
+// ContractRepository stores repayment ledgers. Every write goes through a
+// version check on the contract row, so postings for the same contract are
+// applied one at a time.
+type ContractRepository struct {
+	db *gorm.DB
+}
+
+func NewContractRepository(db *gorm.DB) *ContractRepository {
+	return &ContractRepository{db: db}
+}
+
+func (r *ContractRepository) CreateContract(contract *domain.Contract, installments []domain.ContractInstallment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(contract).Error; err != nil {
+			return err
+		}
+		for i := range installments {
+			installments[i].ContractID = contract.ID
+		}
+		return tx.Create(&installments).Error
+	})
+}
+
+// GetContract returns nil without an error when no contract has the ID.
+func (r *ContractRepository) GetContract(id int64) (*domain.Contract, error) {
+	return firstContract(r.db.Where("id = ?", id))
+}
+
+// GetContractByApplication returns nil without an error when the
+// application has no contract.
+func (r *ContractRepository) GetContractByApplication(applicationID int64) (*domain.Contract, error) {
+	return firstContract(r.db.Where("application_id = ?", applicationID))
+}
+
+func firstContract(query *gorm.DB) (*domain.Contract, error) {
+	var contract domain.Contract
+	err := query.First(&contract).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
+
+func (r *ContractRepository) ListContractInstallments(contractID int64) ([]domain.ContractInstallment, error) {
+	var installments []domain.ContractInstallment
+	err := r.db.
+		Where("contract_id = ?", contractID).
+		Order("installment_number").
+		Find(&installments).Error
+	if err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
+
+// GetPaymentByReference returns nil without an error when the contract has
+// no payment with the reference.
+func (r *ContractRepository) GetPaymentByReference(contractID int64, reference string) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.db.
+		Preload("Allocations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Where("contract_id = ? AND reference = ?", contractID, reference).
+		First(&payment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// ListPayments returns the contract's payments in posting order with their
+// allocations.
+func (r *ContractRepository) ListPayments(contractID int64) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	err := r.db.
+		Preload("Allocations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Where("contract_id = ?", contractID).
+		Order("id").
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// PostPayment creates the payment together with its allocations.
+func (r *ContractRepository) PostPayment(contract *domain.Contract, installments []domain.ContractInstallment, payment *domain.Payment) (bool, error) {
+	return r.saveLedger(contract, func(tx *gorm.DB) error {
+		for _, installment := range installments {
+			if err := saveInstallmentPayments(tx, installment); err != nil {
+				return err
+			}
+		}
+		return tx.Create(payment).Error
+	})
+}
+
+func (r *ContractRepository) AssessCharge(contract *domain.Contract, installment *domain.ContractInstallment) (bool, error) {
+	return r.saveLedger(contract, func(tx *gorm.DB) error {
+		return tx.Model(&domain.ContractInstallment{}).
+			Where("id = ?", installment.ID).
+			Updates(map[string]interface{}{
+				"charge_due":  installment.ChargeDue,
+				"charge_paid": installment.ChargePaid,
+				"paid_on":     installment.PaidOn,
+			}).Error
+	})
+}
+
+// saveLedger moves the contract from contract.Version to the next version
+// and runs write in the same transaction. Updating the contract row first
+// also locks it, so a concurrent posting waits and then finds the version
+// changed. contract.Version is advanced only when the write was applied.
+func (r *ContractRepository) saveLedger(contract *domain.Contract, write func(tx *gorm.DB) error) (bool, error) {
+	applied := false
+	updatedAt := time.Now().UnixMilli()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Contract{}).
+			Where("id = ? AND version = ?", contract.ID, contract.Version).
+			Updates(map[string]interface{}{
+				"credit_balance": contract.CreditBalance,
+				"status":         contract.Status,
+				"version":        contract.Version + 1,
+				"updated_at":     updatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := write(tx); err != nil {
+			return err
+		}
+		applied = true
+		return nil
+	})
+	if err != nil || !applied {
+		return false, err
+	}
+	contract.Version++
+	contract.UpdatedAt = updatedAt
+	return true, nil
+}
+
+func saveInstallmentPayments(tx *gorm.DB, installment domain.ContractInstallment) error {
+	return tx.Model(&domain.ContractInstallment{}).
+		Where("id = ?", installment.ID).
+		Updates(map[string]interface{}{
+			"principal_paid": installment.PrincipalPaid,
+			"margin_paid":    installment.MarginPaid,
+			"charge_paid":    installment.ChargePaid,
+			"paid_on":        installment.PaidOn,
+		}).Error
+}
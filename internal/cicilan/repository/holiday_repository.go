@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"btpntest/domain"
+
+	"gorm.io/gorm"
+)
+
+type HolidayRepository struct {
+	db *gorm.DB
+}
+
+func NewHolidayRepository(db *gorm.DB) *HolidayRepository {
+	return &HolidayRepository{db: db}
+}
+
+func (r *HolidayRepository) GetHolidays() ([]domain.Holiday, error) {
+	var holidays []domain.Holiday
+	if err := r.db.Order("holiday_date").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// HolidayFileRepository reads the holiday calendar from a JSON file of
+// {"date": "YYYY-MM-DD", "name": ..., "type": ...} entries. The file is read
+// on every call so calendar updates need no restart.
+type HolidayFileRepository struct {
+	path string
+}
+
+func NewHolidayFileRepository(path string) *HolidayFileRepository {
+	return &HolidayFileRepository{path: path}
+}
+
+type holidayFileEntry struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (r *HolidayFileRepository) GetHolidays() ([]domain.Holiday, error) {
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []holidayFileEntry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("holiday file %s: %w", r.path, err)
+	}
+
+	holidays := make([]domain.Holiday, 0, len(entries))
+	for _, entry := range entries {
+		date, err := time.Parse("2006-01-02", entry.Date)
+		if err != nil {
+			return nil, fmt.Errorf("holiday file %s: invalid date %q", r.path, entry.Date)
+		}
+		holidays = append(holidays, domain.Holiday{Date: date, Name: entry.Name, Type: entry.Type})
+	}
+	return holidays, nil
+}
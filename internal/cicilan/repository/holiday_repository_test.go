@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestNewHolidayRepository(t *testing.T) {
+	var db *gorm.DB
+	repo := NewHolidayRepository(db)
+
+	if repo == nil {
+		t.Fatal("Failed to create repository")
+	}
+
+	if repo.db != db {
+		t.Error("Repository db field not properly set")
+	}
+}
+
+func TestHolidayFileRepository_GetHolidays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.json")
+	content := `[
+  {"date": "2026-03-20", "name": "Cuti Bersama Idul Fitri 1447 H", "type": "cuti_bersama"},
+  {"date": "2026-03-21", "name": "Idul Fitri 1447 H", "type": "national"}
+]`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	holidays, err := NewHolidayFileRepository(path).GetHolidays()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(holidays) != 2 {
+		t.Fatalf("Expected 2 holidays, got %d", len(holidays))
+	}
+	if holidays[0].Date.Format("2006-01-02") != "2026-03-20" || holidays[0].Type != "cuti_bersama" {
+		t.Errorf("Unexpected first holiday: %+v", holidays[0])
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"date": "20-03-2026"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewHolidayFileRepository(path).GetHolidays(); err == nil {
+		t.Error("Expected an error for a malformed date")
+	}
+}
+
+func TestHolidayFileRepository_ShippedCalendar(t *testing.T) {
+	holidays, err := NewHolidayFileRepository("../../../conf/holidays.json").GetHolidays()
+	if err != nil {
+		t.Fatalf("Expected the shipped calendar to load, got %v", err)
+	}
+	if len(holidays) == 0 {
+		t.Error("Expected the shipped calendar to list holidays")
+	}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"btpntest/domain"
+
+	"gorm.io/gorm"
+)
+
+type LateChargeRepository struct {
+	db *gorm.DB
+}
+
+func NewLateChargeRepository(db *gorm.DB) *LateChargeRepository {
+	return &LateChargeRepository{db: db}
+}
+
+func (r *LateChargeRepository) GetLateChargeRules(ruleSet string) ([]domain.LateChargeRule, error) {
+	var rules []domain.LateChargeRule
+	if err := r.db.Where("rule_set = ?", ruleSet).Order("id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
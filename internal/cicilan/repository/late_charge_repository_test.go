@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestNewLateChargeRepository(t *testing.T) {
+	var db *gorm.DB
+	repo := NewLateChargeRepository(db)
+
+	if repo == nil {
+		t.Fatal("Failed to create repository")
+	}
+
+	if repo.db != db {
+		t.Error("Repository db field not properly set")
+	}
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"btpntest/domain"
+
+	"gorm.io/gorm"
+)
+
+// PricingHistoryRepository reads pricing versions. Versions are only ever
+// inserted, by the tenor repository, so a quote can be reproduced later.
+type PricingHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPricingHistoryRepository(db *gorm.DB) *PricingHistoryRepository {
+	return &PricingHistoryRepository{db: db}
+}
+
+// GetPricingVersion returns nil without an error when no version was in
+// effect at the given instant.
+func (r *PricingHistoryRepository) GetPricingVersion(at time.Time) (*domain.PricingVersion, error) {
+	return findPricingVersion(r.db.
+		Where("effective_at <= ?", at.UnixMilli()).
+		Order("effective_at DESC").
+		Order("id DESC"))
+}
+
+// GetPricingVersionByID returns nil without an error when no version has the ID.
+func (r *PricingHistoryRepository) GetPricingVersionByID(id int64) (*domain.PricingVersion, error) {
+	return findPricingVersion(r.db.Where("id = ?", id))
+}
+
+func findPricingVersion(query *gorm.DB) (*domain.PricingVersion, error) {
+	var version domain.PricingVersion
+	err := query.
+		Preload("Tenors", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tenor_value").Order("tenor_id")
+		}).
+		Preload("Products", func(db *gorm.DB) *gorm.DB {
+			return db.Order("code")
+		}).
+		First(&version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// recordPricingVersion snapshots the whole tenor master and product catalog
+// as a new version.
+// It runs in the transaction that changed the tenor, so every change has
+// exactly one version.
+func recordPricingVersion(tx *gorm.DB, note string) error {
+	tenors, err := listTenors(tx)
+	if err != nil {
+		return err
+	}
+
+	version := domain.PricingVersion{EffectiveAt: time.Now().UnixMilli(), Note: note}
+	for _, tenor := range tenors {
+		version.Tenors = append(version.Tenors, domain.NewPricingVersionTenor(tenor))
+	}
+
+	var products []domain.Product
+	if err := tx.Order("code").Find(&products).Error; err != nil {
+		return err
+	}
+	for _, product := range products {
+		version.Products = append(version.Products, domain.NewPricingVersionProduct(product))
+	}
+	if err := tx.Create(&version).Error; err != nil {
+		return fmt.Errorf("record pricing version: %w", err)
+	}
+	return nil
+}
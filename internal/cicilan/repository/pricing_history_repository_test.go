@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestNewPricingHistoryRepository(t *testing.T) {
+	var db *gorm.DB
+	repo := NewPricingHistoryRepository(db)
+
+	if repo == nil {
+		t.Fatal("Failed to create repository")
+	}
+
+	if repo.db != db {
+		t.Error("Repository db field not properly set")
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+
+	"btpntest/domain"
+
+	"gorm.io/gorm"
+)
+
+// QuoteRepository stores saved installment quotes.
+type QuoteRepository struct {
+	db *gorm.DB
+}
+
+func NewQuoteRepository(db *gorm.DB) *QuoteRepository {
+	return &QuoteRepository{db: db}
+}
+
+func (r *QuoteRepository) CreateQuote(quote *domain.Quote) error {
+	return r.db.Create(quote).Error
+}
+
+// GetQuote returns nil without an error when no quote has the ID.
+func (r *QuoteRepository) GetQuote(id string) (*domain.Quote, error) {
+	var quote domain.Quote
+	err := r.db.Where("id = ?", id).First(&quote).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// ListQuotes returns the newest unexpired quotes matching the filter. The
+// request and result bodies are left out; callers fetch a quote by ID for
+// those.
+func (r *QuoteRepository) ListQuotes(filter domain.QuoteFilter) ([]domain.Quote, error) {
+	query := r.db.
+		Select("id", "channel", "financed_amount", "pricing_version_id", "created_at", "expires_at").
+		Where("expires_at > ?", filter.Now)
+	if filter.From > 0 {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if filter.To > 0 {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	if filter.Channel != "" {
+		query = query.Where("channel = ?", filter.Channel)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var quotes []domain.Quote
+	if err := query.Order("created_at DESC").Order("id").Find(&quotes).Error; err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
+// DeleteExpiredQuotes keeps expired quotes that an application was created
+// from, since contracts are priced from the quoted request and its pricing
+// version.
+func (r *QuoteRepository) DeleteExpiredQuotes(now int64) (int64, error) {
+	applied := r.db.Model(&domain.Application{}).Select("quote_id")
+	result := r.db.Where("expires_at <= ? AND id NOT IN (?)", now, applied).Delete(&domain.Quote{})
+	return result.RowsAffected, result.Error
+}
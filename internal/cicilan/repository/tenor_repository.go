@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+
+	"gorm.io/gorm"
+)
+
+// TenorRepository maintains the tenor master for the admin endpoints.
+type TenorRepository struct {
+	db *gorm.DB
+}
+
+func NewTenorRepository(db *gorm.DB) *TenorRepository {
+	return &TenorRepository{db: db}
+}
+
+// ListTenors returns every tenor, active or not, ordered by length.
+func (r *TenorRepository) ListTenors() ([]domain.Tenor, error) {
+	return listTenors(r.db)
+}
+
+// GetTenorByID returns nil without an error when no tenor has the ID.
+func (r *TenorRepository) GetTenorByID(id int64) (*domain.Tenor, error) {
+	var tenor domain.Tenor
+	err := r.db.First(&tenor, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tenor, nil
+}
+
+// CreateTenor inserts the tenor and records a new pricing version.
+func (r *TenorRepository) CreateTenor(tenor *domain.Tenor) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(tenor).Error; err != nil {
+			return translateDuplicate(err)
+		}
+		return recordPricingVersion(tx, fmt.Sprintf("tenor %d created", tenor.TenorValue))
+	})
+}
+
+// UpdateTenor saves every column, so a cleared rate or date is written as
+// NULL, and records a new pricing version.
+func (r *TenorRepository) UpdateTenor(tenor *domain.Tenor) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(tenor).Error; err != nil {
+			return translateDuplicate(err)
+		}
+		return recordPricingVersion(tx, fmt.Sprintf("tenor %d updated", tenor.TenorValue))
+	})
+}
+
+// translateDuplicate reports a unique-key violation, which the connection
+// translates to gorm.ErrDuplicatedKey, as cicilan.ErrDuplicate.
+func translateDuplicate(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return cicilan.ErrDuplicate
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"btpntest/internal/cicilan"
+
+	"gorm.io/gorm"
+)
+
+func TestNewTenorRepository(t *testing.T) {
+	var db *gorm.DB
+	repo := NewTenorRepository(db)
+
+	if repo == nil {
+		t.Fatal("Failed to create repository")
+	}
+
+	if repo.db != db {
+		t.Error("Repository db field not properly set")
+	}
+}
+
+func TestTranslateDuplicate(t *testing.T) {
+	if err := translateDuplicate(fmt.Errorf("insert: %w", gorm.ErrDuplicatedKey)); !errors.Is(err, cicilan.ErrDuplicate) {
+		t.Errorf("Expected a duplicate key to become cicilan.ErrDuplicate, got %v", err)
+	}
+
+	other := errors.New("connection refused")
+	if err := translateDuplicate(other); err != other {
+		t.Errorf("Expected other errors unchanged, got %v", err)
+	}
+}
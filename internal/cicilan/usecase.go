@@ -4,4 +4,40 @@ import "btpntest/domain"
 
 type CicilanUsecase interface {
 	CalculateInstallments(req *domain.CalculateInstallmentRequest) (*domain.CalculateInstallmentResponse, error)
+	CalculateSchedule(req *domain.CalculateScheduleRequest) (*domain.InstallmentScheduleResponse, error)
+	CalculateMaxFinancing(req *domain.MaxFinancingRequest) (*domain.MaxFinancingResponse, error)
+	CalculateEarlySettlement(req *domain.EarlySettlementRequest) (*domain.EarlySettlementResponse, error)
+	SimulatePrepayment(req *domain.PrepaymentRequest) (*domain.PrepaymentResponse, error)
+}
+
+type TenorAdminUsecase interface {
+	ListTenors(req *domain.ListTenorsRequest) (*domain.ListTenorsResponse, error)
+	CreateTenor(req *domain.TenorRequest) (*domain.TenorResponse, error)
+	UpdateTenor(id int64, req *domain.TenorRequest) (*domain.TenorResponse, error)
+	DeactivateTenor(id int64) (*domain.TenorResponse, error)
+}
+
+type QuoteUsecase interface {
+	GetQuote(id string) (*domain.QuoteResponse, error)
+	ListQuotes(req *domain.ListQuotesRequest) (*domain.ListQuotesResponse, error)
+	PurgeExpiredQuotes() (int64, error)
+}
+
+type ApplicationUsecase interface {
+	CreateApplication(req *domain.CreateApplicationRequest) (*domain.ApplicationResponse, error)
+	GetApplication(id int64) (*domain.ApplicationResponse, error)
+	TransitionApplication(id int64, req *domain.TransitionApplicationRequest) (*domain.ApplicationResponse, error)
+	ListApplicationTransitions(id int64) (*domain.ApplicationTransitionsResponse, error)
+}
+
+type ContractUsecase interface {
+	OpenContract(req *domain.OpenContractRequest) (*domain.ContractResponse, error)
+	GetContract(id int64, query *domain.ContractQuery) (*domain.ContractResponse, error)
+	PostPayment(id int64, req *domain.PostPaymentRequest) (*domain.PostPaymentResponse, error)
+	ListPayments(id int64) (*domain.ListPaymentsResponse, error)
+	AssessCharge(id int64, req *domain.AssessChargeRequest) (*domain.ContractResponse, error)
+}
+
+type LateChargeUsecase interface {
+	CalculateLateCharges(req *domain.CalculateLateChargeRequest) (*domain.CalculateLateChargeResponse, error)
 }
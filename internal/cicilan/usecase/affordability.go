@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"math"
+	"math/big"
+
+	"btpntest/domain"
+)
+
+const DefaultMaxDSR = 0.4
+
+type Option func(*cicilanUsecase)
+
+// WithMaxDSR sets the highest debt-service ratio a tenor may reach before it
+// is flagged as unaffordable.
+func WithMaxDSR(ratio float64) Option {
+	return func(u *cicilanUsecase) {
+		u.maxDSR = ratio
+	}
+}
+
+func validateAffordability(input domain.AffordabilityInput) error {
+	if input.MonthlyIncome < 0 {
+		return &ValidationError{Message: "monthly_income must be greater than 0"}
+	}
+	if input.ExistingObligations < 0 {
+		return &ValidationError{Message: "existing_obligations must not be negative"}
+	}
+	if input.ExistingObligations > 0 && input.MonthlyIncome == 0 {
+		return &ValidationError{Message: "monthly_income is required when existing_obligations is given"}
+	}
+	return nil
+}
+
+// assessAffordability measures the applicant's total monthly debt service
+// against income. The largest periodic installment of the schedule is used,
+// leaving out any balloon, converted to a monthly amount for weekly and
+// bi-weekly payments.
+func assessAffordability(input domain.AffordabilityInput, maxDSR float64, rows []domain.InstallmentScheduleRow, frequency string) *domain.TenorAffordability {
+	monthly := new(big.Rat).Mul(ratFromInt(largestInstallment(rows)), big.NewRat(int64(periodsPerYear[frequency]), 12))
+	obligation := roundRat(monthly, rupiah)
+
+	ratio := float64(input.ExistingObligations+obligation) / float64(input.MonthlyIncome)
+	ratio = math.Round(ratio*1e4) / 1e4
+
+	return &domain.TenorAffordability{
+		MonthlyObligation: obligation,
+		DebtServiceRatio:  ratio,
+		ExceedsMaxDSR:     ratio > maxDSR,
+	}
+}
+
+// recommendTenor picks the shortest affordable tenor, which carries the
+// least margin among the options within the DSR limit.
+func recommendTenor(calculations []domain.InstallmentCalculation) *int {
+	var recommended *int
+	for i := range calculations {
+		calc := calculations[i]
+		if calc.Affordability == nil || calc.Affordability.ExceedsMaxDSR {
+			continue
+		}
+		if recommended == nil || calc.Tenor < *recommended {
+			tenor := calc.Tenor
+			recommended = &tenor
+		}
+	}
+	return recommended
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"testing"
+
+	"btpntest/domain"
+)
+
+func affordabilityTenors() []domain.Tenor {
+	return []domain.Tenor{
+		{ID: 1, TenorValue: 6},
+		{ID: 2, TenorValue: 12},
+		{ID: 3, TenorValue: 24},
+	}
+}
+
+func TestCalculateInstallments_DebtServiceRatio(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: affordabilityTenors()})
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:             12000000,
+		AffordabilityInput: domain.AffordabilityInput{MonthlyIncome: 5000000, ExistingObligations: 1000000},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[int]domain.TenorAffordability{
+		6:  {MonthlyObligation: 2200000, DebtServiceRatio: 0.64, ExceedsMaxDSR: true},
+		12: {MonthlyObligation: 1200000, DebtServiceRatio: 0.44, ExceedsMaxDSR: true},
+		24: {MonthlyObligation: 700000, DebtServiceRatio: 0.34, ExceedsMaxDSR: false},
+	}
+
+	for _, calc := range resp.Calculations {
+		if calc.Affordability == nil {
+			t.Fatalf("Tenor %d: expected affordability to be reported", calc.Tenor)
+		}
+		if *calc.Affordability != expected[calc.Tenor] {
+			t.Errorf("Tenor %d: expected %+v, got %+v", calc.Tenor, expected[calc.Tenor], *calc.Affordability)
+		}
+	}
+
+	if resp.Affordability == nil || resp.Affordability.MaxDSR != DefaultMaxDSR {
+		t.Fatalf("Expected affordability summary with max DSR %v, got %+v", DefaultMaxDSR, resp.Affordability)
+	}
+	if resp.Affordability.RecommendedTenor == nil || *resp.Affordability.RecommendedTenor != 24 {
+		t.Errorf("Expected recommended tenor 24, got %v", resp.Affordability.RecommendedTenor)
+	}
+}
+
+func TestCalculateInstallments_BalloonLeftOutOfDebtService(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: affordabilityTenors()})
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:             12000000,
+		Tenors:             []int{12},
+		AffordabilityInput: domain.AffordabilityInput{MonthlyIncome: 5000000, ExistingObligations: 1000000},
+		PricingOptions: domain.PricingOptions{
+			Profile: &domain.InstallmentProfile{Type: domain.ProfileBalloon, BalloonRate: 0.5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The 6,000,000 balloon is paid once, not every month.
+	expected := domain.TenorAffordability{MonthlyObligation: 700000, DebtServiceRatio: 0.34, ExceedsMaxDSR: false}
+	if got := resp.Calculations[0].Affordability; got == nil || *got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestCalculateInstallments_ConfiguredMaxDSR(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: affordabilityTenors()}, WithMaxDSR(0.5))
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:             12000000,
+		AffordabilityInput: domain.AffordabilityInput{MonthlyIncome: 5000000, ExistingObligations: 1000000},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Affordability.RecommendedTenor == nil || *resp.Affordability.RecommendedTenor != 12 {
+		t.Errorf("Expected recommended tenor 12, got %v", resp.Affordability.RecommendedTenor)
+	}
+}
+
+func TestCalculateInstallments_NoAffordableTenor(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: affordabilityTenors()})
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:             12000000,
+		AffordabilityInput: domain.AffordabilityInput{MonthlyIncome: 1000000},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Affordability.RecommendedTenor != nil {
+		t.Errorf("Expected no recommended tenor, got %d", *resp.Affordability.RecommendedTenor)
+	}
+}
+
+func TestCalculateInstallments_WeeklyObligationIsMonthly(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: []domain.Tenor{
+		{ID: 1, TenorValue: 12, PaymentFrequencies: "monthly,weekly"},
+	}})
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:             12000000,
+		PricingOptions:     domain.PricingOptions{PaymentFrequency: domain.FrequencyWeekly},
+		AffordabilityInput: domain.AffordabilityInput{MonthlyIncome: 6000000},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 52 weekly installments of 14,400,000 / 52 = 276,923 (last one 276,927).
+	affordability := resp.Calculations[0].Affordability
+	if affordability.MonthlyObligation != 1200017 {
+		t.Errorf("Expected monthly obligation 1200017, got %d", affordability.MonthlyObligation)
+	}
+}
+
+func TestCalculateInstallments_AffordabilityOptional(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: affordabilityTenors()})
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 12000000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Affordability != nil {
+		t.Errorf("Expected no affordability summary, got %+v", resp.Affordability)
+	}
+	for _, calc := range resp.Calculations {
+		if calc.Affordability != nil {
+			t.Errorf("Tenor %d: expected no affordability, got %+v", calc.Tenor, calc.Affordability)
+		}
+	}
+
+	_, err = usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:             12000000,
+		AffordabilityInput: domain.AffordabilityInput{ExistingObligations: 500000},
+	})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected validation error for obligations without income, got %v", err)
+	}
+}
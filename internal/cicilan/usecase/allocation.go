@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"btpntest/domain"
+)
+
+// componentOrder is the order in which the parts of one installment are
+// settled.
+var componentOrder = []string{domain.ComponentCharge, domain.ComponentMargin, domain.ComponentPrincipal}
+
+func resolveAllocationOrder(order string) (string, error) {
+	switch order {
+	case "":
+		return domain.AllocationChargesMarginPrincipal, nil
+	case domain.AllocationChargesMarginPrincipal, domain.AllocationOldestInstallment:
+		return order, nil
+	}
+	return "", &ValidationError{Field: "allocation_order", Message: fmt.Sprintf("unsupported allocation order: %s", order)}
+}
+
+// allocatePayment spreads amount over the installments, which must be in
+// installment order, and records what it paid on them. Installments due on
+// or before paidOn are settled in the contract's allocation order. Whatever
+// is left then pays the later installments ahead of time, oldest first, and
+// anything beyond the whole outstanding balance is returned as excess.
+// Installments that were paid in full by this payment get paidOn.
+func allocatePayment(installments []domain.ContractInstallment, amount int64, order string, paidOn time.Time) ([]domain.PaymentAllocation, int64) {
+	var due, notDue []*domain.ContractInstallment
+	for i := range installments {
+		if installments[i].Outstanding() == 0 {
+			continue
+		}
+		if calendarDate(installments[i].DueDate).After(paidOn) {
+			notDue = append(notDue, &installments[i])
+		} else {
+			due = append(due, &installments[i])
+		}
+	}
+
+	var allocations []domain.PaymentAllocation
+	remaining := amount
+	pay := func(installment *domain.ContractInstallment, component string) {
+		paid, owed := installmentComponent(installment, component)
+		portion := min(remaining, owed-*paid)
+		if portion <= 0 {
+			return
+		}
+		*paid += portion
+		remaining -= portion
+		allocations = append(allocations, domain.PaymentAllocation{
+			InstallmentNumber: installment.InstallmentNumber,
+			Component:         component,
+			Amount:            portion,
+		})
+		if installment.Outstanding() == 0 {
+			settledOn := paidOn
+			installment.PaidOn = &settledOn
+		}
+	}
+
+	if order == domain.AllocationChargesMarginPrincipal {
+		for _, component := range componentOrder {
+			for _, installment := range due {
+				pay(installment, component)
+			}
+		}
+	} else {
+		for _, installment := range due {
+			for _, component := range componentOrder {
+				pay(installment, component)
+			}
+		}
+	}
+
+	for _, installment := range notDue {
+		for _, component := range componentOrder {
+			pay(installment, component)
+		}
+	}
+	return allocations, remaining
+}
+
+// installmentComponent returns the paid counter and the amount owed for one
+// component of the installment.
+func installmentComponent(installment *domain.ContractInstallment, component string) (*int64, int64) {
+	switch component {
+	case domain.ComponentCharge:
+		return &installment.ChargePaid, installment.ChargeDue
+	case domain.ComponentMargin:
+		return &installment.MarginPaid, installment.MarginDue
+	default:
+		return &installment.PrincipalPaid, installment.PrincipalDue
+	}
+}
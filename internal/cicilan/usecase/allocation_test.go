@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+func ledgerInstallments() []domain.ContractInstallment {
+	installments := make([]domain.ContractInstallment, 3)
+	for i := range installments {
+		installments[i] = domain.ContractInstallment{
+			InstallmentNumber: i + 1,
+			DueDate:           time.Date(2026, time.Month(11+i), 20, 0, 0, 0, 0, time.UTC),
+			PrincipalDue:      1000000,
+			MarginDue:         100000,
+		}
+	}
+	installments[0].ChargeDue = 25000
+	return installments
+}
+
+func TestAllocatePayment_Orders(t *testing.T) {
+	paidOn := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		order    string
+		expected []domain.PaymentAllocation
+	}{
+		{domain.AllocationChargesMarginPrincipal, []domain.PaymentAllocation{
+			{InstallmentNumber: 1, Component: domain.ComponentCharge, Amount: 25000},
+			{InstallmentNumber: 1, Component: domain.ComponentMargin, Amount: 100000},
+			{InstallmentNumber: 2, Component: domain.ComponentMargin, Amount: 100000},
+			{InstallmentNumber: 1, Component: domain.ComponentPrincipal, Amount: 275000},
+		}},
+		{domain.AllocationOldestInstallment, []domain.PaymentAllocation{
+			{InstallmentNumber: 1, Component: domain.ComponentCharge, Amount: 25000},
+			{InstallmentNumber: 1, Component: domain.ComponentMargin, Amount: 100000},
+			{InstallmentNumber: 1, Component: domain.ComponentPrincipal, Amount: 375000},
+		}},
+	}
+
+	for _, tt := range tests {
+		installments := ledgerInstallments()
+		allocations, excess := allocatePayment(installments, 500000, tt.order, paidOn)
+
+		if excess != 0 {
+			t.Errorf("%s: expected no excess, got %d", tt.order, excess)
+		}
+		if !reflect.DeepEqual(allocations, tt.expected) {
+			t.Errorf("%s: expected allocations %+v, got %+v", tt.order, tt.expected, allocations)
+		}
+		if installments[0].PaidOn != nil {
+			t.Errorf("%s: expected installment 1 to stay partially paid", tt.order)
+		}
+	}
+}
+
+func TestAllocatePayment_AdvanceAndExcess(t *testing.T) {
+	installments := ledgerInstallments()
+	dueDate := installments[0].DueDate
+
+	// Only the first installment is due; the rest pays the second ahead.
+	allocations, excess := allocatePayment(installments, 1200000, domain.AllocationChargesMarginPrincipal, dueDate)
+	if excess != 0 || installments[0].Outstanding() != 0 || installments[1].MarginPaid != 75000 {
+		t.Errorf("Expected installment 1 settled and 75000 margin paid ahead on 2, got %+v (excess %d)", installments, excess)
+	}
+	if installments[0].PaidOn == nil || !installments[0].PaidOn.Equal(dueDate) {
+		t.Errorf("Expected installment 1 paid on its due date, got %v", installments[0].PaidOn)
+	}
+	if last := allocations[len(allocations)-1]; last.InstallmentNumber != 2 || last.Component != domain.ComponentMargin {
+		t.Errorf("Expected the advance to start with installment 2 margin, got %+v", last)
+	}
+
+	// 3,325,000 owed in total less 1,200,000 paid leaves 2,125,000.
+	_, excess = allocatePayment(installments, 2200000, domain.AllocationOldestInstallment, dueDate)
+	if excess != 75000 {
+		t.Errorf("Expected 75000 excess, got %d", excess)
+	}
+	for _, installment := range installments {
+		if installment.Outstanding() != 0 || installment.PaidOn == nil {
+			t.Errorf("Expected installment %d settled, got %+v", installment.InstallmentNumber, installment)
+		}
+	}
+}
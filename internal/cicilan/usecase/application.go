@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+// applicationTransitions lists the statuses each status may move to.
+// Rejected, disbursed and cancelled applications are final.
+var applicationTransitions = map[string][]string{
+	domain.ApplicationDraft:       {domain.ApplicationSubmitted, domain.ApplicationCancelled},
+	domain.ApplicationSubmitted:   {domain.ApplicationUnderReview, domain.ApplicationCancelled},
+	domain.ApplicationUnderReview: {domain.ApplicationApproved, domain.ApplicationRejected, domain.ApplicationCancelled},
+	domain.ApplicationApproved:    {domain.ApplicationDisbursed, domain.ApplicationCancelled},
+	domain.ApplicationRejected:    {},
+	domain.ApplicationDisbursed:   {},
+	domain.ApplicationCancelled:   {},
+}
+
+type applicationUsecase struct {
+	repo   cicilan.ApplicationRepository
+	quotes cicilan.QuoteRepository
+	now    func() time.Time
+}
+
+func NewApplicationUsecase(repo cicilan.ApplicationRepository, quotes cicilan.QuoteRepository) cicilan.ApplicationUsecase {
+	return &applicationUsecase{repo: repo, quotes: quotes, now: time.Now}
+}
+
+// CreateApplication starts a draft application for one tenor of an
+// unexpired quote.
+func (u *applicationUsecase) CreateApplication(req *domain.CreateApplicationRequest) (*domain.ApplicationResponse, error) {
+	quote, err := u.quotes.GetQuote(req.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		return nil, &NotFoundError{Message: fmt.Sprintf("quote %s not found", req.QuoteID)}
+	}
+
+	now := u.now().UnixMilli()
+	if quote.ExpiresAt <= now {
+		return nil, &ValidationError{
+			Field:   "quote_id",
+			Code:    domain.CodeQuoteExpired,
+			Message: fmt.Sprintf("quote %s expired at %s", quote.ID, formatMillis(quote.ExpiresAt)),
+		}
+	}
+
+	var result domain.CalculateInstallmentResponse
+	if err := json.Unmarshal([]byte(quote.ResultBody), &result); err != nil {
+		return nil, fmt.Errorf("decode quote %s result: %w", quote.ID, err)
+	}
+	var calculation *domain.InstallmentCalculation
+	for i := range result.Calculations {
+		if result.Calculations[i].Tenor == req.Tenor {
+			calculation = &result.Calculations[i]
+			break
+		}
+	}
+	if calculation == nil {
+		return nil, &ValidationError{
+			Field:   "tenor",
+			Code:    domain.CodeTenorNotQuoted,
+			Message: fmt.Sprintf("quote %s has no calculation for tenor %d", quote.ID, req.Tenor),
+		}
+	}
+
+	application := domain.Application{
+		QuoteID:            quote.ID,
+		Channel:            quote.Channel,
+		Tenor:              calculation.Tenor,
+		PricingVersionID:   quote.PricingVersionID,
+		FinancedAmount:     quote.FinancedAmount,
+		MonthlyInstallment: calculation.MonthlyInstallment,
+		TotalPayment:       calculation.TotalPayment,
+		Status:             domain.ApplicationDraft,
+		CreatedBy:          req.Actor,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	transition := domain.ApplicationTransition{
+		ToStatus:  domain.ApplicationDraft,
+		Actor:     req.Actor,
+		Note:      req.Note,
+		CreatedAt: now,
+	}
+	if err := u.repo.CreateApplication(&application, &transition); err != nil {
+		return nil, err
+	}
+	return applicationResponse(application), nil
+}
+
+func (u *applicationUsecase) GetApplication(id int64) (*domain.ApplicationResponse, error) {
+	application, err := u.findApplication(id)
+	if err != nil {
+		return nil, err
+	}
+	return applicationResponse(*application), nil
+}
+
+// TransitionApplication moves the application to req.Status when the state
+// machine allows it from the current status.
+func (u *applicationUsecase) TransitionApplication(id int64, req *domain.TransitionApplicationRequest) (*domain.ApplicationResponse, error) {
+	if _, ok := applicationTransitions[req.Status]; !ok {
+		return nil, &ValidationError{Field: "status", Code: domain.CodeInvalidTransition, Message: fmt.Sprintf("unknown application status: %s", req.Status)}
+	}
+
+	application, err := u.findApplication(id)
+	if err != nil {
+		return nil, err
+	}
+	if !canTransition(application.Status, req.Status) {
+		return nil, &ValidationError{
+			Field:   "status",
+			Code:    domain.CodeInvalidTransition,
+			Message: fmt.Sprintf("application %d cannot move from %s to %s", id, application.Status, req.Status),
+		}
+	}
+
+	transition := domain.ApplicationTransition{
+		ApplicationID: id,
+		FromStatus:    application.Status,
+		ToStatus:      req.Status,
+		Actor:         req.Actor,
+		Note:          req.Note,
+		CreatedAt:     u.now().UnixMilli(),
+	}
+	applied, err := u.repo.TransitionApplication(&transition)
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return nil, &ConflictError{
+			Field:   "status",
+			Code:    domain.CodeApplicationConflict,
+			Message: fmt.Sprintf("application %d changed while moving to %s; reload and retry", id, req.Status),
+		}
+	}
+
+	application.Status = transition.ToStatus
+	application.UpdatedAt = transition.CreatedAt
+	return applicationResponse(*application), nil
+}
+
+func (u *applicationUsecase) ListApplicationTransitions(id int64) (*domain.ApplicationTransitionsResponse, error) {
+	application, err := u.findApplication(id)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions, err := u.repo.ListApplicationTransitions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.ApplicationTransitionsResponse{
+		ApplicationID: id,
+		Status:        application.Status,
+		Transitions:   make([]domain.ApplicationTransitionResponse, 0, len(transitions)),
+	}
+	for _, transition := range transitions {
+		response.Transitions = append(response.Transitions, domain.ApplicationTransitionResponse{
+			FromStatus: transition.FromStatus,
+			ToStatus:   transition.ToStatus,
+			Actor:      transition.Actor,
+			Note:       transition.Note,
+			At:         formatMillis(transition.CreatedAt),
+		})
+	}
+	return response, nil
+}
+
+func (u *applicationUsecase) findApplication(id int64) (*domain.Application, error) {
+	application, err := u.repo.GetApplication(id)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, &NotFoundError{Message: fmt.Sprintf("application %d not found", id)}
+	}
+	return application, nil
+}
+
+func canTransition(from, to string) bool {
+	for _, next := range applicationTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func applicationResponse(application domain.Application) *domain.ApplicationResponse {
+	return &domain.ApplicationResponse{
+		ID:                 application.ID,
+		QuoteID:            application.QuoteID,
+		Channel:            application.Channel,
+		Tenor:              application.Tenor,
+		PricingVersionID:   application.PricingVersionID,
+		FinancedAmount:     application.FinancedAmount,
+		MonthlyInstallment: application.MonthlyInstallment,
+		TotalPayment:       application.TotalPayment,
+		Status:             application.Status,
+		NextStatuses:       append([]string{}, applicationTransitions[application.Status]...),
+		CreatedBy:          application.CreatedBy,
+		CreatedAt:          formatMillis(application.CreatedAt),
+		UpdatedAt:          formatMillis(application.UpdatedAt),
+	}
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+type MockApplicationRepository struct {
+	applications []domain.Application
+	transitions  []domain.ApplicationTransition
+	// raced makes the next transition find the status already changed.
+	raced bool
+}
+
+func (m *MockApplicationRepository) CreateApplication(application *domain.Application, transition *domain.ApplicationTransition) error {
+	application.ID = int64(len(m.applications) + 1)
+	transition.ApplicationID = application.ID
+	m.applications = append(m.applications, *application)
+	m.transitions = append(m.transitions, *transition)
+	return nil
+}
+
+func (m *MockApplicationRepository) GetApplication(id int64) (*domain.Application, error) {
+	for _, application := range m.applications {
+		if application.ID == id {
+			return &application, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockApplicationRepository) TransitionApplication(transition *domain.ApplicationTransition) (bool, error) {
+	if m.raced {
+		m.raced = false
+		return false, nil
+	}
+	for i := range m.applications {
+		if m.applications[i].ID == transition.ApplicationID && m.applications[i].Status == transition.FromStatus {
+			m.applications[i].Status = transition.ToStatus
+			m.applications[i].UpdatedAt = transition.CreatedAt
+			m.transitions = append(m.transitions, *transition)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockApplicationRepository) ListApplicationTransitions(applicationID int64) ([]domain.ApplicationTransition, error) {
+	var transitions []domain.ApplicationTransition
+	for _, transition := range m.transitions {
+		if transition.ApplicationID == applicationID {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions, nil
+}
+
+var applicationClock = time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+func quotedApplications(t *testing.T) (*applicationUsecase, *MockApplicationRepository) {
+	result, err := json.Marshal(domain.CalculateInstallmentResponse{Calculations: []domain.InstallmentCalculation{
+		{Tenor: 6, MonthlyInstallment: 1816667, TotalPayment: 10900000},
+		{Tenor: 12, MonthlyInstallment: 1000000, TotalPayment: 12000000},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quotes := &MockQuoteRepository{quotes: []domain.Quote{
+		{ID: "live", Channel: "mobile", FinancedAmount: 10000000, PricingVersionID: 3, ResultBody: string(result), ExpiresAt: applicationClock.Add(time.Hour).UnixMilli()},
+		{ID: "expired", ResultBody: string(result), ExpiresAt: applicationClock.Add(-time.Hour).UnixMilli()},
+	}}
+	repo := &MockApplicationRepository{}
+	return &applicationUsecase{repo: repo, quotes: quotes, now: func() time.Time { return applicationClock }}, repo
+}
+
+func TestCreateApplication(t *testing.T) {
+	usecase, repo := quotedApplications(t)
+
+	resp, err := usecase.CreateApplication(&domain.CreateApplicationRequest{QuoteID: "live", Tenor: 12, Actor: "customer:8812"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Status != domain.ApplicationDraft || resp.MonthlyInstallment != 1000000 || resp.TotalPayment != 12000000 ||
+		resp.FinancedAmount != 10000000 || resp.PricingVersionID != 3 || resp.Channel != "mobile" {
+		t.Errorf("Expected a draft with the quoted 12-month terms, got %+v", resp)
+	}
+	expectedNext := []string{domain.ApplicationSubmitted, domain.ApplicationCancelled}
+	if len(resp.NextStatuses) != 2 || resp.NextStatuses[0] != expectedNext[0] || resp.NextStatuses[1] != expectedNext[1] {
+		t.Errorf("Expected next statuses %v, got %v", expectedNext, resp.NextStatuses)
+	}
+	if len(repo.transitions) != 1 || repo.transitions[0].FromStatus != "" || repo.transitions[0].Actor != "customer:8812" {
+		t.Errorf("Expected the creation recorded as the first transition, got %+v", repo.transitions)
+	}
+}
+
+func TestCreateApplication_QuoteChecks(t *testing.T) {
+	usecase, _ := quotedApplications(t)
+
+	tests := []struct {
+		name string
+		req  domain.CreateApplicationRequest
+		code string
+	}{
+		{"Expired", domain.CreateApplicationRequest{QuoteID: "expired", Tenor: 12, Actor: "a"}, domain.CodeQuoteExpired},
+		{"TenorNotQuoted", domain.CreateApplicationRequest{QuoteID: "live", Tenor: 24, Actor: "a"}, domain.CodeTenorNotQuoted},
+	}
+	for _, tt := range tests {
+		_, err := usecase.CreateApplication(&tt.req)
+		if validationErr, ok := err.(*ValidationError); !ok || validationErr.Code != tt.code {
+			t.Errorf("%s: expected %s, got %v", tt.name, tt.code, err)
+		}
+	}
+
+	if _, err := usecase.CreateApplication(&domain.CreateApplicationRequest{QuoteID: "missing", Tenor: 12, Actor: "a"}); err == nil {
+		t.Error("Expected an error for an unknown quote")
+	} else if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %T", err)
+	}
+}
+
+func TestTransitionApplication_Lifecycle(t *testing.T) {
+	usecase, repo := quotedApplications(t)
+	created, err := usecase.CreateApplication(&domain.CreateApplicationRequest{QuoteID: "live", Tenor: 12, Actor: "customer:8812"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	steps := []struct{ status, actor string }{
+		{domain.ApplicationSubmitted, "customer:8812"},
+		{domain.ApplicationUnderReview, "officer:017"},
+		{domain.ApplicationApproved, "officer:017"},
+		{domain.ApplicationDisbursed, "system"},
+	}
+	for _, step := range steps {
+		resp, err := usecase.TransitionApplication(created.ID, &domain.TransitionApplicationRequest{Status: step.status, Actor: step.actor})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", step.status, err)
+		}
+		if resp.Status != step.status {
+			t.Errorf("Expected status %s, got %s", step.status, resp.Status)
+		}
+	}
+	if repo.applications[0].Status != domain.ApplicationDisbursed {
+		t.Errorf("Expected the stored application disbursed, got %s", repo.applications[0].Status)
+	}
+
+	history, err := usecase.ListApplicationTransitions(created.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if history.Status != domain.ApplicationDisbursed || len(history.Transitions) != 5 {
+		t.Fatalf("Expected 5 transitions ending disbursed, got %+v", history)
+	}
+	last := history.Transitions[4]
+	if last.FromStatus != domain.ApplicationApproved || last.ToStatus != domain.ApplicationDisbursed || last.Actor != "system" || last.At != "2026-10-18T09:00:00Z" {
+		t.Errorf("Expected approved to disbursed by system, got %+v", last)
+	}
+
+	_, err = usecase.TransitionApplication(created.ID, &domain.TransitionApplicationRequest{Status: domain.ApplicationCancelled, Actor: "customer:8812"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Code != domain.CodeInvalidTransition {
+		t.Errorf("Expected a disbursed application to be final, got %v", err)
+	}
+}
+
+func TestTransitionApplication_Invalid(t *testing.T) {
+	usecase, repo := quotedApplications(t)
+	created, err := usecase.CreateApplication(&domain.CreateApplicationRequest{QuoteID: "live", Tenor: 6, Actor: "a"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, status := range []string{domain.ApplicationApproved, domain.ApplicationDraft, "archived"} {
+		_, err := usecase.TransitionApplication(created.ID, &domain.TransitionApplicationRequest{Status: status, Actor: "a"})
+		if validationErr, ok := err.(*ValidationError); !ok || validationErr.Code != domain.CodeInvalidTransition {
+			t.Errorf("%s: expected %s, got %v", status, domain.CodeInvalidTransition, err)
+		}
+	}
+	if len(repo.transitions) != 1 {
+		t.Errorf("Expected rejected transitions not to be recorded, got %d", len(repo.transitions))
+	}
+
+	repo.raced = true
+	_, err = usecase.TransitionApplication(created.ID, &domain.TransitionApplicationRequest{Status: domain.ApplicationSubmitted, Actor: "a"})
+	if conflictErr, ok := err.(*ConflictError); !ok || conflictErr.Code != domain.CodeApplicationConflict {
+		t.Errorf("Expected %s when the status changed concurrently, got %v", domain.CodeApplicationConflict, err)
+	}
+
+	if _, err := usecase.TransitionApplication(99, &domain.TransitionApplicationRequest{Status: domain.ApplicationSubmitted, Actor: "a"}); err == nil {
+		t.Error("Expected an error for an unknown application")
+	} else if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %T", err)
+	}
+}
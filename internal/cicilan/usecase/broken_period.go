@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"btpntest/domain"
+)
+
+func resolveBrokenPeriod(treatment, dayCount string) (string, string, error) {
+	switch treatment {
+	case "":
+		treatment = domain.BrokenPeriodNone
+	case domain.BrokenPeriodNone, domain.BrokenPeriodFirstInstallment, domain.BrokenPeriodSpread:
+	default:
+		return "", "", &ValidationError{
+			Field:   "broken_period_margin",
+			Message: fmt.Sprintf("unsupported broken_period_margin: %s", treatment),
+		}
+	}
+
+	switch dayCount {
+	case "":
+		dayCount = domain.DayCountActual365
+	case domain.DayCount30360, domain.DayCountActual365, domain.DayCountActualActual:
+	default:
+		return "", "", &ValidationError{
+			Field:   "day_count_convention",
+			Message: fmt.Sprintf("unsupported day_count_convention: %s", dayCount),
+		}
+	}
+
+	return treatment, dayCount, nil
+}
+
+// regularStart is where the first regular period begins: one period before
+// the unadjusted first due date. Only a payment day can move it away from
+// the disbursement date.
+func (r dueDateRule) regularStart(startDate time.Time, frequency string) time.Time {
+	if frequency != domain.FrequencyMonthly || r.paymentDay == 0 {
+		return startDate
+	}
+	return paymentDate(startDate, 0, r.paymentDay)
+}
+
+// applyBrokenPeriod charges margin for the days between disbursement and the
+// start of the first regular period at the tenor's annual rate on the
+// principal, and books it on the rows as the treatment requires.
+func (t pricingTerms) applyBrokenPeriod(rows []domain.InstallmentScheduleRow, principal int64, rate float64) *domain.BrokenPeriod {
+	rule := t.dueDates
+	if rule.brokenPeriod == "" || rule.brokenPeriod == domain.BrokenPeriodNone {
+		return nil
+	}
+
+	regularStart := rule.regularStart(t.startDate, t.frequency)
+	days, fraction := yearFraction(t.startDate, regularStart, rule.dayCount)
+	fractionValue, _ := fraction.Float64()
+
+	margin := new(big.Rat).Mul(ratFromInt(principal), ratFromFloat(rate))
+	broken := &domain.BrokenPeriod{
+		DisbursementDate:   t.startDate.Format(dateLayout),
+		RegularStartDate:   regularStart.Format(dateLayout),
+		Days:               days,
+		DayCountConvention: rule.dayCount,
+		YearFraction:       math.Round(fractionValue*1e6) / 1e6,
+		Margin:             roundRat(margin.Mul(margin, fraction), rupiah),
+		Treatment:          rule.brokenPeriod,
+	}
+
+	bookBrokenPeriod(rows, broken.Margin, rule.brokenPeriod)
+	return broken
+}
+
+// bookBrokenPeriod adds margin to the first row or spreads it evenly over the
+// rows, leaving the remainder on the last one.
+func bookBrokenPeriod(rows []domain.InstallmentScheduleRow, margin int64, treatment string) {
+	if len(rows) == 0 {
+		return
+	}
+
+	shares := make([]int64, len(rows))
+	if treatment == domain.BrokenPeriodFirstInstallment {
+		shares[0] = margin
+	} else {
+		share := margin / int64(len(rows))
+		for i := range shares {
+			shares[i] = share
+		}
+		shares[len(shares)-1] += margin - share*int64(len(rows))
+	}
+
+	for i, share := range shares {
+		rows[i].BrokenPeriodMargin = share
+		rows[i].Margin += share
+		rows[i].Installment += share
+	}
+}
+
+// regularInstallment is the first installment after any grace period,
+// without broken-period margin.
+func regularInstallment(rows []domain.InstallmentScheduleRow) int64 {
+	for _, row := range rows {
+		if !row.Grace {
+			return row.Installment - row.BrokenPeriodMargin
+		}
+	}
+	return 0
+}
+
+// yearFraction measures from start to end under the day-count convention,
+// returning the counted days and the fraction of a year; both are negative
+// when end is before start.
+func yearFraction(start, end time.Time, convention string) (int, *big.Rat) {
+	if end.Before(start) {
+		days, fraction := yearFraction(end, start, convention)
+		return -days, fraction.Neg(fraction)
+	}
+
+	switch convention {
+	case domain.DayCount30360:
+		days := days30360(start, end)
+		return days, big.NewRat(int64(days), 360)
+	case domain.DayCountActualActual:
+		fraction := new(big.Rat)
+		for from := start; from.Before(end); {
+			nextYear := time.Date(from.Year()+1, time.January, 1, 0, 0, 0, 0, from.Location())
+			to := end
+			if nextYear.Before(end) {
+				to = nextYear
+			}
+			fraction.Add(fraction, big.NewRat(int64(daysBetween(from, to)), int64(daysInYear(from.Year()))))
+			from = to
+		}
+		return daysBetween(start, end), fraction
+	default:
+		days := daysBetween(start, end)
+		return days, big.NewRat(int64(days), 365)
+	}
+}
+
+// days30360 counts days under the 30/360 bond basis.
+func days30360(start, end time.Time) int {
+	startDay, endDay := start.Day(), end.Day()
+	if startDay == 31 {
+		startDay = 30
+	}
+	if endDay == 31 && startDay == 30 {
+		endDay = 30
+	}
+	return 360*(end.Year()-start.Year()) + 30*(int(end.Month())-int(start.Month())) + endDay - startDay
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+func brokenPeriodSchedule(t *testing.T, startDate string, options domain.DueDateOptions) *domain.InstallmentScheduleResponse {
+	t.Helper()
+
+	rate := 0.2
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12, FlatMarginRate: &rate}}}
+	resp, err := NewCicilanUsecase(mockRepo).CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:         10000000,
+		Tenor:          12,
+		StartDate:      startDate,
+		DueDateOptions: options,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp
+}
+
+func TestCalculateSchedule_BrokenPeriodFirstInstallment(t *testing.T) {
+	plain := brokenPeriodSchedule(t, "2026-01-10", domain.DueDateOptions{PaymentDay: 20})
+	resp := brokenPeriodSchedule(t, "2026-01-10", domain.DueDateOptions{
+		PaymentDay:         20,
+		BrokenPeriodMargin: domain.BrokenPeriodFirstInstallment,
+	})
+
+	// 10 days from 10 to 20 January: 10,000,000 x 20% x 10/365.
+	broken := resp.BrokenPeriod
+	if broken == nil || broken.Days != 10 || broken.Margin != 54795 || broken.RegularStartDate != "2026-01-20" {
+		t.Fatalf("Expected a 10-day broken period of 54795, got %+v", broken)
+	}
+	if broken.DayCountConvention != domain.DayCountActual365 || broken.YearFraction != 0.027397 {
+		t.Errorf("Expected act/365 with fraction 0.027397, got %s %v", broken.DayCountConvention, broken.YearFraction)
+	}
+
+	first := resp.Schedule[0]
+	if first.BrokenPeriodMargin != 54795 || first.Installment != plain.Schedule[0].Installment+54795 {
+		t.Errorf("Expected the first installment to carry 54795, got %+v", first)
+	}
+	for _, row := range resp.Schedule[1:] {
+		if row.BrokenPeriodMargin != 0 {
+			t.Errorf("Installment %d: expected no broken-period margin, got %d", row.InstallmentNumber, row.BrokenPeriodMargin)
+		}
+	}
+	if resp.MonthlyInstallment != plain.MonthlyInstallment {
+		t.Errorf("Expected the regular installment %d, got %d", plain.MonthlyInstallment, resp.MonthlyInstallment)
+	}
+	if resp.TotalMargin != plain.TotalMargin+54795 || resp.TotalPayment != plain.TotalPayment+54795 {
+		t.Errorf("Expected totals to include the broken period, got margin %d payment %d", resp.TotalMargin, resp.TotalPayment)
+	}
+	assertScheduleReconciles(t, resp)
+}
+
+func TestCalculateSchedule_BrokenPeriodSpread(t *testing.T) {
+	// 8 days short: the first regular period starts on 20 January, before
+	// the 28 January disbursement, so the margin is refunded.
+	resp := brokenPeriodSchedule(t, "2026-01-28", domain.DueDateOptions{
+		PaymentDay:         20,
+		BrokenPeriodMargin: domain.BrokenPeriodSpread,
+		DayCountConvention: domain.DayCount30360,
+	})
+
+	broken := resp.BrokenPeriod
+	if broken == nil || broken.Days != -8 || broken.Margin != -44444 {
+		t.Fatalf("Expected a -8 day broken period of -44444, got %+v", broken)
+	}
+
+	var total int64
+	for _, row := range resp.Schedule {
+		total += row.BrokenPeriodMargin
+	}
+	if total != broken.Margin {
+		t.Errorf("Expected spread shares to total %d, got %d", broken.Margin, total)
+	}
+	if resp.Schedule[0].BrokenPeriodMargin != -3703 || resp.Schedule[11].BrokenPeriodMargin != -3711 {
+		t.Errorf("Expected -3703 per installment with the remainder last, got %d and %d",
+			resp.Schedule[0].BrokenPeriodMargin, resp.Schedule[11].BrokenPeriodMargin)
+	}
+	assertScheduleReconciles(t, resp)
+}
+
+func TestCalculateSchedule_NoBrokenPeriodByDefault(t *testing.T) {
+	resp := brokenPeriodSchedule(t, "2026-01-10", domain.DueDateOptions{PaymentDay: 20})
+	if resp.BrokenPeriod != nil {
+		t.Errorf("Expected no broken period without a treatment, got %+v", resp.BrokenPeriod)
+	}
+
+	// Without a payment day the first period is regular.
+	resp = brokenPeriodSchedule(t, "2026-01-10", domain.DueDateOptions{BrokenPeriodMargin: domain.BrokenPeriodFirstInstallment})
+	if resp.BrokenPeriod == nil || resp.BrokenPeriod.Days != 0 || resp.BrokenPeriod.Margin != 0 {
+		t.Errorf("Expected an empty broken period, got %+v", resp.BrokenPeriod)
+	}
+}
+
+func TestYearFraction(t *testing.T) {
+	tests := []struct {
+		convention   string
+		start, end   string
+		expectedDays int
+		expected     *big.Rat
+	}{
+		{domain.DayCountActual365, "2026-01-10", "2026-01-20", 10, big.NewRat(10, 365)},
+		{domain.DayCount30360, "2026-01-31", "2026-03-01", 31, big.NewRat(31, 360)},
+		{domain.DayCount30360, "2026-01-30", "2026-03-31", 60, big.NewRat(60, 360)},
+		{domain.DayCountActualActual, "2027-12-22", "2028-01-11", 20, new(big.Rat).Add(big.NewRat(10, 365), big.NewRat(10, 366))},
+		{domain.DayCountActualActual, "2028-01-20", "2028-01-10", -10, big.NewRat(-10, 366)},
+	}
+
+	for _, tt := range tests {
+		start, _ := time.Parse(dateLayout, tt.start)
+		end, _ := time.Parse(dateLayout, tt.end)
+
+		days, fraction := yearFraction(start, end, tt.convention)
+		if days != tt.expectedDays || fraction.Cmp(tt.expected) != 0 {
+			t.Errorf("%s %s to %s: expected %d days and %s, got %d and %s",
+				tt.convention, tt.start, tt.end, tt.expectedDays, tt.expected.RatString(), days, fraction.RatString())
+		}
+	}
+}
+
+func TestResolveDueDates_BrokenPeriodValidation(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{}).(*cicilanUsecase)
+
+	_, err := usecase.resolveDueDates(domain.DueDateOptions{BrokenPeriodMargin: "last_installment"}, domain.FrequencyMonthly)
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "broken_period_margin" {
+		t.Errorf("Expected broken_period_margin validation error, got %v", err)
+	}
+
+	_, err = usecase.resolveDueDates(domain.DueDateOptions{DayCountConvention: "act/360"}, domain.FrequencyMonthly)
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "day_count_convention" {
+		t.Errorf("Expected day_count_convention validation error, got %v", err)
+	}
+}
+
+func assertScheduleReconciles(t *testing.T, resp *domain.InstallmentScheduleResponse) {
+	t.Helper()
+
+	var principal int64
+	for _, row := range resp.Schedule {
+		principal += row.Principal
+		if row.Installment != row.Principal+row.Margin {
+			t.Errorf("Installment %d: %d is not principal %d plus margin %d", row.InstallmentNumber, row.Installment, row.Principal, row.Margin)
+		}
+	}
+	if principal != resp.Principal {
+		t.Errorf("Expected principal to total %d, got %d", resp.Principal, principal)
+	}
+}
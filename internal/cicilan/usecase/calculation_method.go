@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"math/big"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+type flatMethod struct{}
+
+func NewFlatMethod() cicilan.CalculationMethod {
+	return flatMethod{}
+}
+
+func (flatMethod) Name() string {
+	return domain.MethodFlat
+}
+
+// BuildSchedule charges margin on the original principal for the whole tenor
+// and spreads principal plus margin over the installments by their weights.
+func (flatMethod) BuildSchedule(input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	count := input.Installments
+
+	exactMargin := new(big.Rat).Mul(ratFromInt(input.Principal), ratFromFloat(input.AnnualMarginRate))
+	exactMargin.Mul(exactMargin, big.NewRat(int64(count), int64(input.PeriodsPerYear)))
+
+	return spreadSchedule(input, roundRat(exactMargin, rupiah))
+}
+
+// spreadSchedule spreads principal plus totalMargin over the installments by
+// their weights. Each installment carries margin in proportion to its size;
+// the last one absorbs the rounding remainder and any balloon.
+func spreadSchedule(input domain.PricingInput, totalMargin int64) ([]domain.InstallmentScheduleRow, error) {
+	count := input.Installments
+	totalPayment := input.Principal + totalMargin
+	spread := totalPayment - input.Balloon
+
+	weights, weightTotal := weightRats(input)
+	installments := make([]int64, count)
+	var allocated int64
+	for i := 0; i < count-1; i++ {
+		share := new(big.Rat).Mul(ratFromInt(spread), weights[i])
+		installments[i] = roundRat(share.Quo(share, weightTotal), input.Rounding)
+		if installments[i] <= 0 {
+			return nil, &ValidationError{Message: "rounding_unit is too large for the financed amount"}
+		}
+		allocated += installments[i]
+	}
+	installments[count-1] = totalPayment - allocated
+	if installments[count-1]-input.Balloon <= 0 {
+		return nil, &ValidationError{Message: "rounding_unit is too large for the financed amount"}
+	}
+
+	marginShare := big.NewRat(totalMargin, spread)
+
+	rows := make([]domain.InstallmentScheduleRow, 0, count)
+	remaining := input.Principal
+	marginLeft := totalMargin
+
+	for i, installment := range installments {
+		margin := marginLeft
+		if i < count-1 {
+			margin = roundRat(new(big.Rat).Mul(ratFromInt(installment), marginShare), rupiah)
+		}
+
+		principalPortion := installment - margin
+		remaining -= principalPortion
+		marginLeft -= margin
+
+		rows = append(rows, domain.InstallmentScheduleRow{
+			InstallmentNumber: i + 1,
+			Principal:         principalPortion,
+			Margin:            margin,
+			Installment:       installment,
+			RemainingBalance:  remaining,
+		})
+	}
+	rows[count-1].Balloon = input.Balloon
+
+	return rows, nil
+}
+
+type effectiveMethod struct{}
+
+func NewEffectiveMethod() cicilan.CalculationMethod {
+	return effectiveMethod{}
+}
+
+func (effectiveMethod) Name() string {
+	return domain.MethodEffective
+}
+
+// BuildSchedule sizes the installments so that, discounted at the period
+// rate, they repay the principal: level installments form the classic
+// annuity. Margin is charged on the outstanding balance and the last
+// installment clears whatever remains, including any balloon.
+func (effectiveMethod) BuildSchedule(input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	count := input.Installments
+	periodRate := new(big.Rat).Quo(ratFromFloat(input.AnnualMarginRate), big.NewRat(int64(input.PeriodsPerYear), 1))
+
+	weights, _ := weightRats(input)
+	base := weightedAnnuityPayment(ratFromInt(input.Principal), ratFromInt(input.Balloon), periodRate, weights)
+
+	rows := make([]domain.InstallmentScheduleRow, 0, count)
+	remaining := input.Principal
+
+	for i := 1; i <= count; i++ {
+		installment := roundRat(new(big.Rat).Mul(base, weights[i-1]), input.Rounding)
+		if installment <= 0 {
+			return nil, &ValidationError{Message: "rounding_unit is too large for the financed amount"}
+		}
+
+		margin := roundRat(new(big.Rat).Mul(ratFromInt(remaining), periodRate), rupiah)
+		principalPortion := installment - margin
+
+		if i == count || principalPortion > remaining {
+			principalPortion = remaining
+		}
+
+		remaining -= principalPortion
+
+		rows = append(rows, domain.InstallmentScheduleRow{
+			InstallmentNumber: i,
+			Principal:         principalPortion,
+			Margin:            margin,
+			Installment:       principalPortion + margin,
+			RemainingBalance:  remaining,
+		})
+	}
+	rows[count-1].Balloon = input.Balloon
+
+	return rows, nil
+}
+
+// weightedAnnuityPayment returns the payment per unit of weight whose
+// discounted installments, together with the discounted balloon, equal the
+// principal.
+func weightedAnnuityPayment(principal, balloon, periodRate *big.Rat, weights []*big.Rat) *big.Rat {
+	discount := new(big.Rat).Inv(new(big.Rat).Add(big.NewRat(1, 1), periodRate))
+
+	factor := big.NewRat(1, 1)
+	presentValue := new(big.Rat)
+	for _, weight := range weights {
+		factor.Mul(factor, discount)
+		presentValue.Add(presentValue, new(big.Rat).Mul(weight, factor))
+	}
+
+	financed := new(big.Rat).Sub(principal, new(big.Rat).Mul(balloon, factor))
+	return financed.Quo(financed, presentValue)
+}
+
+func annuityPayment(principal, periodRate *big.Rat, count int) *big.Rat {
+	if periodRate.Sign() == 0 {
+		return new(big.Rat).Quo(principal, big.NewRat(int64(count), 1))
+	}
+
+	growth := big.NewRat(1, 1)
+	factor := new(big.Rat).Add(big.NewRat(1, 1), periodRate)
+	for i := 0; i < count; i++ {
+		growth.Mul(growth, factor)
+	}
+
+	payment := new(big.Rat).Mul(principal, periodRate)
+	payment.Mul(payment, growth)
+	return payment.Quo(payment, new(big.Rat).Sub(growth, big.NewRat(1, 1)))
+}
+
+func defaultMethods() map[string]cicilan.CalculationMethod {
+	methods := make(map[string]cicilan.CalculationMethod)
+	for _, method := range []cicilan.CalculationMethod{NewFlatMethod(), NewEffectiveMethod(), NewDiminishingMethod()} {
+		methods[method.Name()] = method
+	}
+	return methods
+}
+
+func summarize(tenor int, method string, rate float64, rows []domain.InstallmentScheduleRow) domain.InstallmentCalculation {
+	calculation := domain.InstallmentCalculation{
+		Tenor:            tenor,
+		Method:           method,
+		AnnualMarginRate: rate,
+	}
+
+	for _, row := range rows {
+		if !row.Grace {
+			calculation.MonthlyInstallment = row.Installment
+			break
+		}
+	}
+
+	if len(rows) > 0 {
+		calculation.LastInstallment = rows[len(rows)-1].Installment
+	}
+
+	for _, row := range rows {
+		calculation.TotalMargin += row.Margin
+		calculation.TotalPayment += row.Installment
+	}
+
+	return calculation
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"testing"
+
+	"btpntest/domain"
+)
+
+func TestFlatMethod_BuildSchedule(t *testing.T) {
+	rows, err := NewFlatMethod().BuildSchedule(domain.PricingInput{
+		Principal:        10000000,
+		Installments:     12,
+		PeriodsPerYear:   12,
+		AnnualMarginRate: 0.2,
+		Rounding:         rupiah,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(rows) != 12 {
+		t.Fatalf("Expected 12 rows, got %d", len(rows))
+	}
+
+	for _, row := range rows {
+		if row.Margin != 166667 && row.InstallmentNumber != 12 {
+			t.Errorf("Row %d: expected constant flat margin 166667, got %d", row.InstallmentNumber, row.Margin)
+		}
+	}
+
+	calc := summarize(12, domain.MethodFlat, 0.2, rows)
+	if calc.TotalMargin != 2000000 {
+		t.Errorf("Expected total_margin 2000000, got %d", calc.TotalMargin)
+	}
+	if calc.TotalPayment != 12000000 {
+		t.Errorf("Expected total_payment 12000000, got %d", calc.TotalPayment)
+	}
+}
+
+func TestEffectiveMethod_BuildSchedule(t *testing.T) {
+	rows, err := NewEffectiveMethod().BuildSchedule(domain.PricingInput{
+		Principal:        10000000,
+		Installments:     12,
+		PeriodsPerYear:   12,
+		AnnualMarginRate: 0.2,
+		Rounding:         rupiah,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(rows) != 12 {
+		t.Fatalf("Expected 12 rows, got %d", len(rows))
+	}
+
+	if rows[0].Installment != 926345 {
+		t.Errorf("Expected annuity installment 926345, got %d", rows[0].Installment)
+	}
+
+	for i := 1; i < len(rows); i++ {
+		if rows[i].Margin > rows[i-1].Margin {
+			t.Errorf("Row %d: margin %d should not exceed previous %d", rows[i].InstallmentNumber, rows[i].Margin, rows[i-1].Margin)
+		}
+	}
+
+	var principal int64
+	for _, row := range rows {
+		principal += row.Principal
+	}
+	if principal != 10000000 {
+		t.Errorf("Expected principal sum 10000000, got %d", principal)
+	}
+	if rows[11].RemainingBalance != 0 {
+		t.Errorf("Expected zero remaining balance, got %d", rows[11].RemainingBalance)
+	}
+
+	calc := summarize(12, domain.MethodEffective, 0.2, rows)
+	if calc.TotalMargin >= 2000000 {
+		t.Errorf("Expected effective margin below flat margin 2000000, got %d", calc.TotalMargin)
+	}
+}
+
+func TestEffectiveMethod_ZeroRate(t *testing.T) {
+	rows, err := NewEffectiveMethod().BuildSchedule(domain.PricingInput{
+		Principal:      1200000,
+		Installments:   12,
+		PeriodsPerYear: 12,
+		Rounding:       rupiah,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, row := range rows {
+		if row.Margin != 0 || row.Installment != 100000 {
+			t.Errorf("Row %d: expected margin 0 and installment 100000, got %d and %d", row.InstallmentNumber, row.Margin, row.Installment)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+// WithHolidayCalendar sets the national holidays and cuti bersama that
+// business-day conventions skip besides weekends.
+func WithHolidayCalendar(repo cicilan.HolidayRepository) Option {
+	return func(u *cicilanUsecase) {
+		u.holidays = repo
+	}
+}
+
+// dueDateRule places installments on the calendar: monthly payments fall on
+// paymentDay (or the start date's day when zero) and every due date is then
+// moved off weekends and holidays by the business-day convention.
+type dueDateRule struct {
+	paymentDay int
+	convention string
+	holidays   map[string]bool
+	// calendarEnd is the last day of the last year the holiday calendar
+	// lists; holidays after it are unknown.
+	calendarEnd time.Time
+
+	// brokenPeriod and dayCount price the days between disbursement and the
+	// start of the first regular period.
+	brokenPeriod string
+	dayCount     string
+}
+
+func (u *cicilanUsecase) resolveDueDates(options domain.DueDateOptions, frequency string) (dueDateRule, error) {
+	convention := options.BusinessDayConvention
+	switch convention {
+	case "":
+		convention = domain.BusinessDayNone
+	case domain.BusinessDayNone, domain.BusinessDayFollowing, domain.BusinessDayModifiedFollowing, domain.BusinessDayPreceding:
+	default:
+		return dueDateRule{}, &ValidationError{
+			Field:   "business_day_convention",
+			Message: fmt.Sprintf("unsupported business_day_convention: %s", convention),
+		}
+	}
+
+	if options.PaymentDay < 0 || options.PaymentDay > 31 {
+		return dueDateRule{}, &ValidationError{Field: "payment_day", Message: "payment_day must be between 1 and 31"}
+	}
+	if options.PaymentDay > 0 && frequency != domain.FrequencyMonthly {
+		return dueDateRule{}, &ValidationError{Field: "payment_day", Message: "payment_day applies to monthly payments only"}
+	}
+
+	brokenPeriod, dayCount, err := resolveBrokenPeriod(options.BrokenPeriodMargin, options.DayCountConvention)
+	if err != nil {
+		return dueDateRule{}, err
+	}
+
+	rule := dueDateRule{paymentDay: options.PaymentDay, convention: convention, brokenPeriod: brokenPeriod, dayCount: dayCount}
+	if convention == domain.BusinessDayNone || u.holidays == nil {
+		return rule, nil
+	}
+
+	holidays, err := u.holidays.GetHolidays()
+	if err != nil {
+		return dueDateRule{}, err
+	}
+	rule.holidays = make(map[string]bool, len(holidays))
+	for _, holiday := range holidays {
+		rule.holidays[holiday.Date.Format(dateLayout)] = true
+		if end := time.Date(holiday.Date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC); end.After(rule.calendarEnd) {
+			rule.calendarEnd = end
+		}
+	}
+	return rule, nil
+}
+
+// checkCalendar fails when the last of count due dates falls past the end of
+// the holiday calendar, where business days can no longer be told apart
+// from unlisted holidays.
+func (r dueDateRule) checkCalendar(startDate time.Time, frequency string, count int) error {
+	if r.holidays == nil {
+		return nil
+	}
+
+	last := r.dueDate(startDate, frequency, count)
+	if calendarDate(last).After(r.calendarEnd) {
+		return fmt.Errorf("holiday calendar ends on %s but the last due date is %s; add the holidays of the following years",
+			r.calendarEnd.Format(dateLayout), last.Format(dateLayout))
+	}
+	return nil
+}
+
+func (r dueDateRule) assign(rows []domain.InstallmentScheduleRow, startDate time.Time, frequency string) {
+	for i := range rows {
+		rows[i].DueDate = r.dueDate(startDate, frequency, rows[i].InstallmentNumber).Format(dateLayout)
+	}
+}
+
+func (r dueDateRule) dueDate(startDate time.Time, frequency string, number int) time.Time {
+	date := dueDate(startDate, frequency, number)
+	if frequency == domain.FrequencyMonthly && r.paymentDay > 0 {
+		date = paymentDate(startDate, number, r.paymentDay)
+	}
+	return r.adjust(date)
+}
+
+// paymentDate is the payment day of the number-th month after startDate,
+// or the last day of that month when it is shorter.
+func paymentDate(startDate time.Time, number, paymentDay int) time.Time {
+	firstOfMonth := time.Date(startDate.Year(), startDate.Month()+time.Month(number), 1, 0, 0, 0, 0, startDate.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if paymentDay > lastDay {
+		paymentDay = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, paymentDay-1)
+}
+
+func (r dueDateRule) adjust(date time.Time) time.Time {
+	switch r.convention {
+	case domain.BusinessDayFollowing:
+		return r.roll(date, 1)
+	case domain.BusinessDayPreceding:
+		return r.roll(date, -1)
+	case domain.BusinessDayModifiedFollowing:
+		following := r.roll(date, 1)
+		if following.Month() != date.Month() {
+			return r.roll(date, -1)
+		}
+		return following
+	default:
+		return date
+	}
+}
+
+// roll moves date by step days until it reaches a business day.
+func (r dueDateRule) roll(date time.Time, step int) time.Time {
+	for !r.isBusinessDay(date) {
+		date = date.AddDate(0, 0, step)
+	}
+	return date
+}
+
+func (r dueDateRule) isBusinessDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return !r.holidays[date.Format(dateLayout)]
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+type MockHolidayRepository struct {
+	dates []string
+	err   error
+}
+
+func (m *MockHolidayRepository) GetHolidays() ([]domain.Holiday, error) {
+	holidays := make([]domain.Holiday, 0, len(m.dates))
+	for _, date := range m.dates {
+		parsed, _ := time.Parse(dateLayout, date)
+		holidays = append(holidays, domain.Holiday{Date: parsed, Type: domain.HolidayNational})
+	}
+	return holidays, m.err
+}
+
+// Idul Fitri 2026: cuti bersama on 20, 23 and 24 March around the holidays
+// of 21 and 22 March, after Nyepi on 19 March and its cuti bersama on 18 March.
+var lebaran2026 = []string{"2026-03-18", "2026-03-19", "2026-03-20", "2026-03-21", "2026-03-22", "2026-03-23", "2026-03-24"}
+
+func TestDueDateRule_BusinessDayConventions(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{}, WithHolidayCalendar(&MockHolidayRepository{dates: lebaran2026})).(*cicilanUsecase)
+
+	tests := []struct {
+		convention string
+		date       string
+		expected   string
+	}{
+		{domain.BusinessDayNone, "2026-03-20", "2026-03-20"},
+		{domain.BusinessDayFollowing, "2026-03-20", "2026-03-25"},
+		{domain.BusinessDayPreceding, "2026-03-20", "2026-03-17"},
+		{domain.BusinessDayModifiedFollowing, "2026-03-20", "2026-03-25"},
+		{domain.BusinessDayFollowing, "2026-01-31", "2026-02-02"},
+		{domain.BusinessDayModifiedFollowing, "2026-01-31", "2026-01-30"},
+		{domain.BusinessDayPreceding, "2026-02-02", "2026-02-02"},
+	}
+
+	for _, tt := range tests {
+		rule, err := usecase.resolveDueDates(domain.DueDateOptions{BusinessDayConvention: tt.convention}, domain.FrequencyMonthly)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.convention, err)
+		}
+
+		date, _ := time.Parse(dateLayout, tt.date)
+		if adjusted := rule.adjust(date).Format(dateLayout); adjusted != tt.expected {
+			t.Errorf("%s %s: expected %s, got %s", tt.convention, tt.date, tt.expected, adjusted)
+		}
+	}
+}
+
+func TestCalculateSchedule_PaymentDay(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}}}
+	usecase := NewCicilanUsecase(mockRepo, WithHolidayCalendar(&MockHolidayRepository{dates: lebaran2026}))
+
+	resp, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:         10000000,
+		Tenor:          6,
+		StartDate:      "2026-01-10",
+		DueDateOptions: domain.DueDateOptions{PaymentDay: 31},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{"2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31", "2026-06-30", "2026-07-31"}
+	for i, row := range resp.Schedule {
+		if row.DueDate != expected[i] {
+			t.Errorf("Installment %d: expected due date %s, got %s", row.InstallmentNumber, expected[i], row.DueDate)
+		}
+	}
+	if resp.StartDate != "2026-01-10" || resp.PaymentDay != 31 || resp.BusinessDayConvention != domain.BusinessDayNone {
+		t.Errorf("Expected calendar settings in the response, got %s/%d/%s", resp.StartDate, resp.PaymentDay, resp.BusinessDayConvention)
+	}
+
+	resp, err = usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:         10000000,
+		Tenor:          6,
+		StartDate:      "2026-01-10",
+		DueDateOptions: domain.DueDateOptions{PaymentDay: 20, BusinessDayConvention: domain.BusinessDayModifiedFollowing},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 20 Mar 2026 is cuti bersama and 20 Jun is a Saturday.
+	expected = []string{"2026-02-20", "2026-03-25", "2026-04-20", "2026-05-20", "2026-06-22", "2026-07-20"}
+	for i, row := range resp.Schedule {
+		if row.DueDate != expected[i] {
+			t.Errorf("Installment %d: expected due date %s, got %s", row.InstallmentNumber, expected[i], row.DueDate)
+		}
+	}
+}
+
+func TestCalculateSchedule_DueDatesPastHolidayCalendar(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}, {ID: 2, TenorValue: 24}}}
+	usecase := NewCicilanUsecase(mockRepo, WithHolidayCalendar(&MockHolidayRepository{dates: lebaran2026}))
+
+	schedule := func(tenor int, convention string) error {
+		_, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+			Amount:         12000000,
+			Tenor:          tenor,
+			StartDate:      "2026-01-15",
+			DueDateOptions: domain.DueDateOptions{BusinessDayConvention: convention},
+		})
+		return err
+	}
+
+	if err := schedule(6, domain.BusinessDayFollowing); err != nil {
+		t.Errorf("Expected due dates within 2026 to be adjusted, got %v", err)
+	}
+	if err := schedule(24, domain.BusinessDayNone); err != nil {
+		t.Errorf("Expected unadjusted due dates to need no calendar, got %v", err)
+	}
+
+	// The 2026 calendar says nothing about the holidays of 2027.
+	err := schedule(24, domain.BusinessDayFollowing)
+	if err == nil {
+		t.Fatal("Expected an error for due dates past the holiday calendar, got nil")
+	}
+	if _, ok := err.(*ValidationError); ok {
+		t.Errorf("Expected a missing calendar year to be an internal error, got validation error %v", err)
+	}
+}
+
+func TestResolveDueDates_Validation(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{}).(*cicilanUsecase)
+
+	if _, err := usecase.resolveDueDates(domain.DueDateOptions{BusinessDayConvention: "nearest"}, domain.FrequencyMonthly); err == nil {
+		t.Error("Expected validation error for unknown convention")
+	}
+	if _, err := usecase.resolveDueDates(domain.DueDateOptions{PaymentDay: 32}, domain.FrequencyMonthly); err == nil {
+		t.Error("Expected validation error for payment day 32")
+	}
+	if _, err := usecase.resolveDueDates(domain.DueDateOptions{PaymentDay: 15}, domain.FrequencyWeekly); err == nil {
+		t.Error("Expected validation error for payment day on weekly payments")
+	}
+
+	// Without a calendar only weekends are skipped.
+	rule, err := usecase.resolveDueDates(domain.DueDateOptions{BusinessDayConvention: domain.BusinessDayFollowing}, domain.FrequencyMonthly)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	date, _ := time.Parse(dateLayout, "2026-03-20")
+	if adjusted := rule.adjust(date).Format(dateLayout); adjusted != "2026-03-20" {
+		t.Errorf("Expected a Friday to stay put without holidays, got %s", adjusted)
+	}
+}
@@ -1,64 +1,556 @@
 package usecase
 
 import (
+	"fmt"
+	"math/big"
+	"time"
+
 	"btpntest/domain"
 	cicilan "btpntest/internal/cicilan"
 )
 
+const (
+	dateLayout              = "2006-01-02"
+	defaultAnnualMarginRate = 0.2
+)
+
 type cicilanUsecase struct {
-	repo cicilan.CicilanRepository
+	repo        cicilan.CicilanRepository
+	methods     map[string]cicilan.CalculationMethod
+	calculators map[string]cicilan.ProductCalculator
+	maxDSR      float64
+	minAmount   int64
+	maxAmount   int64
+	tenorRange  tenorRange
+	holidays    cicilan.HolidayRepository
+	history     cicilan.PricingHistoryRepository
+	quotes      cicilan.QuoteRepository
+	quoteTTL    time.Duration
 }
 
-func NewCicilanUsecase(repo cicilan.CicilanRepository) cicilan.CicilanUsecase {
-	return &cicilanUsecase{repo: repo}
+func NewCicilanUsecase(repo cicilan.CicilanRepository, options ...Option) cicilan.CicilanUsecase {
+	u := &cicilanUsecase{
+		repo:        repo,
+		methods:     defaultMethods(),
+		calculators: defaultProductCalculators(),
+		maxDSR:      DefaultMaxDSR,
+		minAmount:   DefaultMinAmount,
+		maxAmount:   DefaultMaxAmount,
+	}
+	for _, option := range options {
+		option(u)
+	}
+	return u
 }
 
 func (u *cicilanUsecase) CalculateInstallments(req *domain.CalculateInstallmentRequest) (*domain.CalculateInstallmentResponse, error) {
 
-	if req.Amount <= 0 {
-		return nil, &ValidationError{Message: "amount must be greater than 0"}
+	financing, err := resolveFinancing(req.Amount, req.AssetPrice, req.FinancingComponents)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAffordability(req.AffordabilityInput); err != nil {
+		return nil, err
+	}
+
+	asOf, pricedAt, err := parseAsOf(req.AsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := u.resolveTerms(req.PricingOptions, pricedAt)
+	if err != nil {
+		return nil, err
 	}
+	financing = withProductFee(financing, terms.fee(financing.FinancedAmount))
+	terms.financing = financing
 
-	tenors, err := u.repo.GetAllTenors()
+	tenors, err := u.tenorsOn(terms, asOf)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(tenors) == 0 {
-		return &domain.CalculateInstallmentResponse{
-			Calculations: []domain.InstallmentCalculation{},
-		}, nil
+	var affordability *domain.AffordabilitySummary
+	if req.MonthlyIncome > 0 {
+		affordability = &domain.AffordabilitySummary{
+			MonthlyIncome:       req.MonthlyIncome,
+			ExistingObligations: req.ExistingObligations,
+			MaxDSR:              u.maxDSR,
+		}
 	}
 
-	principal := req.Amount
-	annualMarginRate := 0.2
+	principal := financing.FinancedAmount
+	if err := u.checkPlafond(terms.product, principal); err != nil {
+		return nil, err
+	}
+
+	tenors, excluded, err := u.selectTenors(tenors, req.Tenors)
+	if err != nil {
+		return nil, err
+	}
 
 	calculations := make([]domain.InstallmentCalculation, 0, len(tenors))
+	accepted := []int{}
 
 	for _, tenor := range tenors {
-		totalMargin := int64(float64(principal) * annualMarginRate * (float64(tenor.TenorValue) / 12))
+		if err := terms.eligible(tenor, principal); err != nil {
+			if excluded, err = excludeTenor(excluded, tenor, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
 
-		totalPayment := principal + totalMargin
+		rows, calculation, err := priceTenor(terms, tenor, principal)
+		if err != nil {
+			return nil, err
+		}
+		describePartnership(&calculation, rows, financing)
+		if affordability != nil {
+			calculation.Affordability = assessAffordability(req.AffordabilityInput, u.maxDSR, rows, terms.frequency)
+		}
+		calculations = append(calculations, calculation)
+		accepted = append(accepted, tenor.TenorValue)
+	}
+
+	if affordability != nil {
+		affordability.RecommendedTenor = recommendTenor(calculations)
+	}
+
+	response := &domain.CalculateInstallmentResponse{
+		AsOf:             asOf.Format(dateLayout),
+		PricingVersionID: terms.versionID(),
+		Financing:        financing,
+		Affordability:    affordability,
+		Calculations:     calculations,
+		AcceptedTenors:   accepted,
+		ExcludedTenors:   excluded,
+	}
+	if req.SaveQuote {
+		if err := u.saveQuote(req, response); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+func (u *cicilanUsecase) CalculateSchedule(req *domain.CalculateScheduleRequest) (*domain.InstallmentScheduleResponse, error) {
+	financing, err := resolveFinancing(req.Amount, req.AssetPrice, req.FinancingComponents)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Tenor <= 0 {
+		return nil, &ValidationError{Message: "tenor must be greater than 0"}
+	}
+
+	startDate, err := parseDate(req.StartDate, "start_date", time.Now())
+	if err != nil {
+		return nil, err
+	}
 
-		monthlyInstallment := totalPayment / int64(tenor.TenorValue)
+	terms, err := u.resolveTerms(req.PricingOptions, pricingInstant(startDate))
+	if err != nil {
+		return nil, err
+	}
+	financing = withProductFee(financing, terms.fee(financing.FinancedAmount))
+	terms.financing = financing
 
-		calculations = append(calculations, domain.InstallmentCalculation{
-			Tenor:              tenor.TenorValue,
-			MonthlyInstallment: monthlyInstallment,
-			TotalMargin:        totalMargin,
-			TotalPayment:       totalPayment,
-		})
+	terms.dueDates, err = u.resolveDueDates(req.DueDateOptions, terms.frequency)
+	if err != nil {
+		return nil, err
 	}
 
-	return &domain.CalculateInstallmentResponse{
-		Calculations: calculations,
+	if err := u.checkPlafond(terms.product, financing.FinancedAmount); err != nil {
+		return nil, err
+	}
+
+	offeredOn, err := parseDate(req.OfferedOn, "offered_on", startDate)
+	if err != nil {
+		return nil, err
+	}
+	tenor, err := u.lookupTenor(terms, req.Tenor, offeredOn)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTenorBand(tenor, financing.FinancedAmount); err != nil {
+		return nil, err
+	}
+
+	rows, calculation, err := scheduleTenor(terms, tenor, financing.FinancedAmount, startDate)
+	if err != nil {
+		return nil, err
+	}
+	describePartnership(&calculation, rows, financing)
+
+	return &domain.InstallmentScheduleResponse{
+		Tenor:                 calculation.Tenor,
+		PricingVersionID:      terms.versionID(),
+		StartDate:             startDate.Format(dateLayout),
+		PaymentDay:            req.PaymentDay,
+		BusinessDayConvention: terms.dueDates.convention,
+		Product:               calculation.Product,
+		ContractType:          calculation.ContractType,
+		ProfitType:            calculation.ProfitType,
+		Method:                calculation.Method,
+		Profile:               calculation.Profile,
+		PaymentFrequency:      calculation.PaymentFrequency,
+		InstallmentCount:      calculation.InstallmentCount,
+		GracePeriods:          calculation.GracePeriods,
+		GraceMode:             calculation.GraceMode,
+		AnnualMarginRate:      calculation.AnnualMarginRate,
+		EffectiveAnnualRate:   calculation.EffectiveAnnualRate,
+		Financing:             financing,
+		Principal:             financing.FinancedAmount,
+		MonthlyInstallment:    calculation.MonthlyInstallment,
+		LastInstallment:       calculation.LastInstallment,
+		TotalMargin:           calculation.TotalMargin,
+		TotalPayment:          calculation.TotalPayment,
+		Fee:                   calculation.Fee,
+		Partnership:           calculation.Partnership,
+		BrokenPeriod:          calculation.BrokenPeriod,
+		Schedule:              rows,
 	}, nil
 }
 
+// tenorSchedule prices a single master tenor and dates its installments from startDate.
+func (u *cicilanUsecase) tenorSchedule(terms pricingTerms, tenorValue int, principal int64, startDate time.Time) ([]domain.InstallmentScheduleRow, domain.InstallmentCalculation, error) {
+	tenor, err := u.lookupTenor(terms, tenorValue, startDate)
+	if err != nil {
+		return nil, domain.InstallmentCalculation{}, err
+	}
+	return scheduleTenor(terms, tenor, principal, startDate)
+}
+
+// lookupTenor resolves a tenor among those available on the given date,
+// normally the disbursement date.
+func (u *cicilanUsecase) lookupTenor(terms pricingTerms, tenorValue int, date time.Time) (domain.Tenor, error) {
+	tenors, err := u.tenorsOn(terms, date)
+	if err != nil {
+		return domain.Tenor{}, err
+	}
+
+	return u.resolveTenor(tenors, tenorValue)
+}
+
+func scheduleTenor(terms pricingTerms, tenor domain.Tenor, principal int64, startDate time.Time) ([]domain.InstallmentScheduleRow, domain.InstallmentCalculation, error) {
+	terms.startDate = startDate
+
+	rows, calculation, err := priceTenor(terms, tenor, principal)
+	if err != nil {
+		return nil, domain.InstallmentCalculation{}, err
+	}
+	if err := terms.dueDates.checkCalendar(startDate, terms.frequency, len(rows)); err != nil {
+		return nil, domain.InstallmentCalculation{}, err
+	}
+	terms.dueDates.assign(rows, startDate, terms.frequency)
+
+	return rows, calculation, nil
+}
+
+func (u *cicilanUsecase) resolveProduct(code string, version *domain.PricingVersion) (*domain.Product, cicilan.ProductCalculator, error) {
+	if code == "" {
+		return nil, u.calculators[domain.ContractMurabahah], nil
+	}
+
+	product, err := u.findProduct(code, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if product == nil {
+		return nil, nil, &ValidationError{Message: fmt.Sprintf("product %s is not available", code)}
+	}
+
+	calculator, ok := u.calculators[product.ContractType]
+	if !ok {
+		return nil, nil, fmt.Errorf("product %s has unsupported contract type %q", product.Code, product.ContractType)
+	}
+	// A qardh may only recover its actual costs, so its fee must be a fixed
+	// amount rather than a share of the loan. Like an unknown contract type,
+	// a fee rate is a catalog misconfiguration, not a client error.
+	if product.ContractType == domain.ContractQardh && product.FeeRate != nil && *product.FeeRate != 0 {
+		return nil, nil, fmt.Errorf("qardh product %s has a fee_rate but may charge only a fixed admin fee", product.Code)
+	}
+	return product, calculator, nil
+}
+
+func (u *cicilanUsecase) resolveMethod(calculator cicilan.ProductCalculator, name string) (cicilan.CalculationMethod, error) {
+	supported := calculator.Methods()
+	if name == "" {
+		name = supported[0]
+	}
+
+	method, ok := u.methods[name]
+	if !ok {
+		return nil, &ValidationError{Message: fmt.Sprintf("unsupported calculation method: %s", name)}
+	}
+
+	for _, candidate := range supported {
+		if candidate == name {
+			return method, nil
+		}
+	}
+	return nil, &ValidationError{Message: fmt.Sprintf("%s contracts cannot be priced with the %s method", calculator.ContractType(), name)}
+}
+
+// resolveTerms prices with the requested pricing version, otherwise with
+// the one in effect at the instant pricedAt.
+func (u *cicilanUsecase) resolveTerms(options domain.PricingOptions, pricedAt time.Time) (pricingTerms, error) {
+	version, err := u.pricingVersion(options.PricingVersionID, pricedAt)
+	if err != nil {
+		return pricingTerms{}, err
+	}
+
+	product, calculator, err := u.resolveProduct(options.ProductCode, version)
+	if err != nil {
+		return pricingTerms{}, err
+	}
+
+	method, err := u.resolveMethod(calculator, options.Method)
+	if err != nil {
+		return pricingTerms{}, err
+	}
+
+	if err := validateRateReviews(calculator, options.RateReviews); err != nil {
+		return pricingTerms{}, err
+	}
+
+	profile, err := resolveProfile(options.Profile)
+	if err != nil {
+		return pricingTerms{}, err
+	}
+
+	rounding, err := newRoundingPolicy(options.RoundingMode, options.RoundingUnit)
+	if err != nil {
+		return pricingTerms{}, err
+	}
+
+	frequency, err := resolveFrequency(options.PaymentFrequency)
+	if err != nil {
+		return pricingTerms{}, err
+	}
+
+	graceMode, err := resolveGraceMode(options.GracePeriods, options.GraceMode)
+	if err != nil {
+		return pricingTerms{}, err
+	}
+
+	return pricingTerms{
+		version:      version,
+		product:      product,
+		calculator:   calculator,
+		method:       method,
+		rounding:     rounding,
+		frequency:    frequency,
+		gracePeriods: options.GracePeriods,
+		graceMode:    graceMode,
+		rateReviews:  options.RateReviews,
+		profileType:  profile,
+		profile:      options.Profile,
+		startDate:    today(),
+	}, nil
+}
+
+type pricingTerms struct {
+	version      *domain.PricingVersion
+	product      *domain.Product
+	calculator   cicilan.ProductCalculator
+	method       cicilan.CalculationMethod
+	rounding     domain.RoundingPolicy
+	frequency    string
+	gracePeriods int
+	graceMode    string
+	rateReviews  []domain.RateReview
+	profileType  string
+	profile      *domain.InstallmentProfile
+	// startDate places seasonal installments in their calendar months.
+	startDate time.Time
+	dueDates  dueDateRule
+	// financing holds the charges of the request, which are not disbursed.
+	financing domain.FinancingBreakdown
+}
+
+// versionID is the ID of the pricing version used, or zero for the live
+// tenor master.
+func (t pricingTerms) versionID() int64 {
+	if t.version == nil {
+		return 0
+	}
+	return t.version.ID
+}
+
+// fee is the product's upfront fee on the given principal.
+func (t pricingTerms) fee(principal int64) int64 {
+	if t.product == nil {
+		return 0
+	}
+
+	fee := t.product.FeeAmount
+	if t.product.FeeRate != nil {
+		fee += roundRat(new(big.Rat).Mul(ratFromInt(principal), ratFromFloat(*t.product.FeeRate)), rupiah)
+	}
+	return fee
+}
+
+// eligible reports why a tenor cannot be offered for the principal, if at all.
+func (t pricingTerms) eligible(tenor domain.Tenor, principal int64) error {
+	if _, err := t.installmentsFor(tenor); err != nil {
+		return err
+	}
+	return checkTenorBand(tenor, principal)
+}
+
+func (t pricingTerms) installmentsFor(tenor domain.Tenor) (int, error) {
+	if !allowsFrequency(tenor, t.frequency) {
+		return 0, &ValidationError{
+			Field:   "payment_frequency",
+			Code:    domain.CodeFrequencyNotAllowed,
+			Message: fmt.Sprintf("tenor %d does not allow %s payments", tenor.TenorValue, t.frequency),
+		}
+	}
+
+	count, err := installmentCount(tenor.TenorValue, t.frequency)
+	if err != nil {
+		return 0, err
+	}
+
+	if t.gracePeriods >= count {
+		return 0, &ValidationError{
+			Field:   "grace_periods",
+			Code:    domain.CodeGraceTooLong,
+			Message: fmt.Sprintf("grace_periods must be shorter than the %d installments of tenor %d", count, tenor.TenorValue),
+		}
+	}
+	return count, nil
+}
+
+func priceTenor(terms pricingTerms, tenor domain.Tenor, principal int64) ([]domain.InstallmentScheduleRow, domain.InstallmentCalculation, error) {
+	count, err := terms.installmentsFor(tenor)
+	if err != nil {
+		return nil, domain.InstallmentCalculation{}, err
+	}
+
+	rate := terms.calculator.AnnualRate(tenor, terms.method.Name())
+	rows, err := terms.calculator.BuildSchedule(terms.method, domain.PricingInput{
+		Principal:        principal,
+		Installments:     count,
+		PeriodsPerYear:   periodsPerYear[terms.frequency],
+		AnnualMarginRate: rate,
+		Rounding:         terms.rounding,
+		GracePeriods:     terms.gracePeriods,
+		GraceMode:        terms.graceMode,
+		RateReviews:      terms.rateReviews,
+		Weights:          profileWeights(terms.profile, count, terms.frequency, terms.startDate, terms.dueDates),
+		Balloon:          balloonAmount(terms.profile, principal),
+	})
+	if err != nil {
+		return nil, domain.InstallmentCalculation{}, err
+	}
+
+	brokenPeriod := terms.applyBrokenPeriod(rows, principal, rate)
+	calculation := summarize(tenor.TenorValue, terms.method.Name(), rate, rows)
+	calculation.BrokenPeriod = brokenPeriod
+	if brokenPeriod != nil && brokenPeriod.Treatment == domain.BrokenPeriodFirstInstallment {
+		// The quoted installment is the regular one, without the broken-period margin.
+		calculation.MonthlyInstallment = regularInstallment(rows)
+	}
+	if terms.product != nil {
+		calculation.Product = terms.product.Code
+	}
+	calculation.ContractType = terms.calculator.ContractType()
+	calculation.ProfitType = terms.calculator.ProfitType()
+	calculation.Fee = terms.fee(principal)
+	calculation.PaymentFrequency = terms.frequency
+	calculation.InstallmentCount = count
+	calculation.GracePeriods = terms.gracePeriods
+	if terms.profileType != domain.ProfileLevel {
+		calculation.Profile = terms.profileType
+	}
+	if terms.gracePeriods > 0 {
+		calculation.GraceMode = terms.graceMode
+	}
+	calculation.EffectiveAnnualRate, err = effectiveAnnualRate(principal, calculation.Fee, terms.financing, rows, periodsPerYear[terms.frequency])
+	if err != nil {
+		return nil, domain.InstallmentCalculation{}, err
+	}
+
+	return rows, calculation, nil
+}
+
+func parseDate(value, field string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Message: fmt.Sprintf("%s must use the YYYY-MM-DD format", field)}
+	}
+	return parsed, nil
+}
+
+func addMonths(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, date.Location())
+}
+
+func findTenor(tenors []domain.Tenor, tenorValue int) (domain.Tenor, bool) {
+	for _, tenor := range tenors {
+		if tenor.TenorValue == tenorValue {
+			return tenor, true
+		}
+	}
+	return domain.Tenor{}, false
+}
+
+func marginRate(tenor domain.Tenor, method string) float64 {
+	rate := tenor.FlatMarginRate
+	if method == domain.MethodEffective {
+		rate = tenor.EffectiveMarginRate
+	}
+
+	if rate == nil {
+		return defaultAnnualMarginRate
+	}
+	return *rate
+}
+
+// ValidationError rejects a request. Field and Code, when set, let clients
+// tell which input failed and why without parsing the message.
 type ValidationError struct {
 	Message string
+	Field   string
+	Code    string
 }
 
 func (e *ValidationError) Error() string {
 	return e.Message
 }
+
+// NotFoundError reports that the record a request refers to does not exist.
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+// ConflictError reports a request that is valid on its own but clashes
+// with the current state of a record, such as a change made concurrently.
+type ConflictError struct {
+	Message string
+	Field   string
+	Code    string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
@@ -2,19 +2,35 @@ package usecase
 
 import (
 	"testing"
+	"time"
 
 	"btpntest/domain"
 )
 
 type MockCicilanRepository struct {
-	tenors []domain.Tenor
-	err    error
+	tenors   []domain.Tenor
+	products []domain.Product
+	err      error
+	asOf     time.Time
 }
 
-func (m *MockCicilanRepository) GetAllTenors() ([]domain.Tenor, error) {
+func (m *MockCicilanRepository) GetAllTenors(asOf time.Time) ([]domain.Tenor, error) {
+	m.asOf = asOf
 	return m.tenors, m.err
 }
 
+func (m *MockCicilanRepository) GetProductByCode(code string) (*domain.Product, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, product := range m.products {
+		if product.Code == code {
+			return &product, nil
+		}
+	}
+	return nil, nil
+}
+
 func TestCalculateInstallments_Success(t *testing.T) {
 	mockTenors := []domain.Tenor{
 		{ID: 1, TenorValue: 6},
@@ -53,6 +69,12 @@ func TestCalculateInstallments_Success(t *testing.T) {
 	if calc6.MonthlyInstallment != 1833333 {
 		t.Errorf("Expected monthly_installment 1833333, got %d", calc6.MonthlyInstallment)
 	}
+	if calc6.LastInstallment != 1833335 {
+		t.Errorf("Expected last_installment 1833335, got %d", calc6.LastInstallment)
+	}
+	if calc6.MonthlyInstallment*5+calc6.LastInstallment != calc6.TotalPayment {
+		t.Errorf("Expected installments to add up to total_payment %d", calc6.TotalPayment)
+	}
 
 	calc12 := resp.Calculations[1]
 	if calc12.TotalMargin != 2000000 {
@@ -136,3 +158,306 @@ func TestCalculateInstallments_DifferentAmount(t *testing.T) {
 		t.Errorf("Expected monthly_installment 500000, got %d", calc.MonthlyInstallment)
 	}
 }
+
+func TestCalculateSchedule_Reconciles(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	req := &domain.CalculateScheduleRequest{Amount: 10000000, Tenor: 6, StartDate: "2026-01-31"}
+	resp, err := usecase.CalculateSchedule(req)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Schedule) != 6 {
+		t.Fatalf("Expected 6 schedule rows, got %d", len(resp.Schedule))
+	}
+
+	var principal, margin, payment int64
+	for _, row := range resp.Schedule {
+		principal += row.Principal
+		margin += row.Margin
+		payment += row.Installment
+		if row.Principal+row.Margin != row.Installment {
+			t.Errorf("Row %d: principal %d + margin %d != installment %d", row.InstallmentNumber, row.Principal, row.Margin, row.Installment)
+		}
+	}
+
+	if principal != 10000000 {
+		t.Errorf("Expected principal sum 10000000, got %d", principal)
+	}
+	if margin != resp.TotalMargin {
+		t.Errorf("Expected margin sum %d, got %d", resp.TotalMargin, margin)
+	}
+	if payment != resp.TotalPayment {
+		t.Errorf("Expected payment sum %d, got %d", resp.TotalPayment, payment)
+	}
+
+	last := resp.Schedule[5]
+	if last.RemainingBalance != 0 {
+		t.Errorf("Expected zero remaining balance, got %d", last.RemainingBalance)
+	}
+
+	if resp.Schedule[0].DueDate != "2026-02-28" {
+		t.Errorf("Expected first due date 2026-02-28, got %s", resp.Schedule[0].DueDate)
+	}
+	if last.DueDate != "2026-07-31" {
+		t.Errorf("Expected last due date 2026-07-31, got %s", last.DueDate)
+	}
+}
+
+func TestCalculateSchedule_UnknownTenor(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	req := &domain.CalculateScheduleRequest{Amount: 10000000, Tenor: 9}
+	resp, err := usecase.CalculateSchedule(req)
+
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+	if resp != nil {
+		t.Errorf("Expected nil response, got %v", resp)
+	}
+}
+
+func TestCalculateSchedule_InvalidStartDate(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	req := &domain.CalculateScheduleRequest{Amount: 10000000, Tenor: 6, StartDate: "31/01/2026"}
+	if _, err := usecase.CalculateSchedule(req); err == nil {
+		t.Fatal("Expected validation error for malformed start_date")
+	}
+}
+
+func TestCalculateInstallments_MethodSelection(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Calculations[0].Method != domain.MethodFlat {
+		t.Errorf("Expected default method flat, got %s", resp.Calculations[0].Method)
+	}
+
+	resp, err = usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000, PricingOptions: domain.PricingOptions{Method: domain.MethodEffective}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Calculations[0].Method != domain.MethodEffective {
+		t.Errorf("Expected method effective, got %s", resp.Calculations[0].Method)
+	}
+
+	_, err = usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000, PricingOptions: domain.PricingOptions{Method: "balloon"}})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected validation error for unknown method, got %v", err)
+	}
+}
+
+func TestCalculateInstallments_PerTenorRates(t *testing.T) {
+	flat6, flat36 := 0.18, 0.24
+	effective6 := 0.21
+	mockTenors := []domain.Tenor{
+		{ID: 1, TenorValue: 6, FlatMarginRate: &flat6, EffectiveMarginRate: &effective6},
+		{ID: 2, TenorValue: 36, FlatMarginRate: &flat36},
+	}
+
+	mockRepo := &MockCicilanRepository{tenors: mockTenors}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Calculations[0].TotalMargin != 900000 {
+		t.Errorf("Expected 6-month total_margin 900000, got %d", resp.Calculations[0].TotalMargin)
+	}
+	if resp.Calculations[1].TotalMargin != 7200000 {
+		t.Errorf("Expected 36-month total_margin 7200000, got %d", resp.Calculations[1].TotalMargin)
+	}
+	if resp.Calculations[1].AnnualMarginRate != 0.24 {
+		t.Errorf("Expected 36-month annual_margin_rate 0.24, got %v", resp.Calculations[1].AnnualMarginRate)
+	}
+
+	resp, err = usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000, PricingOptions: domain.PricingOptions{Method: domain.MethodEffective}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Calculations[0].AnnualMarginRate != 0.21 {
+		t.Errorf("Expected effective rate 0.21, got %v", resp.Calculations[0].AnnualMarginRate)
+	}
+	if resp.Calculations[1].AnnualMarginRate != 0.2 {
+		t.Errorf("Expected fallback rate 0.2 when effective rate is unset, got %v", resp.Calculations[1].AnnualMarginRate)
+	}
+}
+
+func TestCalculateInstallments_RoundingReconciles(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}, {ID: 2, TenorValue: 36}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	req := &domain.CalculateInstallmentRequest{
+		Amount: 10000000,
+		PricingOptions: domain.PricingOptions{
+			RoundingMode: domain.RoundingCeiling,
+			RoundingUnit: 1000,
+		},
+	}
+	resp, err := usecase.CalculateInstallments(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, calc := range resp.Calculations {
+		if calc.MonthlyInstallment%1000 != 0 {
+			t.Errorf("Tenor %d: expected installment rounded to 1000, got %d", calc.Tenor, calc.MonthlyInstallment)
+		}
+
+		regularTotal := calc.MonthlyInstallment * int64(calc.Tenor-1)
+		if regularTotal+calc.LastInstallment != calc.TotalPayment {
+			t.Errorf("Tenor %d: %d x %d + %d != %d", calc.Tenor, calc.Tenor-1, calc.MonthlyInstallment, calc.LastInstallment, calc.TotalPayment)
+		}
+	}
+
+	calc6 := resp.Calculations[0]
+	if calc6.MonthlyInstallment != 1834000 {
+		t.Errorf("Expected monthly_installment 1834000, got %d", calc6.MonthlyInstallment)
+	}
+	if calc6.LastInstallment != 1830000 {
+		t.Errorf("Expected last_installment 1830000, got %d", calc6.LastInstallment)
+	}
+}
+
+func TestCalculateInstallments_InvalidRounding(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	_, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000, PricingOptions: domain.PricingOptions{RoundingMode: "up"}})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected validation error for unknown rounding mode, got %v", err)
+	}
+}
+
+func TestCalculateInstallments_FinancedAmountBase(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	req := &domain.CalculateInstallmentRequest{
+		AssetPrice: 12500000,
+		FinancingComponents: domain.FinancingComponents{
+			DownPayment: 2500000,
+			AdminFee:    &domain.FinancingCharge{Amount: 250000},
+		},
+	}
+	resp, err := usecase.CalculateInstallments(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Financing.FinancedAmount != 10000000 {
+		t.Errorf("Expected financed_amount 10000000, got %d", resp.Financing.FinancedAmount)
+	}
+	if resp.Financing.UpfrontPayment != 2750000 {
+		t.Errorf("Expected upfront_payment 2750000, got %d", resp.Financing.UpfrontPayment)
+	}
+	if resp.Calculations[0].TotalPayment != 12000000 {
+		t.Errorf("Expected total_payment 12000000 on the financed amount, got %d", resp.Calculations[0].TotalPayment)
+	}
+}
+
+func TestCalculateSchedule_FinancedAmountBase(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	req := &domain.CalculateScheduleRequest{
+		AssetPrice: 12500000,
+		Tenor:      12,
+		StartDate:  "2026-01-15",
+		FinancingComponents: domain.FinancingComponents{
+			DownPayment: 2500000,
+		},
+	}
+	resp, err := usecase.CalculateSchedule(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Principal != 10000000 {
+		t.Errorf("Expected principal 10000000, got %d", resp.Principal)
+	}
+	if resp.Financing.DownPayment != 2500000 {
+		t.Errorf("Expected financing down_payment 2500000, got %d", resp.Financing.DownPayment)
+	}
+}
+
+func TestCalculateInstallments_WeeklyFrequency(t *testing.T) {
+	mockTenors := []domain.Tenor{
+		{ID: 1, TenorValue: 6, PaymentFrequencies: "monthly,biweekly,weekly"},
+		{ID: 2, TenorValue: 36, PaymentFrequencies: "monthly"},
+	}
+	mockRepo := &MockCicilanRepository{tenors: mockTenors}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	req := &domain.CalculateInstallmentRequest{Amount: 10000000, PricingOptions: domain.PricingOptions{PaymentFrequency: domain.FrequencyWeekly}}
+	resp, err := usecase.CalculateInstallments(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Calculations) != 1 {
+		t.Fatalf("Expected only the weekly-eligible tenor, got %d calculations", len(resp.Calculations))
+	}
+
+	calc := resp.Calculations[0]
+	if calc.InstallmentCount != 26 {
+		t.Errorf("Expected 26 weekly installments, got %d", calc.InstallmentCount)
+	}
+	if calc.TotalMargin != 1000000 {
+		t.Errorf("Expected pro-rated total_margin 1000000, got %d", calc.TotalMargin)
+	}
+	if calc.MonthlyInstallment != 423077 {
+		t.Errorf("Expected weekly installment 423077, got %d", calc.MonthlyInstallment)
+	}
+	if calc.MonthlyInstallment*25+calc.LastInstallment != calc.TotalPayment {
+		t.Errorf("Expected weekly installments to add up to total_payment %d", calc.TotalPayment)
+	}
+}
+
+func TestCalculateSchedule_FrequencyNotAllowed(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 36, PaymentFrequencies: "monthly"}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	req := &domain.CalculateScheduleRequest{Amount: 10000000, Tenor: 36, PricingOptions: domain.PricingOptions{PaymentFrequency: domain.FrequencyBiWeekly}}
+	if _, err := usecase.CalculateSchedule(req); err == nil {
+		t.Fatal("Expected validation error for disallowed frequency")
+	}
+
+	req.PricingOptions.PaymentFrequency = "daily"
+	if _, err := usecase.CalculateSchedule(req); err == nil {
+		t.Fatal("Expected validation error for unknown frequency")
+	}
+}
+
+func TestCalculateInstallments_GracePeriodTooLong(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}, {ID: 2, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	options := domain.PricingOptions{GracePeriods: 6}
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000, PricingOptions: options})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Calculations) != 1 || resp.Calculations[0].Tenor != 12 {
+		t.Errorf("Expected only tenor 12 to support 6 grace periods, got %+v", resp.Calculations)
+	}
+
+	_, err = usecase.CalculateSchedule(&domain.CalculateScheduleRequest{Amount: 10000000, Tenor: 6, PricingOptions: options})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected validation error for grace period as long as the tenor, got %v", err)
+	}
+}
@@ -0,0 +1,482 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+// maxPostingAttempts bounds how often a posting is recomputed after losing
+// a race with another posting on the same contract.
+const maxPostingAttempts = 3
+
+type contractUsecase struct {
+	repo         cicilan.ContractRepository
+	applications cicilan.ApplicationRepository
+	quotes       cicilan.QuoteRepository
+	schedules    cicilan.CicilanUsecase
+	order        string
+	now          func() time.Time
+}
+
+// NewContractUsecase opens ledgers with schedules from schedules. Contracts
+// opened without an allocation order use allocationOrder.
+func NewContractUsecase(repo cicilan.ContractRepository, applications cicilan.ApplicationRepository, quotes cicilan.QuoteRepository, schedules cicilan.CicilanUsecase, allocationOrder string) cicilan.ContractUsecase {
+	return &contractUsecase{
+		repo:         repo,
+		applications: applications,
+		quotes:       quotes,
+		schedules:    schedules,
+		order:        allocationOrder,
+		now:          time.Now,
+	}
+}
+
+// OpenContract starts the ledger of a disbursed application. The schedule
+// is calculated from the quoted request for the application's tenor, with
+// the quote's pricing version and the disbursement date as start date, and
+// must keep the terms the application agreed.
+func (u *contractUsecase) OpenContract(req *domain.OpenContractRequest) (*domain.ContractResponse, error) {
+	order := req.AllocationOrder
+	if order == "" {
+		order = u.order
+	}
+	order, err := resolveAllocationOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	disbursementDate, err := parseDate(req.DisbursementDate, "disbursement_date", u.today())
+	if err != nil {
+		return nil, err
+	}
+
+	application, err := u.applications.GetApplication(req.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, &NotFoundError{Message: fmt.Sprintf("application %d not found", req.ApplicationID)}
+	}
+	if application.Status != domain.ApplicationDisbursed {
+		return nil, &ValidationError{
+			Field:   "application_id",
+			Code:    domain.CodeApplicationNotDisbursed,
+			Message: fmt.Sprintf("application %d is %s, not disbursed", application.ID, application.Status),
+		}
+	}
+
+	existing, err := u.repo.GetContractByApplication(application.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, &ConflictError{
+			Field:   "application_id",
+			Code:    domain.CodeContractExists,
+			Message: fmt.Sprintf("application %d already has contract %d", application.ID, existing.ID),
+		}
+	}
+
+	quote, err := u.quotes.GetQuote(application.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		return nil, &ValidationError{Field: "application_id", Message: fmt.Sprintf("quote %s of application %d is no longer available", application.QuoteID, application.ID)}
+	}
+	var quoted domain.CalculateInstallmentRequest
+	if err := json.Unmarshal([]byte(quote.RequestBody), &quoted); err != nil {
+		return nil, fmt.Errorf("decode quote %s request: %w", quote.ID, err)
+	}
+	var result domain.CalculateInstallmentResponse
+	if err := json.Unmarshal([]byte(quote.ResultBody), &result); err != nil {
+		return nil, fmt.Errorf("decode quote %s result: %w", quote.ID, err)
+	}
+
+	pricing := quoted.PricingOptions
+	if quote.PricingVersionID != 0 {
+		pricing.PricingVersionID = quote.PricingVersionID
+	}
+	schedule, err := u.schedules.CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:              quoted.Amount,
+		AssetPrice:          quoted.AssetPrice,
+		Tenor:               application.Tenor,
+		StartDate:           disbursementDate.Format(dateLayout),
+		OfferedOn:           result.AsOf,
+		PricingOptions:      pricing,
+		FinancingComponents: quoted.FinancingComponents,
+		DueDateOptions:      req.DueDateOptions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if changed := changedTerms(schedule, application, result, quoted.Profile); changed != "" {
+		return nil, &ValidationError{
+			Field:   "application_id",
+			Code:    domain.CodeTermsChanged,
+			Message: fmt.Sprintf("the schedule from %s %s", disbursementDate.Format(dateLayout), changed),
+		}
+	}
+
+	var capitalized int64
+	installments := make([]domain.ContractInstallment, 0, len(schedule.Schedule))
+	for _, row := range schedule.Schedule {
+		dueDate, err := time.Parse(dateLayout, row.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		// A capitalized grace period adds its margin to the principal the
+		// later installments repay and leaves nothing due itself.
+		principal := max(row.Principal, 0)
+		capitalized += row.CapitalizedMargin
+		installments = append(installments, domain.ContractInstallment{
+			InstallmentNumber: row.InstallmentNumber,
+			DueDate:           dueDate,
+			PrincipalDue:      principal,
+			MarginDue:         row.Installment - principal,
+		})
+	}
+
+	now := u.now().UnixMilli()
+	contract := domain.Contract{
+		ApplicationID:     application.ID,
+		AllocationOrder:   order,
+		DisbursementDate:  disbursementDate,
+		FinancedAmount:    schedule.Financing.FinancedAmount,
+		CapitalizedMargin: capitalized,
+		Status:            domain.ContractActive,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := u.repo.CreateContract(&contract, installments); err != nil {
+		return nil, err
+	}
+	return contractResponse(contract, installments, u.today()), nil
+}
+
+// changedTerms describes how schedule departs from the terms the
+// application agreed, or returns "" when it does not. Broken-period margin
+// depends on the disbursement date and is not part of the quoted terms.
+// Seasonal installments follow the calendar months they fall due in, so a
+// quote priced from another start date agreed different amounts; for them
+// only the financed amount and the quoted margin rate are compared.
+func changedTerms(schedule *domain.InstallmentScheduleResponse, application *domain.Application, quoted domain.CalculateInstallmentResponse, profile *domain.InstallmentProfile) string {
+	if profile != nil && profile.Type == domain.ProfileSeasonal {
+		rate := 0.0
+		for _, calculation := range quoted.Calculations {
+			if calculation.Tenor == application.Tenor {
+				rate = calculation.AnnualMarginRate
+			}
+		}
+		if schedule.Financing.FinancedAmount != application.FinancedAmount || schedule.AnnualMarginRate != rate {
+			return fmt.Sprintf("finances %d at an annual margin rate of %g, not the agreed %d at %g",
+				schedule.Financing.FinancedAmount, schedule.AnnualMarginRate, application.FinancedAmount, rate)
+		}
+		return ""
+	}
+
+	totalPayment := schedule.TotalPayment
+	if schedule.BrokenPeriod != nil {
+		totalPayment -= schedule.BrokenPeriod.Margin
+	}
+	if installment := regularInstallment(schedule.Schedule); installment != application.MonthlyInstallment || totalPayment != application.TotalPayment {
+		return fmt.Sprintf("comes to %d a month and %d in total, not the agreed %d and %d",
+			installment, totalPayment, application.MonthlyInstallment, application.TotalPayment)
+	}
+	return ""
+}
+
+// GetContract returns the outstanding balance and the status of every
+// installment as of query.AsOf (default today).
+func (u *contractUsecase) GetContract(id int64, query *domain.ContractQuery) (*domain.ContractResponse, error) {
+	asOf, err := parseDate(query.AsOf, "as_of", u.today())
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := u.findContract(id)
+	if err != nil {
+		return nil, err
+	}
+	installments, err := u.repo.ListContractInstallments(id)
+	if err != nil {
+		return nil, err
+	}
+	return contractResponse(*contract, installments, asOf), nil
+}
+
+// PostPayment allocates the payment over the contract's installments. A
+// payment beyond the outstanding balance is kept as credit balance; a
+// smaller one leaves the installments partially paid.
+func (u *contractUsecase) PostPayment(id int64, req *domain.PostPaymentRequest) (*domain.PostPaymentResponse, error) {
+	if req.Amount <= 0 {
+		return nil, &ValidationError{Field: "amount", Message: "amount must be greater than 0"}
+	}
+	paidOn, err := parseDate(req.PaidOn, "paid_on", u.today())
+	if err != nil {
+		return nil, err
+	}
+
+	var response *domain.PostPaymentResponse
+	err = u.updateLedger(id, func(contract *domain.Contract, installments []domain.ContractInstallment) (bool, error) {
+		duplicate, err := u.repo.GetPaymentByReference(id, req.Reference)
+		if err != nil {
+			return false, err
+		}
+		if duplicate != nil {
+			return false, &ConflictError{
+				Field:   "reference",
+				Code:    domain.CodeDuplicatePayment,
+				Message: fmt.Sprintf("payment %s was already posted as payment %d", req.Reference, duplicate.ID),
+			}
+		}
+		if contract.Status == domain.ContractSettled {
+			return false, &ValidationError{Code: domain.CodeContractSettled, Message: fmt.Sprintf("contract %d is settled", id)}
+		}
+		if paidOn.Before(calendarDate(contract.DisbursementDate)) {
+			return false, &ValidationError{Field: "paid_on", Message: "paid_on must not be before the disbursement date"}
+		}
+
+		outstanding := make(map[int]int64, len(installments))
+		for _, installment := range installments {
+			outstanding[installment.InstallmentNumber] = installment.Outstanding()
+		}
+		allocations, excess := allocatePayment(installments, req.Amount, contract.AllocationOrder, paidOn)
+
+		var changed []domain.ContractInstallment
+		settled := true
+		for _, installment := range installments {
+			if installment.Outstanding() != outstanding[installment.InstallmentNumber] {
+				changed = append(changed, installment)
+			}
+			if installment.Outstanding() > 0 {
+				settled = false
+			}
+		}
+		contract.CreditBalance += excess
+		if settled {
+			contract.Status = domain.ContractSettled
+		}
+
+		payment := domain.Payment{
+			ContractID:  id,
+			Reference:   req.Reference,
+			Amount:      req.Amount,
+			PaidOn:      paidOn,
+			Allocated:   req.Amount - excess,
+			Excess:      excess,
+			Actor:       req.Actor,
+			CreatedAt:   u.now().UnixMilli(),
+			Allocations: allocations,
+		}
+		applied, err := u.repo.PostPayment(contract, changed, &payment)
+		if err != nil || !applied {
+			return applied, err
+		}
+
+		response = &domain.PostPaymentResponse{
+			Payment:  paymentResponse(payment),
+			Contract: *contractResponse(*contract, installments, paidOn),
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (u *contractUsecase) ListPayments(id int64) (*domain.ListPaymentsResponse, error) {
+	if _, err := u.findContract(id); err != nil {
+		return nil, err
+	}
+
+	payments, err := u.repo.ListPayments(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.ListPaymentsResponse{ContractID: id, Payments: make([]domain.PaymentResponse, 0, len(payments))}
+	for _, payment := range payments {
+		response.Payments = append(response.Payments, paymentResponse(payment))
+	}
+	return response, nil
+}
+
+// AssessCharge adds a charge, such as a late-payment charge, to an
+// installment that is overdue today. The contract's credit balance pays
+// the charge first, and a settled contract is reopened for the rest.
+func (u *contractUsecase) AssessCharge(id int64, req *domain.AssessChargeRequest) (*domain.ContractResponse, error) {
+	if req.Amount <= 0 {
+		return nil, &ValidationError{Field: "amount", Message: "amount must be greater than 0"}
+	}
+	today := u.today()
+
+	var response *domain.ContractResponse
+	err := u.updateLedger(id, func(contract *domain.Contract, installments []domain.ContractInstallment) (bool, error) {
+		var installment *domain.ContractInstallment
+		for i := range installments {
+			if installments[i].InstallmentNumber == req.InstallmentNumber {
+				installment = &installments[i]
+			}
+		}
+		if installment == nil {
+			return false, &ValidationError{Field: "installment_number", Message: fmt.Sprintf("contract %d has no installment %d", id, req.InstallmentNumber)}
+		}
+		if !calendarDate(installment.DueDate).Before(today) {
+			return false, &ValidationError{Field: "installment_number", Message: fmt.Sprintf("installment %d is not overdue", req.InstallmentNumber)}
+		}
+
+		// Credit is only left by a payment that settled the contract, so
+		// the charge is the only amount it can pay. Whatever the credit
+		// does not cover reopens the contract.
+		installment.ChargeDue += req.Amount
+		credit := min(contract.CreditBalance, installment.Outstanding())
+		installment.ChargePaid += credit
+		contract.CreditBalance -= credit
+		if installment.Outstanding() > 0 {
+			installment.PaidOn = nil
+			contract.Status = domain.ContractActive
+		} else {
+			installment.PaidOn = &today
+		}
+
+		applied, err := u.repo.AssessCharge(contract, installment)
+		if err != nil || !applied {
+			return applied, err
+		}
+		response = contractResponse(*contract, installments, today)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// updateLedger loads the contract and runs post until it is applied. post
+// reports false when the contract changed after it was loaded, in which
+// case it is run again on the fresh ledger.
+func (u *contractUsecase) updateLedger(id int64, post func(*domain.Contract, []domain.ContractInstallment) (bool, error)) error {
+	for attempt := 0; attempt < maxPostingAttempts; attempt++ {
+		contract, err := u.findContract(id)
+		if err != nil {
+			return err
+		}
+		installments, err := u.repo.ListContractInstallments(id)
+		if err != nil {
+			return err
+		}
+
+		applied, err := post(contract, installments)
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+	}
+	return &ConflictError{
+		Code:    domain.CodeContractConflict,
+		Message: fmt.Sprintf("contract %d is being updated by other requests; retry", id),
+	}
+}
+
+func (u *contractUsecase) findContract(id int64) (*domain.Contract, error) {
+	contract, err := u.repo.GetContract(id)
+	if err != nil {
+		return nil, err
+	}
+	if contract == nil {
+		return nil, &NotFoundError{Message: fmt.Sprintf("contract %d not found", id)}
+	}
+	return contract, nil
+}
+
+func (u *contractUsecase) today() time.Time {
+	now := u.now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// calendarDate strips a DATE column, which the driver reads at midnight in
+// its own location, down to the UTC midnight used for request dates.
+func calendarDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func contractResponse(contract domain.Contract, installments []domain.ContractInstallment, asOf time.Time) *domain.ContractResponse {
+	response := &domain.ContractResponse{
+		ID:                contract.ID,
+		ApplicationID:     contract.ApplicationID,
+		Status:            contract.Status,
+		AllocationOrder:   contract.AllocationOrder,
+		DisbursementDate:  contract.DisbursementDate.Format(dateLayout),
+		FinancedAmount:    contract.FinancedAmount,
+		CapitalizedMargin: contract.CapitalizedMargin,
+		AsOf:              asOf.Format(dateLayout),
+		CreditBalance:     contract.CreditBalance,
+		Installments:      make([]domain.ContractInstallmentResponse, 0, len(installments)),
+	}
+
+	for _, installment := range installments {
+		outstanding := installment.Outstanding()
+		row := domain.ContractInstallmentResponse{
+			InstallmentNumber: installment.InstallmentNumber,
+			DueDate:           installment.DueDate.Format(dateLayout),
+			PrincipalDue:      installment.PrincipalDue,
+			MarginDue:         installment.MarginDue,
+			ChargeDue:         installment.ChargeDue,
+			PrincipalPaid:     installment.PrincipalPaid,
+			MarginPaid:        installment.MarginPaid,
+			ChargePaid:        installment.ChargePaid,
+			Outstanding:       outstanding,
+			Status:            domain.InstallmentUnpaid,
+			Overdue:           outstanding > 0 && calendarDate(installment.DueDate).Before(asOf),
+		}
+		switch {
+		case outstanding == 0:
+			row.Status = domain.InstallmentPaid
+		case installment.PrincipalPaid+installment.MarginPaid+installment.ChargePaid > 0:
+			row.Status = domain.InstallmentPartiallyPaid
+		}
+		if installment.PaidOn != nil {
+			row.PaidOn = installment.PaidOn.Format(dateLayout)
+		}
+
+		response.Outstanding.Principal += installment.PrincipalDue - installment.PrincipalPaid
+		response.Outstanding.Margin += installment.MarginDue - installment.MarginPaid
+		response.Outstanding.Charges += installment.ChargeDue - installment.ChargePaid
+		response.Outstanding.Total += outstanding
+		if row.Overdue {
+			response.Outstanding.Overdue += outstanding
+		}
+		response.Installments = append(response.Installments, row)
+	}
+	return response
+}
+
+func paymentResponse(payment domain.Payment) domain.PaymentResponse {
+	response := domain.PaymentResponse{
+		ID:         payment.ID,
+		ContractID: payment.ContractID,
+		Reference:  payment.Reference,
+		Amount:     payment.Amount,
+		PaidOn:     payment.PaidOn.Format(dateLayout),
+		Allocated:  payment.Allocated,
+		Excess:     payment.Excess,
+		Actor:      payment.Actor,
+		PostedAt:   formatMillis(payment.CreatedAt),
+	}
+	for _, allocation := range payment.Allocations {
+		response.Allocations = append(response.Allocations, domain.PaymentAllocationResponse{
+			InstallmentNumber: allocation.InstallmentNumber,
+			Component:         allocation.Component,
+			Amount:            allocation.Amount,
+		})
+	}
+	return response
+}
@@ -0,0 +1,476 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+type MockContractRepository struct {
+	contracts    []domain.Contract
+	installments []domain.ContractInstallment
+	payments     []domain.Payment
+	// races makes that many postings find the contract changed.
+	races int
+}
+
+func (m *MockContractRepository) CreateContract(contract *domain.Contract, installments []domain.ContractInstallment) error {
+	contract.ID = int64(len(m.contracts) + 1)
+	m.contracts = append(m.contracts, *contract)
+	for i := range installments {
+		installments[i].ID = int64(len(m.installments) + 1)
+		installments[i].ContractID = contract.ID
+		m.installments = append(m.installments, installments[i])
+	}
+	return nil
+}
+
+func (m *MockContractRepository) GetContract(id int64) (*domain.Contract, error) {
+	for _, contract := range m.contracts {
+		if contract.ID == id {
+			return &contract, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockContractRepository) GetContractByApplication(applicationID int64) (*domain.Contract, error) {
+	for _, contract := range m.contracts {
+		if contract.ApplicationID == applicationID {
+			return &contract, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockContractRepository) ListContractInstallments(contractID int64) ([]domain.ContractInstallment, error) {
+	var installments []domain.ContractInstallment
+	for _, installment := range m.installments {
+		if installment.ContractID == contractID {
+			installments = append(installments, installment)
+		}
+	}
+	return installments, nil
+}
+
+func (m *MockContractRepository) GetPaymentByReference(contractID int64, reference string) (*domain.Payment, error) {
+	for _, payment := range m.payments {
+		if payment.ContractID == contractID && payment.Reference == reference {
+			return &payment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockContractRepository) ListPayments(contractID int64) ([]domain.Payment, error) {
+	return m.payments, nil
+}
+
+func (m *MockContractRepository) PostPayment(contract *domain.Contract, installments []domain.ContractInstallment, payment *domain.Payment) (bool, error) {
+	if !m.saveContract(contract) {
+		return false, nil
+	}
+	for _, installment := range installments {
+		m.saveInstallment(installment)
+	}
+	payment.ID = int64(len(m.payments) + 1)
+	m.payments = append(m.payments, *payment)
+	return true, nil
+}
+
+func (m *MockContractRepository) AssessCharge(contract *domain.Contract, installment *domain.ContractInstallment) (bool, error) {
+	if !m.saveContract(contract) {
+		return false, nil
+	}
+	m.saveInstallment(*installment)
+	return true, nil
+}
+
+func (m *MockContractRepository) saveContract(contract *domain.Contract) bool {
+	if m.races > 0 {
+		m.races--
+		return false
+	}
+	for i := range m.contracts {
+		if m.contracts[i].ID == contract.ID && m.contracts[i].Version == contract.Version {
+			contract.Version++
+			m.contracts[i] = *contract
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockContractRepository) saveInstallment(installment domain.ContractInstallment) {
+	for i := range m.installments {
+		if m.installments[i].ID == installment.ID {
+			m.installments[i] = installment
+		}
+	}
+}
+
+// disbursedContracts opens contracts for a disbursed application quoted at
+// 12,000,000 over 12 months with a 20% flat margin: 1,000,000 principal and
+// 200,000 margin a month.
+func disbursedContracts(t *testing.T) (*contractUsecase, *MockContractRepository) {
+	rate := 0.2
+	schedules := NewCicilanUsecase(&MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12, FlatMarginRate: &rate}}})
+	return contractsQuotedWith(t, schedules, 0)
+}
+
+// contractsQuotedWith opens contracts from a quote saved on 2026-10-15 with
+// the given pricing version, scheduled by schedules.
+func contractsQuotedWith(t *testing.T, schedules cicilan.CicilanUsecase, pricingVersion int64) (*contractUsecase, *MockContractRepository) {
+	request, err := json.Marshal(domain.CalculateInstallmentRequest{Amount: 12000000, PricingOptions: domain.PricingOptions{Method: domain.MethodFlat}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := json.Marshal(domain.CalculateInstallmentResponse{AsOf: "2026-10-15", PricingVersionID: pricingVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	quotes := &MockQuoteRepository{quotes: []domain.Quote{
+		{ID: "q", PricingVersionID: pricingVersion, RequestBody: string(request), ResultBody: string(result), ExpiresAt: 1},
+	}}
+	applications := &MockApplicationRepository{applications: []domain.Application{
+		{ID: 1, QuoteID: "q", Tenor: 12, Status: domain.ApplicationDisbursed, MonthlyInstallment: 1200000, TotalPayment: 14400000},
+		{ID: 2, QuoteID: "q", Tenor: 12, Status: domain.ApplicationApproved, MonthlyInstallment: 1200000, TotalPayment: 14400000},
+		{ID: 3, QuoteID: "q", Tenor: 12, Status: domain.ApplicationDisbursed, MonthlyInstallment: 1240000, TotalPayment: 14880000},
+	}}
+
+	repo := &MockContractRepository{}
+	usecase := &contractUsecase{
+		repo:         repo,
+		applications: applications,
+		quotes:       quotes,
+		schedules:    schedules,
+		order:        domain.AllocationChargesMarginPrincipal,
+		now:          func() time.Time { return time.Date(2027, 1, 5, 10, 0, 0, 0, time.UTC) },
+	}
+	return usecase, repo
+}
+
+func openTestContract(t *testing.T, usecase *contractUsecase) *domain.ContractResponse {
+	resp, err := usecase.OpenContract(&domain.OpenContractRequest{ApplicationID: 1, DisbursementDate: "2026-10-20"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return resp
+}
+
+func TestOpenContract(t *testing.T) {
+	usecase, _ := disbursedContracts(t)
+
+	resp := openTestContract(t, usecase)
+	if len(resp.Installments) != 12 || resp.FinancedAmount != 12000000 || resp.AllocationOrder != domain.AllocationChargesMarginPrincipal {
+		t.Fatalf("Expected a 12-installment ledger for 12,000,000, got %+v", resp)
+	}
+	first := resp.Installments[0]
+	if first.DueDate != "2026-11-20" || first.PrincipalDue != 1000000 || first.MarginDue != 200000 || first.Status != domain.InstallmentUnpaid {
+		t.Errorf("Expected 1,000,000 + 200,000 unpaid on 2026-11-20, got %+v", first)
+	}
+	// As of 2027-01-05 the November and December installments are overdue.
+	if resp.Outstanding.Total != 14400000 || resp.Outstanding.Overdue != 2400000 || !resp.Installments[1].Overdue || resp.Installments[2].Overdue {
+		t.Errorf("Expected 14,400,000 outstanding with 2,400,000 overdue, got %+v", resp.Outstanding)
+	}
+
+	tests := []struct {
+		name string
+		req  domain.OpenContractRequest
+		code string
+	}{
+		{"NotDisbursed", domain.OpenContractRequest{ApplicationID: 2}, domain.CodeApplicationNotDisbursed},
+		{"BadOrder", domain.OpenContractRequest{ApplicationID: 2, AllocationOrder: "newest_first"}, ""},
+	}
+	for _, tt := range tests {
+		_, err := usecase.OpenContract(&tt.req)
+		if validationErr, ok := err.(*ValidationError); !ok || validationErr.Code != tt.code {
+			t.Errorf("%s: expected validation error %q, got %v", tt.name, tt.code, err)
+		}
+	}
+
+	_, err := usecase.OpenContract(&domain.OpenContractRequest{ApplicationID: 1})
+	if conflictErr, ok := err.(*ConflictError); !ok || conflictErr.Code != domain.CodeContractExists {
+		t.Errorf("Expected %s for an application that already has a contract, got %v", domain.CodeContractExists, err)
+	}
+}
+
+func TestOpenContract_QuotedPricingVersion(t *testing.T) {
+	rate20, rate24 := 0.2, 0.24
+	repriced := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC).UnixMilli()
+	deactivated := time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC).UnixMilli()
+	history := &MockPricingHistoryRepository{versions: []domain.PricingVersion{
+		{ID: 1, EffectiveAt: 0, Tenors: []domain.PricingVersionTenor{
+			{TenorID: 1, TenorValue: 12, FlatMarginRate: &rate20, Active: true, EffectiveTo: datePtr("2026-10-15")},
+		}},
+		{ID: 2, EffectiveAt: repriced, Tenors: []domain.PricingVersionTenor{
+			{TenorID: 1, TenorValue: 12, FlatMarginRate: &rate24, Active: true},
+		}},
+		{ID: 3, EffectiveAt: deactivated, Tenors: []domain.PricingVersionTenor{
+			{TenorID: 1, TenorValue: 12, FlatMarginRate: &rate24, Active: false},
+		}},
+	}}
+	schedules := NewCicilanUsecase(&MockCicilanRepository{}, WithPricingHistory(history))
+
+	// Quoted at 20% on the last day the tenor was offered; since then the
+	// rate went up to 24% and the tenor was withdrawn.
+	usecase, _ := contractsQuotedWith(t, schedules, 1)
+	resp := openTestContract(t, usecase)
+	if first := resp.Installments[0]; first.PrincipalDue != 1000000 || first.MarginDue != 200000 {
+		t.Errorf("Expected the quoted 1,000,000 + 200,000, got %+v", first)
+	}
+
+	// Terms that the quoted pricing does not reproduce are rejected.
+	_, err := usecase.OpenContract(&domain.OpenContractRequest{ApplicationID: 3, DisbursementDate: "2026-10-20"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Code != domain.CodeTermsChanged {
+		t.Errorf("Expected validation error %q, got %v", domain.CodeTermsChanged, err)
+	}
+}
+
+func TestOpenContract_CapitalizedGrace(t *testing.T) {
+	usecase, _ := disbursedContracts(t)
+	pricing := domain.PricingOptions{Method: domain.MethodFlat, GracePeriods: 2, GraceMode: domain.GraceCapitalized}
+	schedule, err := usecase.schedules.CalculateSchedule(&domain.CalculateScheduleRequest{Amount: 12000000, Tenor: 12, StartDate: "2026-10-20", PricingOptions: pricing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, err := json.Marshal(domain.CalculateInstallmentRequest{Amount: 12000000, PricingOptions: pricing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	usecase.quotes = &MockQuoteRepository{quotes: []domain.Quote{{ID: "q", RequestBody: string(request), ResultBody: `{"as_of": "2026-10-15"}`, ExpiresAt: 1}}}
+	usecase.applications = &MockApplicationRepository{applications: []domain.Application{
+		{ID: 1, QuoteID: "q", Tenor: 12, Status: domain.ApplicationDisbursed, MonthlyInstallment: regularInstallment(schedule.Schedule), TotalPayment: schedule.TotalPayment},
+	}}
+
+	resp := openTestContract(t, usecase)
+	// The grace months capitalize 200,000 and then 203,333, the margin on
+	// 12,200,000.
+	grace := resp.Installments[0]
+	if grace.PrincipalDue != 0 || grace.MarginDue != 0 || grace.Status != domain.InstallmentPaid {
+		t.Errorf("Expected nothing due in a capitalized grace month, got %+v", grace)
+	}
+	if resp.CapitalizedMargin != 403333 {
+		t.Errorf("Expected 403,333 capitalized margin, got %d", resp.CapitalizedMargin)
+	}
+	if resp.Outstanding.Principal != resp.FinancedAmount+resp.CapitalizedMargin || resp.Outstanding.Total != schedule.TotalPayment {
+		t.Errorf("Expected principal of 12,403,333 and %d in total outstanding, got %+v", schedule.TotalPayment, resp.Outstanding)
+	}
+}
+
+func TestOpenContract_SeasonalQuotedBeforeDisbursement(t *testing.T) {
+	usecase, _ := disbursedContracts(t)
+	pricing := domain.PricingOptions{Method: domain.MethodFlat, Profile: &domain.InstallmentProfile{Type: domain.ProfileSeasonal, HighMonths: []int{11}, HighFactor: 2}}
+
+	// Quoted on 2026-09-15, the first installment fell due in October; from
+	// the 2026-10-20 disbursement it falls due in the high month.
+	quotedSchedule, err := usecase.schedules.CalculateSchedule(&domain.CalculateScheduleRequest{Amount: 12000000, Tenor: 12, StartDate: "2026-09-15", PricingOptions: pricing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, err := json.Marshal(domain.CalculateInstallmentRequest{Amount: 12000000, PricingOptions: pricing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := json.Marshal(domain.CalculateInstallmentResponse{AsOf: "2026-09-15", Calculations: []domain.InstallmentCalculation{{Tenor: 12, AnnualMarginRate: 0.2}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	usecase.quotes = &MockQuoteRepository{quotes: []domain.Quote{{ID: "q", RequestBody: string(request), ResultBody: string(result), ExpiresAt: 1}}}
+	agreed := domain.Application{QuoteID: "q", Tenor: 12, Status: domain.ApplicationDisbursed, FinancedAmount: 12000000,
+		MonthlyInstallment: regularInstallment(quotedSchedule.Schedule), TotalPayment: quotedSchedule.TotalPayment}
+	otherAmount := agreed
+	otherAmount.ID, otherAmount.FinancedAmount = 2, 11000000
+	agreed.ID = 1
+	usecase.applications = &MockApplicationRepository{applications: []domain.Application{agreed, otherAmount}}
+
+	resp := openTestContract(t, usecase)
+	if first := resp.Installments[0]; first.DueDate != "2026-11-20" || first.PrincipalDue+first.MarginDue == agreed.MonthlyInstallment {
+		t.Errorf("Expected the November installment re-priced for the high month, got %+v", first)
+	}
+
+	_, err = usecase.OpenContract(&domain.OpenContractRequest{ApplicationID: 2, DisbursementDate: "2026-10-20"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Code != domain.CodeTermsChanged {
+		t.Errorf("Expected %s for a different financed amount, got %v", domain.CodeTermsChanged, err)
+	}
+}
+
+// inZone moves the contract's DATE columns to midnight in zone, as a driver
+// reading them in that location returns them.
+func inZone(repo *MockContractRepository, zone *time.Location) {
+	local := func(date time.Time) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, zone)
+	}
+	for i := range repo.contracts {
+		repo.contracts[i].DisbursementDate = local(repo.contracts[i].DisbursementDate)
+	}
+	for i := range repo.installments {
+		repo.installments[i].DueDate = local(repo.installments[i].DueDate)
+	}
+}
+
+func TestContractDatesAcrossTimeZones(t *testing.T) {
+	// East of UTC a local midnight is still the previous day in UTC, so an
+	// installment would turn overdue on its own due date.
+	usecase, repo := disbursedContracts(t)
+	openTestContract(t, usecase)
+	inZone(repo, time.FixedZone("WIB", 7*60*60))
+
+	resp, err := usecase.GetContract(1, &domain.ContractQuery{AsOf: "2026-12-20"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Installments[0].Overdue || resp.Installments[1].Overdue {
+		t.Errorf("Expected only the November installment overdue on the December due date, got %+v", resp.Installments[:2])
+	}
+
+	// Today is 2027-01-05.
+	repo.installments[2].DueDate = time.Date(2027, 1, 5, 0, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	_, err = usecase.AssessCharge(1, &domain.AssessChargeRequest{InstallmentNumber: 3, Amount: 10000})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "installment_number" {
+		t.Errorf("Expected an installment due today not to be overdue, got %v", err)
+	}
+
+	// West of UTC a local midnight is later on the same day in UTC, so a
+	// payment on the disbursement date would look earlier than it.
+	usecase, repo = disbursedContracts(t)
+	openTestContract(t, usecase)
+	inZone(repo, time.FixedZone("EST", -5*60*60))
+
+	if _, err := usecase.PostPayment(1, &domain.PostPaymentRequest{Reference: "tz", Amount: 1200000, PaidOn: "2026-10-20"}); err != nil {
+		t.Errorf("Expected a payment on the disbursement date to be accepted, got %v", err)
+	}
+}
+
+func TestPostPayment_UnderAndOverpayment(t *testing.T) {
+	usecase, repo := disbursedContracts(t)
+	contract := openTestContract(t, usecase)
+
+	resp, err := usecase.PostPayment(contract.ID, &domain.PostPaymentRequest{Amount: 1500000, PaidOn: "2026-12-22", Reference: "VA-1", Actor: "channel:va"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Both due margins first, then principal of the oldest installment.
+	expected := []domain.PaymentAllocationResponse{
+		{InstallmentNumber: 1, Component: domain.ComponentMargin, Amount: 200000},
+		{InstallmentNumber: 2, Component: domain.ComponentMargin, Amount: 200000},
+		{InstallmentNumber: 1, Component: domain.ComponentPrincipal, Amount: 1000000},
+		{InstallmentNumber: 2, Component: domain.ComponentPrincipal, Amount: 100000},
+	}
+	if len(resp.Payment.Allocations) != len(expected) {
+		t.Fatalf("Expected allocations %+v, got %+v", expected, resp.Payment.Allocations)
+	}
+	for i := range expected {
+		if resp.Payment.Allocations[i] != expected[i] {
+			t.Errorf("Allocation %d: expected %+v, got %+v", i, expected[i], resp.Payment.Allocations[i])
+		}
+	}
+	if resp.Contract.Installments[0].Status != domain.InstallmentPaid || resp.Contract.Installments[0].PaidOn != "2026-12-22" ||
+		resp.Contract.Installments[1].Status != domain.InstallmentPartiallyPaid || resp.Contract.Installments[1].Outstanding != 900000 {
+		t.Errorf("Expected installment 1 paid and 900,000 left on installment 2, got %+v", resp.Contract.Installments[:2])
+	}
+	if repo.contracts[0].Version != 1 || repo.installments[1].PrincipalPaid != 100000 {
+		t.Errorf("Expected the posting saved, got %+v", repo.contracts[0])
+	}
+
+	resp, err = usecase.PostPayment(contract.ID, &domain.PostPaymentRequest{Amount: 13000000, PaidOn: "2027-01-05", Reference: "VA-2", Actor: "channel:va"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// 14,400,000 owed less 1,500,000 paid leaves 12,900,000.
+	if resp.Payment.Allocated != 12900000 || resp.Payment.Excess != 100000 {
+		t.Errorf("Expected 12,900,000 allocated and 100,000 excess, got %+v", resp.Payment)
+	}
+	if resp.Contract.Status != domain.ContractSettled || resp.Contract.CreditBalance != 100000 || resp.Contract.Outstanding.Total != 0 {
+		t.Errorf("Expected a settled contract with 100,000 credit, got %+v", resp.Contract)
+	}
+
+	_, err = usecase.PostPayment(contract.ID, &domain.PostPaymentRequest{Amount: 1000, Reference: "VA-3", Actor: "channel:va"})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Code != domain.CodeContractSettled {
+		t.Errorf("Expected %s, got %v", domain.CodeContractSettled, err)
+	}
+}
+
+func TestAssessCharge_PaidFromCreditBalance(t *testing.T) {
+	usecase, repo := disbursedContracts(t)
+	contract := openTestContract(t, usecase)
+	if _, err := usecase.PostPayment(contract.ID, &domain.PostPaymentRequest{Amount: 14500000, PaidOn: "2027-01-05", Reference: "VA-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The 100,000 credit covers the first charge in full.
+	resp, err := usecase.AssessCharge(contract.ID, &domain.AssessChargeRequest{InstallmentNumber: 1, Amount: 25000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Status != domain.ContractSettled || resp.CreditBalance != 75000 || resp.Installments[0].ChargePaid != 25000 || resp.Installments[0].PaidOn != "2027-01-05" {
+		t.Errorf("Expected the charge paid from credit and the contract still settled, got %+v", resp)
+	}
+
+	// The rest of the credit covers part of the second.
+	resp, err = usecase.AssessCharge(contract.ID, &domain.AssessChargeRequest{InstallmentNumber: 2, Amount: 100000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Status != domain.ContractActive || resp.CreditBalance != 0 || resp.Outstanding.Charges != 25000 || resp.Installments[1].Status != domain.InstallmentPartiallyPaid {
+		t.Errorf("Expected 25,000 of the charge left on a reopened contract, got %+v", resp)
+	}
+	if repo.contracts[0].CreditBalance != 0 || repo.installments[1].ChargePaid != 75000 {
+		t.Errorf("Expected the credit used saved, got %+v and %+v", repo.contracts[0], repo.installments[1])
+	}
+}
+
+func TestPostPayment_DuplicateAndConflict(t *testing.T) {
+	usecase, repo := disbursedContracts(t)
+	contract := openTestContract(t, usecase)
+	req := &domain.PostPaymentRequest{Amount: 1200000, PaidOn: "2026-11-20", Reference: "VA-1", Actor: "channel:va"}
+
+	// A posting that loses a race is recomputed on the fresh ledger.
+	repo.races = 2
+	if _, err := usecase.PostPayment(contract.ID, req); err != nil {
+		t.Fatalf("Expected the posting to succeed on retry, got %v", err)
+	}
+
+	_, err := usecase.PostPayment(contract.ID, req)
+	if conflictErr, ok := err.(*ConflictError); !ok || conflictErr.Code != domain.CodeDuplicatePayment {
+		t.Errorf("Expected %s, got %v", domain.CodeDuplicatePayment, err)
+	}
+
+	repo.races = maxPostingAttempts
+	_, err = usecase.PostPayment(contract.ID, &domain.PostPaymentRequest{Amount: 1000, Reference: "VA-2", Actor: "channel:va"})
+	if conflictErr, ok := err.(*ConflictError); !ok || conflictErr.Code != domain.CodeContractConflict {
+		t.Errorf("Expected %s, got %v", domain.CodeContractConflict, err)
+	}
+	if len(repo.payments) != 1 {
+		t.Errorf("Expected one payment posted, got %d", len(repo.payments))
+	}
+}
+
+func TestAssessCharge(t *testing.T) {
+	usecase, repo := disbursedContracts(t)
+	contract := openTestContract(t, usecase)
+
+	resp, err := usecase.AssessCharge(contract.ID, &domain.AssessChargeRequest{InstallmentNumber: 1, Amount: 25000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Installments[0].ChargeDue != 25000 || resp.Outstanding.Charges != 25000 || repo.installments[0].ChargeDue != 25000 {
+		t.Errorf("Expected a 25,000 charge on installment 1, got %+v", resp.Installments[0])
+	}
+
+	// January's installment is not due until 2027-01-20.
+	if _, err := usecase.AssessCharge(contract.ID, &domain.AssessChargeRequest{InstallmentNumber: 3, Amount: 25000}); err == nil {
+		t.Error("Expected an error charging an installment that is not overdue")
+	}
+
+	payment, err := usecase.PostPayment(contract.ID, &domain.PostPaymentRequest{Amount: 25000, PaidOn: "2026-12-01", Reference: "VA-1", Actor: "channel:va"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if allocation := payment.Payment.Allocations[0]; allocation.Component != domain.ComponentCharge || allocation.Amount != 25000 {
+		t.Errorf("Expected the charge paid first, got %+v", allocation)
+	}
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"btpntest/domain"
+)
+
+const defaultRebateRate = 1.0
+
+// CalculateEarlySettlement quotes the amount needed to close a contract
+// before maturity. The original schedule is rebuilt with the same pricing
+// engine and the pricing in effect on the start date, margin already earned
+// up to the settlement date is kept, and the muqasah rebate is granted on
+// the unearned remainder.
+func (u *cicilanUsecase) CalculateEarlySettlement(req *domain.EarlySettlementRequest) (*domain.EarlySettlementResponse, error) {
+	if req.Amount <= 0 {
+		return nil, &ValidationError{Message: "amount must be greater than 0"}
+	}
+	if req.Tenor <= 0 {
+		return nil, &ValidationError{Message: "tenor must be greater than 0"}
+	}
+	if req.InstallmentsPaid < 0 {
+		return nil, &ValidationError{Message: "installments_paid must not be negative"}
+	}
+	if req.StartDate == "" {
+		return nil, &ValidationError{Message: "start_date is required"}
+	}
+
+	startDate, err := parseDate(req.StartDate, "start_date", time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	settlementDate, err := parseDate(req.SettlementDate, "settlement_date", today())
+	if err != nil {
+		return nil, err
+	}
+	if settlementDate.Before(startDate) {
+		return nil, &ValidationError{Message: "settlement_date must not be before start_date"}
+	}
+
+	unearnedMethod, err := resolveUnearnedMarginMethod(req.UnearnedMarginMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	rebateRate := defaultRebateRate
+	if req.RebateRate != nil {
+		rebateRate = *req.RebateRate
+	}
+	if rebateRate < 0 || rebateRate > 1 {
+		return nil, &ValidationError{Message: "rebate_rate must be between 0 and 1"}
+	}
+
+	terms, err := u.resolveTerms(req.PricingOptions, pricingInstant(startDate))
+	if err != nil {
+		return nil, err
+	}
+
+	terms.dueDates, err = u.resolveDueDates(req.DueDateOptions, terms.frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, calculation, err := u.tenorSchedule(terms, req.Tenor, req.Amount, startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.InstallmentsPaid >= len(rows) {
+		return nil, &ValidationError{Message: fmt.Sprintf("installments_paid must be less than the %d installments of tenor %d", len(rows), req.Tenor)}
+	}
+
+	outstandingPrincipal := req.Amount
+	if req.InstallmentsPaid > 0 {
+		outstandingPrincipal = rows[req.InstallmentsPaid-1].RemainingBalance
+	}
+
+	remaining := rows[req.InstallmentsPaid:]
+	var outstandingMargin, dueMargin int64
+	due := 0
+	for _, row := range remaining {
+		outstandingMargin += row.Margin
+		if !dueOnOrBefore(row, settlementDate) {
+			continue
+		}
+		dueMargin += row.Margin
+		due++
+	}
+
+	var unearned int64
+	switch unearnedMethod {
+	case domain.UnearnedMarginRuleOf78:
+		unearned = ruleOf78Unearned(calculation.TotalMargin, len(rows), len(remaining)-due)
+		if unearned > outstandingMargin-dueMargin {
+			unearned = outstandingMargin - dueMargin
+		}
+	default:
+		accrued := int64(0)
+		if due < len(remaining) {
+			previous := startDate
+			if index := req.InstallmentsPaid + due; index > 0 {
+				previous, _ = time.Parse(dateLayout, rows[index-1].DueDate)
+			}
+			accrued = accruedMargin(remaining[due], previous, settlementDate)
+		}
+		unearned = outstandingMargin - dueMargin - accrued
+	}
+
+	rebate := roundRat(new(big.Rat).Mul(ratFromInt(unearned), ratFromFloat(rebateRate)), rupiah)
+
+	return &domain.EarlySettlementResponse{
+		Tenor:                calculation.Tenor,
+		PricingVersionID:     terms.versionID(),
+		Method:               calculation.Method,
+		PaymentFrequency:     calculation.PaymentFrequency,
+		InstallmentCount:     calculation.InstallmentCount,
+		InstallmentsPaid:     req.InstallmentsPaid,
+		InstallmentsDue:      due,
+		SettlementDate:       settlementDate.Format(dateLayout),
+		OutstandingPrincipal: outstandingPrincipal,
+		OutstandingMargin:    outstandingMargin,
+		EarnedMargin:         outstandingMargin - unearned,
+		UnearnedMargin:       unearned,
+		UnearnedMarginMethod: unearnedMethod,
+		RebateRate:           rebateRate,
+		Rebate:               rebate,
+		SettlementAmount:     outstandingPrincipal + outstandingMargin - rebate,
+	}, nil
+}
+
+func resolveUnearnedMarginMethod(method string) (string, error) {
+	switch method {
+	case "":
+		return domain.UnearnedMarginSchedule, nil
+	case domain.UnearnedMarginSchedule, domain.UnearnedMarginRuleOf78:
+		return method, nil
+	default:
+		return "", &ValidationError{Message: fmt.Sprintf("unsupported unearned_margin_method: %s", method)}
+	}
+}
+
+func dueOnOrBefore(row domain.InstallmentScheduleRow, date time.Time) bool {
+	due, err := time.Parse(dateLayout, row.DueDate)
+	return err == nil && !due.After(date)
+}
+
+// accruedMargin is the part of the running installment's margin earned
+// between the previous due date and the settlement date, pro rata by days.
+func accruedMargin(row domain.InstallmentScheduleRow, previous, settlement time.Time) int64 {
+	due, err := time.Parse(dateLayout, row.DueDate)
+	if err != nil {
+		return 0
+	}
+
+	periodDays := daysBetween(previous, due)
+	elapsedDays := daysBetween(previous, settlement)
+	if periodDays <= 0 || elapsedDays <= 0 {
+		return 0
+	}
+
+	share := new(big.Rat).SetFrac64(int64(elapsedDays), int64(periodDays))
+	return roundRat(share.Mul(share, ratFromInt(row.Margin)), rupiah)
+}
+
+// ruleOf78Unearned applies the sum-of-digits rule: with k of n installments
+// remaining, k(k+1)/n(n+1) of the total margin is still unearned.
+func ruleOf78Unearned(totalMargin int64, count, remaining int) int64 {
+	if remaining <= 0 {
+		return 0
+	}
+
+	share := new(big.Rat).SetFrac64(int64(remaining)*int64(remaining+1), int64(count)*int64(count+1))
+	return roundRat(share.Mul(share, ratFromInt(totalMargin)), rupiah)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"testing"
+
+	"btpntest/domain"
+)
+
+func settlementUsecase() *cicilanUsecase {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	return NewCicilanUsecase(mockRepo).(*cicilanUsecase)
+}
+
+func TestCalculateEarlySettlement_ScheduleAccrual(t *testing.T) {
+	rebateRate := 0.5
+	resp, err := settlementUsecase().CalculateEarlySettlement(&domain.EarlySettlementRequest{
+		Amount:           12000000,
+		Tenor:            12,
+		StartDate:        "2026-01-15",
+		InstallmentsPaid: 6,
+		SettlementDate:   "2026-07-30",
+		RebateRate:       &rebateRate,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 15 of the 31 days between 2026-07-15 and 2026-08-15 have elapsed.
+	if resp.OutstandingPrincipal != 6000000 {
+		t.Errorf("Expected outstanding principal 6000000, got %d", resp.OutstandingPrincipal)
+	}
+	if resp.OutstandingMargin != 1200000 {
+		t.Errorf("Expected outstanding margin 1200000, got %d", resp.OutstandingMargin)
+	}
+	if resp.EarnedMargin != 96774 || resp.UnearnedMargin != 1103226 {
+		t.Errorf("Expected earned/unearned 96774/1103226, got %d/%d", resp.EarnedMargin, resp.UnearnedMargin)
+	}
+	if resp.Rebate != 551613 {
+		t.Errorf("Expected rebate 551613, got %d", resp.Rebate)
+	}
+	if resp.SettlementAmount != 6648387 {
+		t.Errorf("Expected settlement amount 6648387, got %d", resp.SettlementAmount)
+	}
+}
+
+func TestCalculateEarlySettlement_RuleOf78(t *testing.T) {
+	resp, err := settlementUsecase().CalculateEarlySettlement(&domain.EarlySettlementRequest{
+		Amount:               12000000,
+		Tenor:                12,
+		StartDate:            "2026-01-15",
+		InstallmentsPaid:     6,
+		SettlementDate:       "2026-07-30",
+		UnearnedMarginMethod: domain.UnearnedMarginRuleOf78,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 2,400,000 * (6*7)/(12*13)
+	if resp.UnearnedMargin != 646154 {
+		t.Errorf("Expected unearned margin 646154, got %d", resp.UnearnedMargin)
+	}
+	if resp.RebateRate != 1 || resp.Rebate != 646154 {
+		t.Errorf("Expected full rebate of 646154, got %v/%d", resp.RebateRate, resp.Rebate)
+	}
+	if resp.SettlementAmount != 6553846 {
+		t.Errorf("Expected settlement amount 6553846, got %d", resp.SettlementAmount)
+	}
+}
+
+func TestCalculateEarlySettlement_OverdueInstallmentsEarnMargin(t *testing.T) {
+	resp, err := settlementUsecase().CalculateEarlySettlement(&domain.EarlySettlementRequest{
+		Amount:           12000000,
+		Tenor:            12,
+		StartDate:        "2026-01-15",
+		InstallmentsPaid: 5,
+		SettlementDate:   "2026-07-15",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.InstallmentsDue != 1 {
+		t.Errorf("Expected 1 installment due, got %d", resp.InstallmentsDue)
+	}
+	if resp.OutstandingPrincipal != 7000000 || resp.OutstandingMargin != 1400000 {
+		t.Errorf("Expected outstanding 7000000/1400000, got %d/%d", resp.OutstandingPrincipal, resp.OutstandingMargin)
+	}
+	if resp.UnearnedMargin != 1200000 {
+		t.Errorf("Expected unearned margin 1200000, got %d", resp.UnearnedMargin)
+	}
+	if resp.SettlementAmount != 7200000 {
+		t.Errorf("Expected settlement amount 7200000, got %d", resp.SettlementAmount)
+	}
+}
+
+func TestCalculateEarlySettlement_PricedAsOfStartDate(t *testing.T) {
+	liveRate := 0.3
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 2, TenorValue: 12, FlatMarginRate: &liveRate}}}
+	usecase := NewCicilanUsecase(mockRepo, WithPricingHistory(pricingHistory()))
+
+	tests := []struct {
+		name    string
+		request int64
+		version int64
+		margin  int64
+	}{
+		// Started before the repricing on 1 October, so priced at 20%.
+		{"start date", 0, 1, 2400000},
+		{"requested version", 2, 2, 2880000},
+	}
+
+	for _, tt := range tests {
+		resp, err := usecase.CalculateEarlySettlement(&domain.EarlySettlementRequest{
+			Amount:         12000000,
+			Tenor:          12,
+			StartDate:      "2026-09-15",
+			SettlementDate: "2026-09-15",
+			PricingOptions: domain.PricingOptions{PricingVersionID: tt.request},
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+		if resp.PricingVersionID != tt.version || resp.OutstandingMargin != tt.margin {
+			t.Errorf("%s: expected version %d and outstanding margin %d, got %d and %d",
+				tt.name, tt.version, tt.margin, resp.PricingVersionID, resp.OutstandingMargin)
+		}
+	}
+}
+
+func TestCalculateEarlySettlement_Validation(t *testing.T) {
+	usecase := settlementUsecase()
+	base := domain.EarlySettlementRequest{Amount: 12000000, Tenor: 12, StartDate: "2026-01-15", SettlementDate: "2026-03-01"}
+
+	invalid := map[string]func(req *domain.EarlySettlementRequest){
+		"all installments paid":     func(req *domain.EarlySettlementRequest) { req.InstallmentsPaid = 12 },
+		"settlement before start":   func(req *domain.EarlySettlementRequest) { req.SettlementDate = "2025-12-31" },
+		"unknown unearned method":   func(req *domain.EarlySettlementRequest) { req.UnearnedMarginMethod = "sum_of_years" },
+		"missing start date":        func(req *domain.EarlySettlementRequest) { req.StartDate = "" },
+		"rebate rate above one":     func(req *domain.EarlySettlementRequest) { rate := 1.5; req.RebateRate = &rate },
+		"unavailable tenor":         func(req *domain.EarlySettlementRequest) { req.Tenor = 24 },
+		"malformed settlement date": func(req *domain.EarlySettlementRequest) { req.SettlementDate = "01-03-2026" },
+	}
+
+	for name, mutate := range invalid {
+		req := base
+		mutate(&req)
+		_, err := usecase.CalculateEarlySettlement(&req)
+		if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+
+	"btpntest/domain"
+)
+
+const (
+	irrTolerance     = 1e-12
+	irrMaxIterations = 200
+	irrPrecision     = 1e6
+)
+
+var errIRRNotConverged = errors.New("effective rate did not converge")
+
+// effectiveAnnualRate is the true annual cost of the financing: the internal
+// rate of return of the installments against the amount actually disbursed,
+// compounded over a year. The customer receives the principal less the
+// product fee and the charges of the financing, whether those are paid
+// upfront or financed into the principal.
+func effectiveAnnualRate(principal, fee int64, financing domain.FinancingBreakdown, rows []domain.InstallmentScheduleRow, periodsPerYear int) (float64, error) {
+	disbursed := principal - fee - financing.UpfrontCharges - financing.FinancedCharges
+	if disbursed <= 0 || len(rows) == 0 {
+		return 0, nil
+	}
+
+	periodRate, err := solveIRR(float64(disbursed), cashFlows(rows))
+	if err != nil {
+		return 0, err
+	}
+
+	annualRate := math.Pow(1+periodRate, float64(periodsPerYear)) - 1
+	return math.Round(annualRate*irrPrecision) / irrPrecision, nil
+}
+
+func cashFlows(rows []domain.InstallmentScheduleRow) []float64 {
+	flows := make([]float64, len(rows))
+	for i, row := range rows {
+		flows[i] = float64(row.Installment)
+	}
+	return flows
+}
+
+func solveIRR(principal float64, flows []float64) (float64, error) {
+	npv := func(rate float64) (float64, float64) {
+		value, derivative := -principal, 0.0
+		discount := 1.0
+		for period, flow := range flows {
+			discount /= 1 + rate
+			value += flow * discount
+			derivative -= float64(period+1) * flow * discount / (1 + rate)
+		}
+		return value, derivative
+	}
+
+	low, high := 0.0, 0.1
+	lowValue, _ := npv(low)
+	if lowValue <= 0 {
+		return 0, nil
+	}
+
+	for highValue, _ := npv(high); highValue > 0; highValue, _ = npv(high) {
+		high *= 2
+		if high > 1e6 {
+			return 0, errIRRNotConverged
+		}
+	}
+
+	rate := (low + high) / 2
+	for i := 0; i < irrMaxIterations; i++ {
+		value, derivative := npv(rate)
+		if math.Abs(value) < irrTolerance*principal {
+			return rate, nil
+		}
+
+		if value > 0 {
+			low = rate
+		} else {
+			high = rate
+		}
+
+		next := rate - value/derivative
+		if derivative == 0 || next <= low || next >= high {
+			next = (low + high) / 2
+		}
+
+		if math.Abs(next-rate) < irrTolerance {
+			return next, nil
+		}
+		rate = next
+	}
+
+	return 0, errIRRNotConverged
+}
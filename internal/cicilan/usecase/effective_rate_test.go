@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"btpntest/domain"
+)
+
+func TestSolveIRR_ConvergesForSupportedRange(t *testing.T) {
+	amounts := []int64{100000, 1000000, 10000000, 500000000, 10000000000, 1000000000000}
+	tenors := []int{6, 12, 18, 24, 30, 36}
+	rates := []float64{0, 0.01, 0.18, 0.2, 0.24, 0.6}
+
+	for _, method := range []string{domain.MethodFlat, domain.MethodEffective} {
+		calculator := defaultMethods()[method]
+		for _, amount := range amounts {
+			for _, tenor := range tenors {
+				for _, rate := range rates {
+					rows, err := calculator.BuildSchedule(domain.PricingInput{
+						Principal: amount, Installments: tenor, PeriodsPerYear: 12, AnnualMarginRate: rate, Rounding: rupiah,
+					})
+					if err != nil {
+						t.Fatalf("%s %d/%d/%v: expected no error, got %v", method, amount, tenor, rate, err)
+					}
+
+					eir, err := effectiveAnnualRate(amount, 0, domain.FinancingBreakdown{}, rows, 12)
+					if err != nil {
+						t.Fatalf("%s %d/%d/%v: expected convergence, got %v", method, amount, tenor, rate, err)
+					}
+
+					if eir < rate-0.0001 {
+						t.Errorf("%s %d/%d/%v: effective rate %v below contractual rate", method, amount, tenor, rate, eir)
+					}
+					// The effective method compounds its nominal rate monthly.
+					compounded := math.Pow(1+rate/12, 12) - 1
+					if method == domain.MethodEffective && amount >= 10000000 && math.Abs(eir-compounded) > 0.0001 {
+						t.Errorf("%s %d/%d/%v: expected effective rate close to %v, got %v", method, amount, tenor, rate, compounded, eir)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestEffectiveAnnualRate_FlatTwentyPercent(t *testing.T) {
+	rows, err := NewFlatMethod().BuildSchedule(domain.PricingInput{
+		Principal: 10000000, Installments: 12, PeriodsPerYear: 12, AnnualMarginRate: 0.2, Rounding: rupiah,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	eir, err := effectiveAnnualRate(10000000, 0, domain.FinancingBreakdown{}, rows, 12)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 2.9229% a month, compounded.
+	if math.Abs(eir-0.4130) > 0.0005 {
+		t.Errorf("Expected effective annual rate around 0.4130, got %v", eir)
+	}
+}
+
+func TestEffectiveAnnualRate_IncludesUpfrontFee(t *testing.T) {
+	rows, err := NewEffectiveMethod().BuildSchedule(domain.PricingInput{
+		Principal: 10000000, Installments: 12, PeriodsPerYear: 12, AnnualMarginRate: 0.2, Rounding: rupiah,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	withoutFee, err := effectiveAnnualRate(10000000, 0, domain.FinancingBreakdown{}, rows, 12)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	withFee, err := effectiveAnnualRate(10000000, 200000, domain.FinancingBreakdown{}, rows, 12)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if math.Abs(withoutFee-0.2194) > 0.0005 {
+		t.Errorf("Expected 20%% compounded monthly, around 0.2194, got %v", withoutFee)
+	}
+	if withFee <= withoutFee+0.03 {
+		t.Errorf("Expected a 2%% upfront fee to raise the rate well above %v, got %v", withoutFee, withFee)
+	}
+
+	if eir, err := effectiveAnnualRate(10000000, 10000000, domain.FinancingBreakdown{}, rows, 12); err != nil || eir != 0 {
+		t.Errorf("Expected zero without a net disbursement, got %v, %v", eir, err)
+	}
+}
+
+func TestCalculateSchedule_EffectiveRateNetsOutCharges(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}})
+
+	eir := func(adminFee *domain.FinancingCharge) float64 {
+		t.Helper()
+		resp, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+			AssetPrice:          10000000,
+			Tenor:               12,
+			FinancingComponents: domain.FinancingComponents{AdminFee: adminFee},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return resp.EffectiveAnnualRate
+	}
+
+	none := eir(nil)
+	financed := eir(&domain.FinancingCharge{Amount: 1000000, Financed: true})
+	upfront := eir(&domain.FinancingCharge{Amount: 1000000})
+
+	// A financed fee also bears margin, but an upfront fee is paid out of a
+	// smaller financed amount and weighs more against it.
+	if math.Abs(none-0.4130) > 0.0005 {
+		t.Errorf("Expected about 0.4130 without charges, got %v", none)
+	}
+	if financed <= none || upfront <= financed {
+		t.Errorf("Expected the rate to rise from %v with a financed fee (%v) and more with an upfront one (%v)", none, financed, upfront)
+	}
+}
+
+func TestSolveIRR_ZeroMargin(t *testing.T) {
+	rate, err := solveIRR(1200, []float64{100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rate != 0 {
+		t.Errorf("Expected zero rate, got %v", rate)
+	}
+}
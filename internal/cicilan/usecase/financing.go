@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"fmt"
+	"math/big"
+
+	"btpntest/domain"
+)
+
+func resolveFinancing(amount, assetPrice int64, components domain.FinancingComponents) (domain.FinancingBreakdown, error) {
+	if amount > 0 && assetPrice > 0 {
+		return domain.FinancingBreakdown{}, &ValidationError{Message: "provide either amount or asset_price, not both"}
+	}
+
+	price := amount
+	if assetPrice > 0 {
+		price = assetPrice
+	}
+
+	if price <= 0 {
+		return domain.FinancingBreakdown{}, &ValidationError{Message: "amount must be greater than 0"}
+	}
+
+	if components.DownPayment < 0 {
+		return domain.FinancingBreakdown{}, &ValidationError{Message: "down_payment must not be negative"}
+	}
+	if components.DownPaymentRate < 0 || components.DownPaymentRate >= 1 {
+		return domain.FinancingBreakdown{}, &ValidationError{Message: "down_payment_rate must be between 0 and 1"}
+	}
+	if components.DownPayment > 0 && components.DownPaymentRate > 0 {
+		return domain.FinancingBreakdown{}, &ValidationError{Message: "provide either down_payment or down_payment_rate, not both"}
+	}
+
+	downPayment := components.DownPayment
+	if components.DownPaymentRate > 0 {
+		downPayment = roundRat(new(big.Rat).Mul(ratFromInt(price), ratFromFloat(components.DownPaymentRate)), rupiah)
+	}
+
+	if downPayment >= price {
+		return domain.FinancingBreakdown{}, &ValidationError{Message: "down_payment must be less than the asset price"}
+	}
+
+	breakdown := domain.FinancingBreakdown{
+		AssetPrice:  price,
+		DownPayment: downPayment,
+	}
+
+	charges := []struct {
+		name   string
+		charge *domain.FinancingCharge
+	}{
+		{"admin_fee", components.AdminFee},
+		{"takaful_contribution", components.TakafulContribution},
+		{"stamp_duty", components.StampDuty},
+	}
+
+	for _, item := range charges {
+		if item.charge == nil {
+			continue
+		}
+		if item.charge.Amount < 0 {
+			return domain.FinancingBreakdown{}, &ValidationError{Message: fmt.Sprintf("%s must not be negative", item.name)}
+		}
+
+		if item.charge.Financed {
+			breakdown.FinancedCharges += item.charge.Amount
+		} else {
+			breakdown.UpfrontCharges += item.charge.Amount
+		}
+	}
+
+	breakdown.FinancedAmount = price - downPayment + breakdown.FinancedCharges
+	breakdown.UpfrontPayment = downPayment + breakdown.UpfrontCharges
+
+	return breakdown, nil
+}
+
+// withProductFee adds the product's fee, which is collected at signing, to
+// the cash the customer pays upfront.
+func withProductFee(breakdown domain.FinancingBreakdown, fee int64) domain.FinancingBreakdown {
+	breakdown.ProductFee = fee
+	breakdown.UpfrontPayment += fee
+	return breakdown
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"testing"
+
+	"btpntest/domain"
+)
+
+func TestResolveFinancing_AmountOnly(t *testing.T) {
+	breakdown, err := resolveFinancing(10000000, 0, domain.FinancingComponents{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if breakdown.FinancedAmount != 10000000 || breakdown.UpfrontPayment != 0 {
+		t.Errorf("Expected financed 10000000 and upfront 0, got %d and %d", breakdown.FinancedAmount, breakdown.UpfrontPayment)
+	}
+}
+
+func TestResolveFinancing_Components(t *testing.T) {
+	components := domain.FinancingComponents{
+		DownPaymentRate:     0.2,
+		AdminFee:            &domain.FinancingCharge{Amount: 500000, Financed: true},
+		TakafulContribution: &domain.FinancingCharge{Amount: 300000, Financed: true},
+		StampDuty:           &domain.FinancingCharge{Amount: 10000},
+	}
+
+	breakdown, err := resolveFinancing(0, 15000000, components)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if breakdown.DownPayment != 3000000 {
+		t.Errorf("Expected down_payment 3000000, got %d", breakdown.DownPayment)
+	}
+	if breakdown.FinancedCharges != 800000 {
+		t.Errorf("Expected financed_charges 800000, got %d", breakdown.FinancedCharges)
+	}
+	if breakdown.UpfrontCharges != 10000 {
+		t.Errorf("Expected upfront_charges 10000, got %d", breakdown.UpfrontCharges)
+	}
+	if breakdown.FinancedAmount != 12800000 {
+		t.Errorf("Expected financed_amount 12800000, got %d", breakdown.FinancedAmount)
+	}
+	if breakdown.UpfrontPayment != 3010000 {
+		t.Errorf("Expected upfront_payment 3010000, got %d", breakdown.UpfrontPayment)
+	}
+}
+
+func TestResolveFinancing_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     int64
+		assetPrice int64
+		components domain.FinancingComponents
+	}{
+		{"NoPrice", 0, 0, domain.FinancingComponents{}},
+		{"AmountAndAssetPrice", 1000000, 1000000, domain.FinancingComponents{}},
+		{"BothDownPayments", 0, 1000000, domain.FinancingComponents{DownPayment: 100000, DownPaymentRate: 0.1}},
+		{"DownPaymentCoversPrice", 0, 1000000, domain.FinancingComponents{DownPayment: 1000000}},
+		{"DownPaymentRateTooHigh", 0, 1000000, domain.FinancingComponents{DownPaymentRate: 1}},
+		{"NegativeCharge", 1000000, 0, domain.FinancingComponents{AdminFee: &domain.FinancingCharge{Amount: -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveFinancing(tt.amount, tt.assetPrice, tt.components)
+			if _, ok := err.(*ValidationError); !ok {
+				t.Errorf("Expected validation error, got %v", err)
+			}
+		})
+	}
+}
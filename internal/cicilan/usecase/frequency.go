@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"btpntest/domain"
+)
+
+var periodsPerYear = map[string]int{
+	domain.FrequencyMonthly:  12,
+	domain.FrequencyBiWeekly: 26,
+	domain.FrequencyWeekly:   52,
+}
+
+func resolveFrequency(frequency string) (string, error) {
+	if frequency == "" {
+		return domain.FrequencyMonthly, nil
+	}
+
+	if _, ok := periodsPerYear[frequency]; !ok {
+		return "", &ValidationError{Message: fmt.Sprintf("unsupported payment frequency: %s", frequency)}
+	}
+	return frequency, nil
+}
+
+func allowsFrequency(tenor domain.Tenor, frequency string) bool {
+	if tenor.PaymentFrequencies == "" {
+		return frequency == domain.FrequencyMonthly
+	}
+
+	for _, allowed := range strings.Split(tenor.PaymentFrequencies, ",") {
+		if strings.TrimSpace(allowed) == frequency {
+			return true
+		}
+	}
+	return false
+}
+
+func installmentCount(tenorMonths int, frequency string) (int, error) {
+	periods := tenorMonths * periodsPerYear[frequency]
+	if periods%12 != 0 {
+		return 0, &ValidationError{
+			Field:   "payment_frequency",
+			Code:    domain.CodeFrequencyNotAllowed,
+			Message: fmt.Sprintf("tenor %d months cannot be split into whole %s installments", tenorMonths, frequency),
+		}
+	}
+	return periods / 12, nil
+}
+
+func dueDate(startDate time.Time, frequency string, number int) time.Time {
+	switch frequency {
+	case domain.FrequencyWeekly:
+		return startDate.AddDate(0, 0, 7*number)
+	case domain.FrequencyBiWeekly:
+		return startDate.AddDate(0, 0, 14*number)
+	default:
+		return addMonths(startDate, number)
+	}
+}
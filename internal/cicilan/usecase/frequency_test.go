@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+func TestInstallmentCount(t *testing.T) {
+	tests := []struct {
+		months    int
+		frequency string
+		expected  int
+	}{
+		{6, domain.FrequencyMonthly, 6},
+		{6, domain.FrequencyBiWeekly, 13},
+		{6, domain.FrequencyWeekly, 26},
+		{12, domain.FrequencyBiWeekly, 26},
+		{36, domain.FrequencyWeekly, 156},
+	}
+
+	for _, tt := range tests {
+		count, err := installmentCount(tt.months, tt.frequency)
+		if err != nil {
+			t.Fatalf("%d months %s: expected no error, got %v", tt.months, tt.frequency, err)
+		}
+		if count != tt.expected {
+			t.Errorf("%d months %s: expected %d installments, got %d", tt.months, tt.frequency, tt.expected, count)
+		}
+	}
+
+	if _, err := installmentCount(7, domain.FrequencyBiWeekly); err == nil {
+		t.Error("Expected validation error for 7 months bi-weekly")
+	}
+}
+
+func TestAllowsFrequency(t *testing.T) {
+	legacy := domain.Tenor{TenorValue: 6}
+	if !allowsFrequency(legacy, domain.FrequencyMonthly) || allowsFrequency(legacy, domain.FrequencyWeekly) {
+		t.Error("Expected tenors without configured frequencies to allow monthly only")
+	}
+
+	group := domain.Tenor{TenorValue: 12, PaymentFrequencies: "monthly, biweekly"}
+	if !allowsFrequency(group, domain.FrequencyBiWeekly) {
+		t.Error("Expected bi-weekly to be allowed")
+	}
+	if allowsFrequency(group, domain.FrequencyWeekly) {
+		t.Error("Expected weekly to be rejected")
+	}
+}
+
+func TestDueDate(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	if got := dueDate(start, domain.FrequencyWeekly, 2).Format(dateLayout); got != "2026-01-19" {
+		t.Errorf("Expected weekly due date 2026-01-19, got %s", got)
+	}
+	if got := dueDate(start, domain.FrequencyBiWeekly, 2).Format(dateLayout); got != "2026-02-02" {
+		t.Errorf("Expected bi-weekly due date 2026-02-02, got %s", got)
+	}
+	if got := dueDate(start, domain.FrequencyMonthly, 2).Format(dateLayout); got != "2026-03-05" {
+		t.Errorf("Expected monthly due date 2026-03-05, got %s", got)
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"fmt"
+	"math/big"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+func resolveGraceMode(periods int, mode string) (string, error) {
+	if periods < 0 {
+		return "", &ValidationError{Message: "grace_periods must not be negative"}
+	}
+
+	switch mode {
+	case "":
+		return domain.GraceMarginOnly, nil
+	case domain.GraceMarginOnly, domain.GraceCapitalized:
+		return mode, nil
+	default:
+		return "", &ValidationError{Message: fmt.Sprintf("unsupported grace mode: %s", mode)}
+	}
+}
+
+func buildWithGrace(method cicilan.CalculationMethod, input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	if input.GracePeriods == 0 {
+		return method.BuildSchedule(input)
+	}
+
+	balance := input.Principal
+
+	rows := make([]domain.InstallmentScheduleRow, 0, input.Installments)
+	for i := 1; i <= input.GracePeriods; i++ {
+		periodRate := new(big.Rat).Quo(ratFromFloat(rateForPeriod(input, input.PeriodOffset+i)), big.NewRat(int64(input.PeriodsPerYear), 1))
+		margin := roundRat(new(big.Rat).Mul(ratFromInt(balance), periodRate), rupiah)
+		row := domain.InstallmentScheduleRow{
+			InstallmentNumber: i,
+			Margin:            margin,
+			Grace:             true,
+		}
+
+		if input.GraceMode == domain.GraceCapitalized {
+			balance += margin
+			row.Principal = -margin
+			row.CapitalizedMargin = margin
+		} else {
+			row.Installment = margin
+		}
+
+		row.RemainingBalance = balance
+		rows = append(rows, row)
+	}
+
+	regular := input
+	regular.Principal = balance
+	regular.Installments -= input.GracePeriods
+	regular.GracePeriods = 0
+	regular.PeriodOffset += input.GracePeriods
+	if input.Weights != nil {
+		regular.Weights = input.Weights[input.GracePeriods:]
+	}
+
+	tail, err := method.BuildSchedule(regular)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range tail {
+		row.InstallmentNumber += input.GracePeriods
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"testing"
+
+	"btpntest/domain"
+)
+
+func TestBuildWithGrace_MarginOnly(t *testing.T) {
+	rows, err := buildWithGrace(NewFlatMethod(), domain.PricingInput{
+		Principal: 12000000, Installments: 12, PeriodsPerYear: 12, AnnualMarginRate: 0.2, Rounding: rupiah,
+		GracePeriods: 3, GraceMode: domain.GraceMarginOnly,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(rows) != 12 {
+		t.Fatalf("Expected 12 rows, got %d", len(rows))
+	}
+
+	for _, row := range rows[:3] {
+		if !row.Grace || row.Principal != 0 || row.Installment != 200000 || row.RemainingBalance != 12000000 {
+			t.Errorf("Row %d: expected margin-only grace installment 200000, got %+v", row.InstallmentNumber, row)
+		}
+	}
+
+	if rows[3].InstallmentNumber != 4 || rows[3].Grace {
+		t.Errorf("Expected regular installments to continue at number 4, got %+v", rows[3])
+	}
+
+	calc := summarize(12, domain.MethodFlat, 0.2, rows)
+	if calc.TotalMargin != 2400000 {
+		t.Errorf("Expected total_margin 2400000, got %d", calc.TotalMargin)
+	}
+	if calc.MonthlyInstallment != 1533333 {
+		t.Errorf("Expected regular installment 1533333, got %d", calc.MonthlyInstallment)
+	}
+	assertReconciles(t, 12000000, rows, calc)
+}
+
+func TestBuildWithGrace_Capitalized(t *testing.T) {
+	for _, method := range []string{domain.MethodFlat, domain.MethodEffective} {
+		rows, err := buildWithGrace(defaultMethods()[method], domain.PricingInput{
+			Principal: 12000000, Installments: 12, PeriodsPerYear: 12, AnnualMarginRate: 0.2, Rounding: rupiah,
+			GracePeriods: 3, GraceMode: domain.GraceCapitalized,
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", method, err)
+		}
+
+		for _, row := range rows[:3] {
+			if row.Installment != 0 || row.CapitalizedMargin == 0 || row.Principal != -row.CapitalizedMargin {
+				t.Errorf("%s row %d: expected unpaid capitalised grace row, got %+v", method, row.InstallmentNumber, row)
+			}
+		}
+
+		if rows[2].RemainingBalance != 12610055 {
+			t.Errorf("%s: expected capitalised balance 12610055, got %d", method, rows[2].RemainingBalance)
+		}
+
+		calc := summarize(12, method, 0.2, rows)
+		assertReconciles(t, 12000000, rows, calc)
+	}
+}
+
+func TestResolveGraceMode(t *testing.T) {
+	if mode, err := resolveGraceMode(2, ""); err != nil || mode != domain.GraceMarginOnly {
+		t.Errorf("Expected default margin_only, got %s (%v)", mode, err)
+	}
+	if _, err := resolveGraceMode(-1, ""); err == nil {
+		t.Error("Expected validation error for negative grace periods")
+	}
+	if _, err := resolveGraceMode(2, "holiday"); err == nil {
+		t.Error("Expected validation error for unknown grace mode")
+	}
+}
+
+func assertReconciles(t *testing.T, principal int64, rows []domain.InstallmentScheduleRow, calc domain.InstallmentCalculation) {
+	t.Helper()
+
+	var principalSum, marginSum, paymentSum int64
+	for _, row := range rows {
+		principalSum += row.Principal
+		marginSum += row.Margin
+		paymentSum += row.Installment
+	}
+
+	if principalSum != principal {
+		t.Errorf("Expected principal sum %d, got %d", principal, principalSum)
+	}
+	if marginSum != calc.TotalMargin || paymentSum != calc.TotalPayment {
+		t.Errorf("Expected sums to match totals, got margin %d/%d and payment %d/%d", marginSum, calc.TotalMargin, paymentSum, calc.TotalPayment)
+	}
+	if calc.TotalPayment != principal+calc.TotalMargin {
+		t.Errorf("Expected total_payment %d to equal principal plus margin %d", calc.TotalPayment, principal+calc.TotalMargin)
+	}
+	if rows[len(rows)-1].RemainingBalance != 0 {
+		t.Errorf("Expected zero closing balance, got %d", rows[len(rows)-1].RemainingBalance)
+	}
+}
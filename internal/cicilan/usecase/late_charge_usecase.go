@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+type lateChargeUsecase struct {
+	repo cicilan.LateChargeRepository
+}
+
+func NewLateChargeUsecase(repo cicilan.LateChargeRepository) cicilan.LateChargeUsecase {
+	return &lateChargeUsecase{repo: repo}
+}
+
+// CalculateLateCharges applies every rule of the requested rule set to each
+// installment that was paid, or is still unpaid, after its due date.
+func (u *lateChargeUsecase) CalculateLateCharges(req *domain.CalculateLateChargeRequest) (*domain.CalculateLateChargeResponse, error) {
+	if len(req.Installments) == 0 {
+		return nil, &ValidationError{Message: "installments must not be empty"}
+	}
+
+	ruleSet := req.RuleSet
+	if ruleSet == "" {
+		ruleSet = domain.DefaultLateChargeRuleSet
+	}
+
+	calculationDate, err := parseDate(req.CalculationDate, "calculation_date", today())
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := u.repo.GetLateChargeRules(ruleSet)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, &ValidationError{Message: fmt.Sprintf("late charge rule set %s is not available", ruleSet)}
+	}
+
+	response := &domain.CalculateLateChargeResponse{
+		RuleSet:         ruleSet,
+		CalculationDate: calculationDate.Format(dateLayout),
+		Charges:         []domain.LateCharge{},
+	}
+
+	for _, installment := range req.Installments {
+		daysLate, err := daysOverdue(installment, calculationDate)
+		if err != nil {
+			return nil, err
+		}
+		if daysLate <= 0 {
+			continue
+		}
+
+		for _, rule := range rules {
+			charge, err := applyLateChargeRule(rule, installment, daysLate)
+			if err != nil {
+				return nil, err
+			}
+			if charge.Amount == 0 {
+				continue
+			}
+
+			response.Charges = append(response.Charges, charge)
+			if charge.ChargeType == domain.LateChargeTawidh {
+				response.TotalTawidh += charge.Amount
+			} else {
+				response.TotalTazir += charge.Amount
+			}
+		}
+	}
+	response.TotalCharges = response.TotalTawidh + response.TotalTazir
+
+	return response, nil
+}
+
+// daysOverdue counts the days from the due date until the installment was
+// paid, or until the calculation date when it is still outstanding.
+func daysOverdue(installment domain.LateChargeInstallment, calculationDate time.Time) (int, error) {
+	if installment.Installment <= 0 {
+		return 0, &ValidationError{Message: "installment must be greater than 0"}
+	}
+
+	due, err := parseDate(installment.DueDate, "due_date", time.Time{})
+	if err != nil {
+		return 0, err
+	}
+	if due.IsZero() {
+		return 0, &ValidationError{Message: "due_date is required"}
+	}
+
+	settled, err := parseDate(installment.PaidDate, "paid_date", calculationDate)
+	if err != nil {
+		return 0, err
+	}
+	if settled.After(calculationDate) {
+		settled = calculationDate
+	}
+
+	return daysBetween(due, settled), nil
+}
+
+func applyLateChargeRule(rule domain.LateChargeRule, installment domain.LateChargeInstallment, daysLate int) (domain.LateCharge, error) {
+	charge := domain.LateCharge{
+		InstallmentNumber: installment.InstallmentNumber,
+		DueDate:           installment.DueDate,
+		DaysLate:          daysLate,
+		ChargeType:        rule.ChargeType,
+	}
+
+	if rule.ChargeType != domain.LateChargeTawidh && rule.ChargeType != domain.LateChargeTazir {
+		return charge, fmt.Errorf("late charge rule %d has unsupported charge type %q", rule.ID, rule.ChargeType)
+	}
+
+	chargeableDays := daysLate - rule.GraceDays
+	if chargeableDays <= 0 {
+		return charge, nil
+	}
+
+	charge.UncappedAmount = rule.FlatAmount
+	if rule.DailyRate != nil {
+		daily := new(big.Rat).Mul(ratFromInt(installment.Installment), ratFromFloat(*rule.DailyRate))
+		charge.UncappedAmount += roundRat(daily.Mul(daily, ratFromInt(int64(chargeableDays))), rupiah)
+	}
+
+	charge.Amount = charge.UncappedAmount
+	if limit, ok := lateChargeCap(rule, installment.Installment); ok && charge.Amount > limit {
+		charge.Amount = limit
+		charge.Capped = true
+	}
+
+	return charge, nil
+}
+
+// lateChargeCap is the tighter of the rule's fixed cap and its cap as a
+// share of the overdue installment; a zero or missing value means no cap.
+func lateChargeCap(rule domain.LateChargeRule, installment int64) (int64, bool) {
+	limit, capped := rule.CapAmount, rule.CapAmount > 0
+
+	if rule.CapRate != nil {
+		rateCap := roundRat(new(big.Rat).Mul(ratFromInt(installment), ratFromFloat(*rule.CapRate)), rupiah)
+		if !capped || rateCap < limit {
+			limit, capped = rateCap, true
+		}
+	}
+
+	return limit, capped
+}
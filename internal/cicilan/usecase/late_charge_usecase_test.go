@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"btpntest/domain"
+)
+
+type MockLateChargeRepository struct {
+	rules []domain.LateChargeRule
+	err   error
+}
+
+func (m *MockLateChargeRepository) GetLateChargeRules(ruleSet string) ([]domain.LateChargeRule, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var rules []domain.LateChargeRule
+	for _, rule := range m.rules {
+		if rule.RuleSet == ruleSet {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func defaultLateChargeRules() []domain.LateChargeRule {
+	dailyRate, capRate := 0.001, 0.05
+	return []domain.LateChargeRule{
+		{ID: 1, RuleSet: "default", ChargeType: domain.LateChargeTawidh, FlatAmount: 25000, CapAmount: 25000},
+		{ID: 2, RuleSet: "default", ChargeType: domain.LateChargeTazir, GraceDays: 3, DailyRate: &dailyRate, CapRate: &capRate},
+	}
+}
+
+func TestCalculateLateCharges_ClassifiesAndCaps(t *testing.T) {
+	usecase := NewLateChargeUsecase(&MockLateChargeRepository{rules: defaultLateChargeRules()})
+
+	resp, err := usecase.CalculateLateCharges(&domain.CalculateLateChargeRequest{
+		CalculationDate: "2026-05-14",
+		Installments: []domain.LateChargeInstallment{
+			{InstallmentNumber: 1, DueDate: "2026-02-15", Installment: 1200000, PaidDate: "2026-02-20"},
+			{InstallmentNumber: 2, DueDate: "2026-03-15", Installment: 1200000},
+			{InstallmentNumber: 3, DueDate: "2026-04-15", Installment: 1200000, PaidDate: "2026-04-17"},
+			{InstallmentNumber: 4, DueDate: "2026-05-15", Installment: 1200000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []domain.LateCharge{
+		{InstallmentNumber: 1, DueDate: "2026-02-15", DaysLate: 5, ChargeType: domain.LateChargeTawidh, UncappedAmount: 25000, Amount: 25000},
+		{InstallmentNumber: 1, DueDate: "2026-02-15", DaysLate: 5, ChargeType: domain.LateChargeTazir, UncappedAmount: 2400, Amount: 2400},
+		{InstallmentNumber: 2, DueDate: "2026-03-15", DaysLate: 60, ChargeType: domain.LateChargeTawidh, UncappedAmount: 25000, Amount: 25000},
+		{InstallmentNumber: 2, DueDate: "2026-03-15", DaysLate: 60, ChargeType: domain.LateChargeTazir, UncappedAmount: 68400, Amount: 60000, Capped: true},
+		{InstallmentNumber: 3, DueDate: "2026-04-15", DaysLate: 2, ChargeType: domain.LateChargeTawidh, UncappedAmount: 25000, Amount: 25000},
+	}
+
+	if len(resp.Charges) != len(expected) {
+		t.Fatalf("Expected %d charges, got %d: %+v", len(expected), len(resp.Charges), resp.Charges)
+	}
+	for i, charge := range resp.Charges {
+		if charge != expected[i] {
+			t.Errorf("Charge %d: expected %+v, got %+v", i, expected[i], charge)
+		}
+	}
+
+	if resp.RuleSet != "default" {
+		t.Errorf("Expected default rule set, got %s", resp.RuleSet)
+	}
+	if resp.TotalTawidh != 75000 || resp.TotalTazir != 62400 || resp.TotalCharges != 137400 {
+		t.Errorf("Expected totals 75000/62400/137400, got %d/%d/%d", resp.TotalTawidh, resp.TotalTazir, resp.TotalCharges)
+	}
+}
+
+func TestCalculateLateCharges_UnknownRuleSet(t *testing.T) {
+	usecase := NewLateChargeUsecase(&MockLateChargeRepository{rules: defaultLateChargeRules()})
+
+	_, err := usecase.CalculateLateCharges(&domain.CalculateLateChargeRequest{
+		RuleSet:      "premium",
+		Installments: []domain.LateChargeInstallment{{InstallmentNumber: 1, DueDate: "2026-02-15", Installment: 1200000}},
+	})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+}
+
+func TestCalculateLateCharges_RepositoryError(t *testing.T) {
+	usecase := NewLateChargeUsecase(&MockLateChargeRepository{err: errors.New("connection refused")})
+
+	_, err := usecase.CalculateLateCharges(&domain.CalculateLateChargeRequest{
+		Installments: []domain.LateChargeInstallment{{InstallmentNumber: 1, DueDate: "2026-02-15", Installment: 1200000}},
+	})
+	if err == nil {
+		t.Fatal("Expected repository error, got nil")
+	}
+	if _, ok := err.(*ValidationError); ok {
+		t.Fatalf("Expected repository error to pass through, got validation error %v", err)
+	}
+}
+
+func TestCalculateLateCharges_InvalidDates(t *testing.T) {
+	usecase := NewLateChargeUsecase(&MockLateChargeRepository{rules: defaultLateChargeRules()})
+
+	invalid := []domain.CalculateLateChargeRequest{
+		{CalculationDate: "14/05/2026", Installments: []domain.LateChargeInstallment{{InstallmentNumber: 1, DueDate: "2026-02-15", Installment: 1}}},
+		{Installments: []domain.LateChargeInstallment{{InstallmentNumber: 1, DueDate: "15-02-2026", Installment: 1}}},
+		{Installments: []domain.LateChargeInstallment{{InstallmentNumber: 1, DueDate: "2026-02-15", Installment: 1, PaidDate: "tomorrow"}}},
+	}
+
+	for i, req := range invalid {
+		if _, err := usecase.CalculateLateCharges(&req); err == nil {
+			t.Errorf("Request %d: expected validation error, got nil", i)
+		}
+	}
+}
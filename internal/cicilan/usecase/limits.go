@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"fmt"
+
+	"btpntest/domain"
+)
+
+// Service-wide plafond used for products without limits of their own. The
+// maximum also keeps amounts far from the range where int64 money overflows.
+const (
+	DefaultMinAmount int64 = 1000000
+	DefaultMaxAmount int64 = 10000000000
+)
+
+// WithAmountLimits sets the service-wide minimum and maximum financed amount;
+// a maximum of 0 leaves amounts unbounded.
+func WithAmountLimits(minAmount, maxAmount int64) Option {
+	return func(u *cicilanUsecase) {
+		u.minAmount = minAmount
+		u.maxAmount = maxAmount
+	}
+}
+
+// plafond is the financed amount range of the product, falling back to the
+// service-wide limits for a missing product or limit.
+func (u *cicilanUsecase) plafond(product *domain.Product) (int64, int64) {
+	minAmount, maxAmount := u.minAmount, u.maxAmount
+	if product != nil && product.MinAmount != nil {
+		minAmount = *product.MinAmount
+	}
+	if product != nil && product.MaxAmount != nil {
+		maxAmount = *product.MaxAmount
+	}
+	return minAmount, maxAmount
+}
+
+// tenorLimits narrows the product's plafond to the amount band of the tenor.
+func (u *cicilanUsecase) tenorLimits(product *domain.Product, tenor domain.Tenor) (int64, int64) {
+	minAmount, maxAmount := u.plafond(product)
+	if tenor.MinAmount != nil && *tenor.MinAmount > minAmount {
+		minAmount = *tenor.MinAmount
+	}
+	if tenor.MaxAmount != nil && (maxAmount == 0 || *tenor.MaxAmount < maxAmount) {
+		maxAmount = *tenor.MaxAmount
+	}
+	return minAmount, maxAmount
+}
+
+func (u *cicilanUsecase) checkPlafond(product *domain.Product, principal int64) error {
+	minAmount, maxAmount := u.plafond(product)
+
+	scope := "financing"
+	if product != nil {
+		scope = fmt.Sprintf("financing for product %s", product.Code)
+	}
+
+	if principal < minAmount {
+		return &ValidationError{
+			Field:   "amount",
+			Code:    domain.CodeAmountBelowMinimum,
+			Message: fmt.Sprintf("financed amount %d is below the minimum %s of %d", principal, scope, minAmount),
+		}
+	}
+	if maxAmount > 0 && principal > maxAmount {
+		return &ValidationError{
+			Field:   "amount",
+			Code:    domain.CodeAmountAboveMaximum,
+			Message: fmt.Sprintf("financed amount %d exceeds the maximum %s of %d", principal, scope, maxAmount),
+		}
+	}
+	return nil
+}
+
+// checkTenorBand rejects a principal outside the amount band of the tenor.
+func checkTenorBand(tenor domain.Tenor, principal int64) error {
+	if tenor.MinAmount != nil && principal < *tenor.MinAmount {
+		return &ValidationError{
+			Field:   "tenor",
+			Code:    domain.CodeAmountBelowMinimum,
+			Message: fmt.Sprintf("tenor %d requires a financed amount of at least %d", tenor.TenorValue, *tenor.MinAmount),
+		}
+	}
+	if tenor.MaxAmount != nil && principal > *tenor.MaxAmount {
+		return &ValidationError{
+			Field:   "tenor",
+			Code:    domain.CodeAmountAboveMaximum,
+			Message: fmt.Sprintf("tenor %d is offered up to a financed amount of %d", tenor.TenorValue, *tenor.MaxAmount),
+		}
+	}
+	return nil
+}
+
+// excludeTenor records why a tenor was left out of a quote. Errors other than
+// validation errors are not exclusions and are returned to the caller.
+func excludeTenor(excluded []domain.ExcludedTenor, tenor domain.Tenor, err error) ([]domain.ExcludedTenor, error) {
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		return excluded, err
+	}
+	return append(excluded, domain.ExcludedTenor{
+		Tenor:  tenor.TenorValue,
+		Code:   validationErr.Code,
+		Reason: validationErr.Message,
+	}), nil
+}
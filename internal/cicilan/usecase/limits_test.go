@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"testing"
+
+	"btpntest/domain"
+)
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func limitedUsecase() *cicilanUsecase {
+	mockRepo := &MockCicilanRepository{
+		tenors: []domain.Tenor{
+			{ID: 1, TenorValue: 6, PaymentFrequencies: "monthly,weekly"},
+			{ID: 2, TenorValue: 12, PaymentFrequencies: "monthly,weekly"},
+			{ID: 3, TenorValue: 24, PaymentFrequencies: "monthly", MinAmount: int64Ptr(3000000)},
+		},
+		products: []domain.Product{
+			{ID: 1, Code: "qardh", ContractType: domain.ContractQardh, MaxAmount: int64Ptr(10000000)},
+		},
+	}
+	return NewCicilanUsecase(mockRepo).(*cicilanUsecase)
+}
+
+func TestCalculateInstallments_Plafond(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int64
+		product string
+		code    string
+	}{
+		{"BelowDefaultMinimum", 1, "", domain.CodeAmountBelowMinimum},
+		{"AboveDefaultMaximum", 10000000000000, "", domain.CodeAmountAboveMaximum},
+		{"AboveProductMaximum", 12000000, "qardh", domain.CodeAmountAboveMaximum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := limitedUsecase().CalculateInstallments(&domain.CalculateInstallmentRequest{
+				Amount:         tt.amount,
+				PricingOptions: domain.PricingOptions{ProductCode: tt.product},
+			})
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected validation error, got %v", err)
+			}
+			if validationErr.Code != tt.code || validationErr.Field != "amount" {
+				t.Errorf("Expected %s on amount, got %s on %s", tt.code, validationErr.Code, validationErr.Field)
+			}
+		})
+	}
+}
+
+func TestCalculateInstallments_ExcludedTenors(t *testing.T) {
+	usecase := limitedUsecase()
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 2000000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Calculations) != 2 || resp.Calculations[1].Tenor != 12 {
+		t.Fatalf("Expected tenors 6 and 12 to be offered, got %+v", resp.Calculations)
+	}
+	if len(resp.ExcludedTenors) != 1 {
+		t.Fatalf("Expected one excluded tenor, got %+v", resp.ExcludedTenors)
+	}
+	excluded := resp.ExcludedTenors[0]
+	if excluded.Tenor != 24 || excluded.Code != domain.CodeAmountBelowMinimum || excluded.Reason == "" {
+		t.Errorf("Expected tenor 24 excluded below its minimum, got %+v", excluded)
+	}
+
+	resp, err = usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:         5000000,
+		PricingOptions: domain.PricingOptions{PaymentFrequency: domain.FrequencyWeekly},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Calculations) != 2 || len(resp.ExcludedTenors) != 1 || resp.ExcludedTenors[0].Code != domain.CodeFrequencyNotAllowed {
+		t.Errorf("Expected tenor 24 excluded for weekly payments, got %+v", resp.ExcludedTenors)
+	}
+}
+
+func TestCalculateSchedule_TenorBand(t *testing.T) {
+	_, err := limitedUsecase().CalculateSchedule(&domain.CalculateScheduleRequest{Amount: 2000000, Tenor: 24})
+
+	validationErr, ok := err.(*ValidationError)
+	if !ok || validationErr.Code != domain.CodeAmountBelowMinimum || validationErr.Field != "tenor" {
+		t.Fatalf("Expected tenor band validation error, got %v", err)
+	}
+}
+
+func TestCalculateMaxFinancing_Limits(t *testing.T) {
+	usecase := limitedUsecase()
+
+	resp, err := usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{
+		TargetInstallment: 5000000,
+		PricingOptions:    domain.PricingOptions{ProductCode: "qardh"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, calc := range resp.Calculations {
+		if calc.MaxAmount != 10000000 {
+			t.Errorf("Tenor %d: expected max amount capped at the 10000000 plafond, got %d", calc.Tenor, calc.MaxAmount)
+		}
+	}
+
+	resp, err = usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{TargetInstallment: 100000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Calculations) != 1 || resp.Calculations[0].Tenor != 12 {
+		t.Errorf("Expected only tenor 12 to reach the minimum amount, got %+v", resp.Calculations)
+	}
+	if len(resp.ExcludedTenors) != 2 {
+		t.Fatalf("Expected tenors 6 and 24 to be excluded, got %+v", resp.ExcludedTenors)
+	}
+	for _, excluded := range resp.ExcludedTenors {
+		if excluded.Code != domain.CodeAmountBelowMinimum {
+			t.Errorf("Tenor %d: expected %s, got %s", excluded.Tenor, domain.CodeAmountBelowMinimum, excluded.Code)
+		}
+	}
+}
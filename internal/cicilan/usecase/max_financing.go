@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"btpntest/domain"
+)
+
+func (u *cicilanUsecase) CalculateMaxFinancing(req *domain.MaxFinancingRequest) (*domain.MaxFinancingResponse, error) {
+	if req.TargetInstallment <= 0 {
+		return nil, &ValidationError{Message: "target_installment must be greater than 0"}
+	}
+
+	terms, err := u.resolveTerms(req.PricingOptions, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	tenors, err := u.tenorsOn(terms, today())
+	if err != nil {
+		return nil, err
+	}
+
+	tenors, excluded, err := u.selectTenors(tenors, req.Tenors)
+	if err != nil {
+		return nil, err
+	}
+
+	calculations := make([]domain.MaxFinancingCalculation, 0, len(tenors))
+	accepted := []int{}
+
+	for _, tenor := range tenors {
+		if _, err := terms.installmentsFor(tenor); err != nil {
+			if excluded, err = excludeTenor(excluded, tenor, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		minAmount, maxAmount := u.tenorLimits(terms.product, tenor)
+		calculation, err := maxFinancingForTenor(terms, tenor, req.TargetInstallment, maxAmount)
+		if err != nil {
+			return nil, err
+		}
+		if calculation.MaxAmount < minAmount {
+			excluded = append(excluded, domain.ExcludedTenor{
+				Tenor:  tenor.TenorValue,
+				Code:   domain.CodeAmountBelowMinimum,
+				Reason: fmt.Sprintf("the largest affordable amount %d is below the minimum financed amount of %d", calculation.MaxAmount, minAmount),
+			})
+			continue
+		}
+		calculations = append(calculations, calculation)
+		accepted = append(accepted, tenor.TenorValue)
+	}
+
+	return &domain.MaxFinancingResponse{
+		TargetInstallment: req.TargetInstallment,
+		PricingVersionID:  terms.versionID(),
+		Calculations:      calculations,
+		AcceptedTenors:    accepted,
+		ExcludedTenors:    excluded,
+	}, nil
+}
+
+// maxFinancingForTenor searches for the largest principal up to ceiling whose
+// installments stay within target; a ceiling of 0 means no cap.
+func maxFinancingForTenor(terms pricingTerms, tenor domain.Tenor, target, ceiling int64) (domain.MaxFinancingCalculation, error) {
+	best := domain.MaxFinancingCalculation{
+		InstallmentCalculation: domain.InstallmentCalculation{
+			Tenor:            tenor.TenorValue,
+			Method:           terms.method.Name(),
+			PaymentFrequency: terms.frequency,
+			ContractType:     terms.calculator.ContractType(),
+			ProfitType:       terms.calculator.ProfitType(),
+			AnnualMarginRate: terms.calculator.AnnualRate(tenor, terms.method.Name()),
+		},
+	}
+	if terms.product != nil {
+		best.Product = terms.product.Code
+	}
+
+	count, err := terms.installmentsFor(tenor)
+	if err != nil {
+		return best, err
+	}
+	best.InstallmentCount = count
+	best.GracePeriods = terms.gracePeriods
+
+	// The installments repay at least the principal less its balloon, which
+	// bounds the principal; that bound is clamped where it would overflow.
+	bound := new(big.Rat).Mul(ratFromInt(target), big.NewRat(int64(count), 1))
+	if terms.profile != nil && terms.profile.Type == domain.ProfileBalloon {
+		bound.Quo(bound, new(big.Rat).Sub(big.NewRat(1, 1), ratFromFloat(terms.profile.BalloonRate)))
+	}
+
+	var principal int64
+	low, high := int64(1), int64(math.MaxInt64)
+	if bound.Cmp(ratFromInt(math.MaxInt64)) < 0 {
+		high = new(big.Int).Quo(bound.Num(), bound.Denom()).Int64()
+	}
+	if ceiling > 0 && high > ceiling {
+		high = ceiling
+	}
+
+	for low <= high {
+		candidate := low + (high-low)/2
+
+		_, calculation, err := priceTenor(terms, tenor, candidate)
+		if err != nil {
+			if _, ok := err.(*ValidationError); !ok {
+				return best, err
+			}
+			low = candidate + 1
+			continue
+		}
+
+		if calculation.MonthlyInstallment <= target {
+			principal = candidate
+			low = candidate + 1
+		} else {
+			high = candidate - 1
+		}
+	}
+
+	for principal > 0 {
+		rows, calculation, err := priceTenor(terms, tenor, principal)
+		if err != nil {
+			return best, err
+		}
+
+		largest := largestInstallment(rows)
+		if largest <= target {
+			return domain.MaxFinancingCalculation{MaxAmount: principal, InstallmentCalculation: calculation}, nil
+		}
+
+		// Step back by half the principal the excess stands for, computed
+		// exactly so that large amounts cannot overflow.
+		excess := new(big.Rat).Mul(ratFromInt(largest-target), ratFromInt(principal))
+		excess.Quo(excess, new(big.Rat).Mul(ratFromInt(calculation.TotalPayment), big.NewRat(2, 1)))
+		step := new(big.Int).Quo(excess.Num(), excess.Denom()).Int64()
+		if step < 1 {
+			step = 1
+		}
+		principal -= step
+	}
+
+	return best, nil
+}
+
+// largestInstallment is the largest periodic installment of the schedule. A
+// balloon is a one-off lump sum, not part of the regular installment that
+// carries it.
+func largestInstallment(rows []domain.InstallmentScheduleRow) int64 {
+	var largest int64
+	for _, row := range rows {
+		if regular := row.Installment - row.Balloon; regular > largest {
+			largest = regular
+		}
+	}
+	return largest
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"btpntest/domain"
+)
+
+func TestCalculateMaxFinancing_AgreesWithForwardCalculation(t *testing.T) {
+	mockTenors := []domain.Tenor{
+		{ID: 1, TenorValue: 6},
+		{ID: 2, TenorValue: 12},
+		{ID: 3, TenorValue: 36},
+	}
+	mockRepo := &MockCicilanRepository{tenors: mockTenors}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	for _, method := range []string{domain.MethodFlat, domain.MethodEffective} {
+		options := domain.PricingOptions{Method: method, RoundingMode: domain.RoundingCeiling, RoundingUnit: 100}
+		req := &domain.MaxFinancingRequest{TargetInstallment: 750000, PricingOptions: options}
+		resp, err := usecase.CalculateMaxFinancing(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if len(resp.Calculations) != 3 {
+			t.Fatalf("Expected 3 calculations, got %d", len(resp.Calculations))
+		}
+
+		for _, calc := range resp.Calculations {
+			if calc.MaxAmount <= 0 {
+				t.Fatalf("%s tenor %d: expected positive max amount, got %d", method, calc.Tenor, calc.MaxAmount)
+			}
+
+			forward, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+				Amount: calc.MaxAmount, Tenor: calc.Tenor, PricingOptions: options,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if largestInstallment(forward.Schedule) > 750000 {
+				t.Errorf("%s tenor %d: installment exceeds target at max amount %d", method, calc.Tenor, calc.MaxAmount)
+			}
+
+			above, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+				Amount: calc.MaxAmount + 1, Tenor: calc.Tenor, PricingOptions: options,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if largestInstallment(above.Schedule) <= 750000 {
+				t.Errorf("%s tenor %d: amount %d also fits, max amount is not maximal", method, calc.Tenor, calc.MaxAmount+1)
+			}
+		}
+	}
+}
+
+func TestCalculateMaxFinancing_FlatExample(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	resp, err := usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{TargetInstallment: 1000000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Calculations[0].MaxAmount != 10000000 {
+		t.Errorf("Expected max_amount 10000000, got %d", resp.Calculations[0].MaxAmount)
+	}
+}
+
+func TestCalculateMaxFinancing_LargeTargetStaysWithinCeiling(t *testing.T) {
+	maxAmount := int64(50000000)
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{
+		{ID: 1, TenorValue: 12, MaxAmount: &maxAmount},
+		{ID: 2, TenorValue: 24},
+	}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	// target × installments overflows int64.
+	resp, err := usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{TargetInstallment: math.MaxInt64 / 4})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(resp.Calculations) != 2 {
+		t.Fatalf("Expected both tenors, got %+v excluded %+v", resp.AcceptedTenors, resp.ExcludedTenors)
+	}
+	if resp.Calculations[0].MaxAmount != maxAmount || resp.Calculations[1].MaxAmount != DefaultMaxAmount {
+		t.Errorf("Expected the tenor and service ceilings %d/%d, got %d/%d",
+			maxAmount, DefaultMaxAmount, resp.Calculations[0].MaxAmount, resp.Calculations[1].MaxAmount)
+	}
+}
+
+func TestCalculateMaxFinancing_BalloonProfile(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	resp, err := usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{
+		TargetInstallment: 700000,
+		PricingOptions: domain.PricingOptions{
+			Profile: &domain.InstallmentProfile{Type: domain.ProfileBalloon, BalloonRate: 0.5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 12,000,000 flat at 20% leaves 8,400,000 besides the 6,000,000 balloon:
+	// twelve installments of 700,000.
+	calc := resp.Calculations[0]
+	if calc.MaxAmount < 12000000 || calc.MaxAmount > 12000010 || calc.MonthlyInstallment > 700000 {
+		t.Errorf("Expected max_amount of about 12000000 within 700000 a month, got %d at %d", calc.MaxAmount, calc.MonthlyInstallment)
+	}
+}
+
+func TestCalculateMaxFinancing_PricingHistory(t *testing.T) {
+	liveRate := 0.3
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 2, TenorValue: 12, FlatMarginRate: &liveRate}}}
+	usecase := NewCicilanUsecase(mockRepo, WithPricingHistory(pricingHistory()))
+
+	resp, err := usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{TargetInstallment: 1000000})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Priced with the current version at 24%, not the live 30%.
+	if resp.PricingVersionID != 2 || resp.Calculations[0].AnnualMarginRate != 0.24 {
+		t.Errorf("Expected version 2 at 0.24, got version %d at %v", resp.PricingVersionID, resp.Calculations[0].AnnualMarginRate)
+	}
+}
+
+func TestCalculateMaxFinancing_TenorSelection(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}, {ID: 2, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	resp, err := usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{TargetInstallment: 500000, Tenors: []int{12}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Calculations) != 1 || resp.Calculations[0].Tenor != 12 {
+		t.Errorf("Expected only tenor 12, got %+v", resp.Calculations)
+	}
+
+	_, err = usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{TargetInstallment: 500000, Tenors: []int{9}})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected validation error for unknown tenor, got %v", err)
+	}
+
+	_, err = usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{TargetInstallment: 0})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected validation error for zero target, got %v", err)
+	}
+}
+
+func TestCalculateMaxFinancing_FloorRoundingStaysWithinTarget(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 36}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	resp, err := usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{
+		TargetInstallment: 750000, PricingOptions: domain.PricingOptions{RoundingMode: domain.RoundingFloor, RoundingUnit: 1000},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	calc := resp.Calculations[0]
+	if calc.MonthlyInstallment > 750000 || calc.LastInstallment > 750000 {
+		t.Errorf("Expected installments within target, got regular %d and last %d", calc.MonthlyInstallment, calc.LastInstallment)
+	}
+	if calc.MaxAmount < 16800000 {
+		t.Errorf("Expected max_amount close to the analytical bound, got %d", calc.MaxAmount)
+	}
+}
+
+func TestCalculateMaxFinancing_UncappedLargeTarget(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 36}}}
+	usecase := NewCicilanUsecase(mockRepo, WithAmountLimits(1, 0))
+
+	// Floor rounding leaves the last installment above target, and the excess
+	// times a principal in the hundreds of trillions overflows int64.
+	target := int64(20000000000500)
+	resp, err := usecase.CalculateMaxFinancing(&domain.MaxFinancingRequest{
+		TargetInstallment: target, PricingOptions: domain.PricingOptions{RoundingMode: domain.RoundingFloor, RoundingUnit: 1000},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	calc := resp.Calculations[0]
+	if calc.MonthlyInstallment > target || calc.LastInstallment > target {
+		t.Errorf("Expected installments within target, got regular %d and last %d", calc.MonthlyInstallment, calc.LastInstallment)
+	}
+	if calc.MaxAmount < 449000000000000 {
+		t.Errorf("Expected max_amount close to the analytical bound, got %d", calc.MaxAmount)
+	}
+}
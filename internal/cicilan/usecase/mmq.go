@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+type diminishingMethod struct{}
+
+func NewDiminishingMethod() cicilan.CalculationMethod {
+	return diminishingMethod{}
+}
+
+func (diminishingMethod) Name() string {
+	return domain.MethodDiminishing
+}
+
+// BuildSchedule acquires the asset in units sized by the installment weights,
+// rounded with the request's rounding policy, and charges rent on the balance
+// still owned by the bank at the rate in force for each period.
+func (diminishingMethod) BuildSchedule(input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	count := input.Installments
+
+	weights, weightTotal := weightRats(input)
+	acquisitions := make([]int64, count)
+	var allocated int64
+	for i := 0; i < count-1; i++ {
+		share := new(big.Rat).Mul(ratFromInt(input.Principal-input.Balloon), weights[i])
+		acquisitions[i] = roundRat(share.Quo(share, weightTotal), input.Rounding)
+		if acquisitions[i] <= 0 {
+			return nil, &ValidationError{Message: "rounding_unit is too large for the financed amount"}
+		}
+		allocated += acquisitions[i]
+	}
+	acquisitions[count-1] = input.Principal - allocated
+	if acquisitions[count-1]-input.Balloon <= 0 {
+		return nil, &ValidationError{Message: "rounding_unit is too large for the financed amount"}
+	}
+
+	rows := make([]domain.InstallmentScheduleRow, 0, count)
+	remaining := input.Principal
+
+	for i, share := range acquisitions {
+		annualRate := rateForPeriod(input, input.PeriodOffset+i+1)
+		periodRate := new(big.Rat).Quo(ratFromFloat(annualRate), big.NewRat(int64(input.PeriodsPerYear), 1))
+		rent := roundRat(new(big.Rat).Mul(ratFromInt(remaining), periodRate), rupiah)
+
+		remaining -= share
+
+		rows = append(rows, domain.InstallmentScheduleRow{
+			InstallmentNumber: i + 1,
+			Principal:         share,
+			Margin:            rent,
+			Installment:       share + rent,
+			RemainingBalance:  remaining,
+			RentRate:          annualRate,
+		})
+	}
+	rows[count-1].Balloon = input.Balloon
+
+	return rows, nil
+}
+
+// rateForPeriod is the annual rate in force for a 1-based period: the base
+// rate until the first review, then the rate of the latest review whose month
+// has started.
+func rateForPeriod(input domain.PricingInput, period int) float64 {
+	month := (period*12 + input.PeriodsPerYear - 1) / input.PeriodsPerYear
+
+	rate := input.AnnualMarginRate
+	for _, review := range input.RateReviews {
+		if month < review.FromMonth {
+			break
+		}
+		rate = review.AnnualRate
+	}
+	return rate
+}
+
+func validateRateReviews(calculator cicilan.ProductCalculator, reviews []domain.RateReview) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	if !calculator.SupportsRateReviews() {
+		return &ValidationError{Message: fmt.Sprintf("rate_reviews are not supported for %s contracts", calculator.ContractType())}
+	}
+
+	previous := 1
+	for _, review := range reviews {
+		if review.FromMonth <= previous {
+			return &ValidationError{Message: "rate_reviews must start after month 1 and be in ascending from_month order"}
+		}
+		if review.AnnualRate < 0 || review.AnnualRate >= 1 {
+			return &ValidationError{Message: "rate_reviews annual_rate must be between 0 and 1"}
+		}
+		previous = review.FromMonth
+	}
+	return nil
+}
+
+// describePartnership adds the ownership trajectory to an MMQ schedule and
+// summarises the partnership. The asset belongs to the customer for the down
+// payment and to the bank for the financed amount.
+func describePartnership(calculation *domain.InstallmentCalculation, rows []domain.InstallmentScheduleRow, financing domain.FinancingBreakdown) {
+	if calculation.ContractType != domain.ContractMMQ {
+		return
+	}
+
+	assetValue := financing.DownPayment + financing.FinancedAmount
+	summary := &domain.PartnershipSummary{
+		AssetValue:           assetValue,
+		CustomerContribution: financing.DownPayment,
+		InitialBankShare:     ownershipFraction(financing.FinancedAmount, assetValue),
+	}
+
+	for i := range rows {
+		bankShare := ownershipFraction(rows[i].RemainingBalance, assetValue)
+		rows[i].Ownership = &domain.Ownership{
+			BankShare:     bankShare,
+			CustomerShare: math.Round((1-bankShare)*1e6) / 1e6,
+		}
+		summary.TotalAcquisition += rows[i].Principal
+		summary.TotalRent += rows[i].Margin
+	}
+
+	summary.TotalCost = summary.CustomerContribution + summary.TotalAcquisition + summary.TotalRent + calculation.Fee
+	calculation.Partnership = summary
+}
+
+func ownershipFraction(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	fraction, _ := new(big.Rat).SetFrac64(part, whole).Float64()
+	return math.Round(fraction*1e6) / 1e6
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+func mmqUsecase() *cicilanUsecase {
+	mockRepo := &MockCicilanRepository{
+		tenors:   []domain.Tenor{{ID: 1, TenorValue: 24}},
+		products: []domain.Product{{ID: 1, Code: "mmq", ContractType: domain.ContractMMQ}},
+	}
+	return NewCicilanUsecase(mockRepo).(*cicilanUsecase)
+}
+
+func TestCalculateSchedule_MMQOwnershipAndRepricing(t *testing.T) {
+	resp, err := mmqUsecase().CalculateSchedule(&domain.CalculateScheduleRequest{
+		AssetPrice:          15000000,
+		Tenor:               24,
+		FinancingComponents: domain.FinancingComponents{DownPayment: 3000000},
+		PricingOptions: domain.PricingOptions{
+			ProductCode: "mmq",
+			RateReviews: []domain.RateReview{{FromMonth: 13, AnnualRate: 0.24}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	checks := []struct {
+		index     int
+		rent      int64
+		rate      float64
+		bankShare float64
+	}{
+		{0, 200000, 0.2, 0.766667},
+		{11, 108333, 0.2, 0.4},
+		{12, 120000, 0.24, 0.366667},
+		{23, 10000, 0.24, 0},
+	}
+	for _, check := range checks {
+		row := resp.Schedule[check.index]
+		if row.Principal != 500000 || row.Margin != check.rent || row.RentRate != check.rate {
+			t.Errorf("Installment %d: expected acquisition 500000 and rent %d at %v, got %d/%d at %v",
+				row.InstallmentNumber, check.rent, check.rate, row.Principal, row.Margin, row.RentRate)
+		}
+		if row.Ownership == nil || row.Ownership.BankShare != check.bankShare {
+			t.Errorf("Installment %d: expected bank share %v, got %+v", row.InstallmentNumber, check.bankShare, row.Ownership)
+		}
+	}
+
+	last := resp.Schedule[23].Ownership
+	if last.CustomerShare != 1 {
+		t.Errorf("Expected the customer to own the asset outright, got %v", last.CustomerShare)
+	}
+
+	expected := domain.PartnershipSummary{
+		AssetValue:           15000000,
+		CustomerContribution: 3000000,
+		InitialBankShare:     0.8,
+		TotalAcquisition:     12000000,
+		TotalRent:            2630000,
+		TotalCost:            17630000,
+	}
+	if resp.Partnership == nil || *resp.Partnership != expected {
+		t.Errorf("Expected partnership %+v, got %+v", expected, resp.Partnership)
+	}
+}
+
+func TestCalculateInstallments_MMQReportsPartnership(t *testing.T) {
+	resp, err := mmqUsecase().CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:         12000000,
+		PricingOptions: domain.PricingOptions{ProductCode: "mmq"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	partnership := resp.Calculations[0].Partnership
+	if partnership == nil || partnership.InitialBankShare != 1 || partnership.TotalCost != 14500000 {
+		t.Errorf("Expected full bank funding with total cost 14500000, got %+v", partnership)
+	}
+}
+
+func TestRateForPeriod_MapsPeriodsToMonths(t *testing.T) {
+	input := domain.PricingInput{
+		AnnualMarginRate: 0.1,
+		PeriodsPerYear:   52,
+		RateReviews:      []domain.RateReview{{FromMonth: 13, AnnualRate: 0.12}, {FromMonth: 25, AnnualRate: 0.15}},
+	}
+
+	// Week 52 ends month 12, week 53 falls in month 13.
+	for period, expected := range map[int]float64{1: 0.1, 52: 0.1, 53: 0.12, 104: 0.12, 105: 0.15} {
+		if rate := rateForPeriod(input, period); rate != expected {
+			t.Errorf("Period %d: expected rate %v, got %v", period, expected, rate)
+		}
+	}
+}
+
+func TestResolveTerms_RateReviewValidation(t *testing.T) {
+	usecase := productUsecase()
+
+	invalid := []domain.PricingOptions{
+		{RateReviews: []domain.RateReview{{FromMonth: 13, AnnualRate: 0.24}}},
+		{ProductCode: "mmq", RateReviews: []domain.RateReview{{FromMonth: 1, AnnualRate: 0.24}}},
+		{ProductCode: "mmq", RateReviews: []domain.RateReview{{FromMonth: 25, AnnualRate: 0.24}, {FromMonth: 13, AnnualRate: 0.22}}},
+		{ProductCode: "mmq", RateReviews: []domain.RateReview{{FromMonth: 13, AnnualRate: 1.5}}},
+	}
+
+	for _, options := range invalid {
+		if _, err := usecase.resolveTerms(options, time.Now()); err == nil {
+			t.Errorf("%+v: expected validation error, got nil", options)
+		} else if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("%+v: expected validation error, got %v", options, err)
+		}
+	}
+}
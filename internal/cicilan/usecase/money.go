@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"btpntest/domain"
+)
+
+var rupiah = domain.RoundingPolicy{Mode: domain.RoundingHalfUp, Unit: 1}
+
+func newRoundingPolicy(mode string, unit int64) (domain.RoundingPolicy, error) {
+	if mode == "" {
+		mode = domain.RoundingHalfUp
+	}
+
+	switch mode {
+	case domain.RoundingHalfUp, domain.RoundingHalfEven, domain.RoundingFloor, domain.RoundingCeiling:
+	default:
+		return domain.RoundingPolicy{}, &ValidationError{Message: fmt.Sprintf("unsupported rounding mode: %s", mode)}
+	}
+
+	if unit < 0 {
+		return domain.RoundingPolicy{}, &ValidationError{Message: "rounding_unit must not be negative"}
+	}
+	if unit == 0 {
+		unit = 1
+	}
+
+	return domain.RoundingPolicy{Mode: mode, Unit: unit}, nil
+}
+
+func ratFromInt(value int64) *big.Rat {
+	return new(big.Rat).SetInt64(value)
+}
+
+func ratFromFloat(value float64) *big.Rat {
+	rat, _ := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	return rat
+}
+
+func roundRat(value *big.Rat, policy domain.RoundingPolicy) int64 {
+	unit := policy.Unit
+	if unit <= 0 {
+		unit = 1
+	}
+
+	scaled := new(big.Rat).Quo(value, ratFromInt(unit))
+	quotient, remainder := new(big.Int).DivMod(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	if remainder.Sign() != 0 {
+		twiceRemainder := new(big.Int).Lsh(remainder, 1)
+		half := twiceRemainder.Cmp(scaled.Denom())
+
+		switch policy.Mode {
+		case domain.RoundingCeiling:
+			quotient.Add(quotient, big.NewInt(1))
+		case domain.RoundingHalfEven:
+			if half > 0 || (half == 0 && quotient.Bit(0) == 1) {
+				quotient.Add(quotient, big.NewInt(1))
+			}
+		case domain.RoundingHalfUp:
+			if half >= 0 {
+				quotient.Add(quotient, big.NewInt(1))
+			}
+		}
+	}
+
+	return quotient.Int64() * unit
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"math/big"
+	"testing"
+
+	"btpntest/domain"
+)
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *big.Rat
+		policy   domain.RoundingPolicy
+		expected int64
+	}{
+		{"HalfUp_Down", big.NewRat(55000001, 30), domain.RoundingPolicy{Mode: domain.RoundingHalfUp, Unit: 1}, 1833333},
+		{"HalfUp_Tie", big.NewRat(5, 2), domain.RoundingPolicy{Mode: domain.RoundingHalfUp, Unit: 1}, 3},
+		{"HalfEven_TieDown", big.NewRat(5, 2), domain.RoundingPolicy{Mode: domain.RoundingHalfEven, Unit: 1}, 2},
+		{"HalfEven_TieUp", big.NewRat(7, 2), domain.RoundingPolicy{Mode: domain.RoundingHalfEven, Unit: 1}, 4},
+		{"Floor", big.NewRat(19, 10), domain.RoundingPolicy{Mode: domain.RoundingFloor, Unit: 1}, 1},
+		{"Ceiling_Hundred", big.NewRat(1833334, 1), domain.RoundingPolicy{Mode: domain.RoundingCeiling, Unit: 100}, 1833400},
+		{"Ceiling_Thousand", big.NewRat(1833334, 1), domain.RoundingPolicy{Mode: domain.RoundingCeiling, Unit: 1000}, 1834000},
+		{"Ceiling_Exact", big.NewRat(1834000, 1), domain.RoundingPolicy{Mode: domain.RoundingCeiling, Unit: 1000}, 1834000},
+		{"HalfUp_Thousand", big.NewRat(1833500, 1), domain.RoundingPolicy{Mode: domain.RoundingHalfUp, Unit: 1000}, 1834000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundRat(tt.value, tt.policy); got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRatFromFloat_IsExactDecimal(t *testing.T) {
+	if ratFromFloat(0.18).Cmp(big.NewRat(18, 100)) != 0 {
+		t.Errorf("Expected 0.18 to convert to exactly 18/100, got %s", ratFromFloat(0.18).String())
+	}
+}
+
+func TestNewRoundingPolicy(t *testing.T) {
+	policy, err := newRoundingPolicy("", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if policy.Mode != domain.RoundingHalfUp || policy.Unit != 1 {
+		t.Errorf("Expected default half_up/1, got %s/%d", policy.Mode, policy.Unit)
+	}
+
+	if _, err := newRoundingPolicy("bankers", 1); err == nil {
+		t.Error("Expected validation error for unknown mode")
+	}
+
+	if _, err := newRoundingPolicy(domain.RoundingCeiling, -100); err == nil {
+		t.Error("Expected validation error for negative unit")
+	}
+}
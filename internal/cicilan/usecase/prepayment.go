@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"btpntest/domain"
+)
+
+// SimulatePrepayment recomputes a contract after a lump-sum partial payment.
+// The original schedule is rebuilt with the same pricing engine and the
+// pricing in effect on the start date, the prepayment reduces the
+// outstanding principal from the next installment on, and the remainder is
+// repriced either over the remaining installments (reduce_installment) or
+// over the fewest installments that do not exceed the current one
+// (reduce_tenor). The running installment's margin saved by the prepayment
+// is still earned up to the prepayment date and is due with it.
+func (u *cicilanUsecase) SimulatePrepayment(req *domain.PrepaymentRequest) (*domain.PrepaymentResponse, error) {
+	if req.Amount <= 0 {
+		return nil, &ValidationError{Message: "amount must be greater than 0"}
+	}
+	if req.Tenor <= 0 {
+		return nil, &ValidationError{Message: "tenor must be greater than 0"}
+	}
+	if req.InstallmentsPaid < 0 {
+		return nil, &ValidationError{Message: "installments_paid must not be negative"}
+	}
+	if req.PrepaymentAmount <= 0 {
+		return nil, &ValidationError{Field: "prepayment_amount", Message: "prepayment_amount must be greater than 0"}
+	}
+	if req.StartDate == "" {
+		return nil, &ValidationError{Message: "start_date is required"}
+	}
+
+	startDate, err := parseDate(req.StartDate, "start_date", time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	prepaymentDate, err := parseDate(req.PrepaymentDate, "prepayment_date", today())
+	if err != nil {
+		return nil, err
+	}
+	if prepaymentDate.Before(startDate) {
+		return nil, &ValidationError{Field: "prepayment_date", Message: "prepayment_date must not be before start_date"}
+	}
+
+	option, err := resolvePrepaymentOption(req.Option)
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := u.resolveTerms(req.PricingOptions, pricingInstant(startDate))
+	if err != nil {
+		return nil, err
+	}
+
+	terms.dueDates, err = u.resolveDueDates(req.DueDateOptions, terms.frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, calculation, err := u.tenorSchedule(terms, req.Tenor, req.Amount, startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	paid := req.InstallmentsPaid
+	if paid >= len(rows) {
+		return nil, &ValidationError{Message: fmt.Sprintf("installments_paid must be less than the %d installments of tenor %d", len(rows), req.Tenor)}
+	}
+	if dueOnOrBefore(rows[paid], prepaymentDate) {
+		return nil, &ValidationError{
+			Field:   "installments_paid",
+			Message: fmt.Sprintf("installment %d is due on %s and must be paid before the prepayment", rows[paid].InstallmentNumber, rows[paid].DueDate),
+		}
+	}
+
+	outstanding := req.Amount
+	if paid > 0 {
+		outstanding = rows[paid-1].RemainingBalance
+	}
+	if req.PrepaymentAmount >= outstanding {
+		return nil, &ValidationError{
+			Field:   "prepayment_amount",
+			Message: fmt.Sprintf("prepayment_amount must be less than the outstanding principal of %d; use early settlement to close the contract", outstanding),
+		}
+	}
+
+	remaining := rows[paid:]
+	principal := outstanding - req.PrepaymentAmount
+	weights := profileWeights(terms.profile, len(rows), terms.frequency, startDate, terms.dueDates)
+	balloon := balloonAmount(terms.profile, req.Amount)
+	if balloon > principal {
+		balloon = principal
+	}
+
+	var brokenPeriod int64
+	for _, row := range remaining {
+		brokenPeriod += row.BrokenPeriodMargin
+	}
+
+	reprice := func(count int) ([]domain.InstallmentScheduleRow, error) {
+		input := domain.PricingInput{
+			Principal:        principal,
+			Installments:     count,
+			PeriodsPerYear:   periodsPerYear[terms.frequency],
+			AnnualMarginRate: calculation.AnnualMarginRate,
+			Rounding:         terms.rounding,
+			GraceMode:        terms.graceMode,
+			RateReviews:      terms.rateReviews,
+			Balloon:          balloon,
+			PeriodOffset:     paid,
+		}
+		if terms.gracePeriods > paid {
+			input.GracePeriods = terms.gracePeriods - paid
+		}
+		if weights != nil {
+			input.Weights = weights[paid : paid+count]
+		}
+		return terms.calculator.BuildSchedule(terms.method, input)
+	}
+
+	revised, err := reprice(len(remaining))
+	if err != nil {
+		return nil, err
+	}
+
+	if option == domain.PrepaymentReduceTenor {
+		current := regularInstallment(remaining)
+		for count := firstRegular(revised) + 1; count < len(remaining); count++ {
+			shorter, err := reprice(count)
+			if err != nil {
+				return nil, err
+			}
+			if regularInstallment(shorter) <= current {
+				revised = shorter
+				break
+			}
+		}
+	}
+
+	// Margin saved in the running period accrues pro rata by days up to the
+	// prepayment date, as in early settlement.
+	var accrued int64
+	if saved := remaining[0].Margin - revised[0].Margin; saved > 0 {
+		previous := startDate
+		if paid > 0 {
+			previous, _ = time.Parse(dateLayout, rows[paid-1].DueDate)
+		}
+		running := remaining[0]
+		running.Margin = saved
+		accrued = accruedMargin(running, previous, prepaymentDate)
+	}
+
+	for i := range revised {
+		revised[i].InstallmentNumber += paid
+	}
+	if brokenPeriod != 0 {
+		bookBrokenPeriod(revised, brokenPeriod, terms.dueDates.brokenPeriod)
+	}
+	terms.dueDates.assign(revised, startDate, terms.frequency)
+
+	original := summarizeRemaining(remaining)
+	revisedTerms := summarizeRemaining(revised)
+
+	return &domain.PrepaymentResponse{
+		Tenor:                calculation.Tenor,
+		PricingVersionID:     terms.versionID(),
+		Method:               calculation.Method,
+		PaymentFrequency:     calculation.PaymentFrequency,
+		Option:               option,
+		InstallmentsPaid:     paid,
+		PrepaymentDate:       prepaymentDate.Format(dateLayout),
+		PrepaymentAmount:     req.PrepaymentAmount,
+		OutstandingPrincipal: outstanding,
+		RemainingPrincipal:   principal,
+		Original:             original,
+		Revised:              revisedTerms,
+		AccruedMargin:        accrued,
+		MarginSaved:          original.RemainingMargin - revisedTerms.RemainingMargin - accrued,
+		Schedule:             revised,
+	}, nil
+}
+
+func resolvePrepaymentOption(option string) (string, error) {
+	switch option {
+	case "":
+		return domain.PrepaymentReduceInstallment, nil
+	case domain.PrepaymentReduceInstallment, domain.PrepaymentReduceTenor:
+		return option, nil
+	default:
+		return "", &ValidationError{Field: "option", Message: fmt.Sprintf("unsupported prepayment option: %s", option)}
+	}
+}
+
+// firstRegular is the number of grace rows at the start of the schedule.
+func firstRegular(rows []domain.InstallmentScheduleRow) int {
+	for i, row := range rows {
+		if !row.Grace {
+			return i
+		}
+	}
+	return len(rows)
+}
+
+func summarizeRemaining(rows []domain.InstallmentScheduleRow) domain.PrepaymentTerms {
+	summary := domain.PrepaymentTerms{
+		RemainingInstallments: len(rows),
+		Installment:           regularInstallment(rows),
+	}
+	if len(rows) > 0 {
+		last := rows[len(rows)-1]
+		summary.LastInstallment = last.Installment
+		summary.MaturityDate = last.DueDate
+	}
+	for _, row := range rows {
+		summary.RemainingMargin += row.Margin
+		summary.RemainingPayment += row.Installment
+	}
+	return summary
+}
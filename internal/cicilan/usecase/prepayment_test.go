@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"testing"
+
+	"btpntest/domain"
+)
+
+func prepaymentRequest(option string) *domain.PrepaymentRequest {
+	return &domain.PrepaymentRequest{
+		Amount:           12000000,
+		Tenor:            12,
+		StartDate:        "2026-01-15",
+		InstallmentsPaid: 6,
+		PrepaymentAmount: 3000000,
+		PrepaymentDate:   "2026-07-20",
+		Option:           option,
+	}
+}
+
+func TestSimulatePrepayment_ReduceInstallment(t *testing.T) {
+	resp, err := settlementUsecase().SimulatePrepayment(prepaymentRequest(""))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Option != domain.PrepaymentReduceInstallment {
+		t.Errorf("Expected the default option %s, got %s", domain.PrepaymentReduceInstallment, resp.Option)
+	}
+	if resp.OutstandingPrincipal != 6000000 || resp.RemainingPrincipal != 3000000 {
+		t.Errorf("Expected 6000000 outstanding and 3000000 remaining, got %d/%d", resp.OutstandingPrincipal, resp.RemainingPrincipal)
+	}
+
+	// Six remaining flat installments of 1,200,000 become six of 550,000.
+	if resp.Original.Installment != 1200000 || resp.Original.RemainingMargin != 1200000 {
+		t.Errorf("Expected original 1200000 per installment and 1200000 margin, got %+v", resp.Original)
+	}
+	if resp.Revised.RemainingInstallments != 6 || resp.Revised.Installment != 550000 || resp.Revised.RemainingMargin != 300000 {
+		t.Errorf("Expected six installments of 550000 with 300000 margin, got %+v", resp.Revised)
+	}
+	if resp.Revised.MaturityDate != resp.Original.MaturityDate || resp.Revised.MaturityDate != "2027-01-15" {
+		t.Errorf("Expected maturity to stay 2027-01-15, got %s", resp.Revised.MaturityDate)
+	}
+	// The running installment's 150,000 saving accrues for 5 of 31 days.
+	if resp.AccruedMargin != 24194 || resp.MarginSaved != 875806 {
+		t.Errorf("Expected accrued margin 24194 and margin saved 875806, got %d/%d", resp.AccruedMargin, resp.MarginSaved)
+	}
+	if resp.Schedule[0].InstallmentNumber != 7 || resp.Schedule[0].DueDate != "2026-08-15" {
+		t.Errorf("Expected the schedule to continue at installment 7 on 2026-08-15, got %+v", resp.Schedule[0])
+	}
+}
+
+func TestSimulatePrepayment_ReduceTenor(t *testing.T) {
+	resp, err := settlementUsecase().SimulatePrepayment(prepaymentRequest(domain.PrepaymentReduceTenor))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// 3,000,000 over three months costs 1,050,000 a month, within the
+	// current 1,200,000; over two months it would cost 1,550,000.
+	if resp.Revised.RemainingInstallments != 3 || resp.Revised.Installment != 1050000 {
+		t.Errorf("Expected three installments of 1050000, got %+v", resp.Revised)
+	}
+	if resp.Revised.MaturityDate != "2026-10-15" {
+		t.Errorf("Expected maturity 2026-10-15, got %s", resp.Revised.MaturityDate)
+	}
+	if resp.AccruedMargin != 24194 || resp.MarginSaved != 1025806 {
+		t.Errorf("Expected accrued margin 24194 and margin saved 1025806, got %d/%d", resp.AccruedMargin, resp.MarginSaved)
+	}
+}
+
+func TestSimulatePrepayment_AccruesToPrepaymentDate(t *testing.T) {
+	tests := []struct {
+		date    string
+		accrued int64
+	}{
+		{"2026-07-15", 0},
+		{"2026-07-20", 24194},
+		{"2026-08-14", 145161},
+	}
+
+	for _, tt := range tests {
+		req := prepaymentRequest("")
+		req.PrepaymentDate = tt.date
+
+		resp, err := settlementUsecase().SimulatePrepayment(req)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.date, err)
+		}
+		if resp.AccruedMargin != tt.accrued || resp.MarginSaved != 900000-tt.accrued {
+			t.Errorf("%s: expected accrued margin %d, got %d with margin saved %d", tt.date, tt.accrued, resp.AccruedMargin, resp.MarginSaved)
+		}
+		if resp.Revised.Installment != 550000 {
+			t.Errorf("%s: expected the revised installment to stay 550000, got %d", tt.date, resp.Revised.Installment)
+		}
+	}
+}
+
+func TestSimulatePrepayment_PricedAsOfStartDate(t *testing.T) {
+	liveRate := 0.3
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 2, TenorValue: 12, FlatMarginRate: &liveRate}}}
+	usecase := NewCicilanUsecase(mockRepo, WithPricingHistory(pricingHistory()))
+
+	req := prepaymentRequest("")
+	req.StartDate = "2026-09-15"
+	req.InstallmentsPaid = 0
+	req.PrepaymentDate = "2026-09-15"
+
+	resp, err := usecase.SimulatePrepayment(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Started before the repricing on 1 October, so priced at 20%.
+	if resp.PricingVersionID != 1 || resp.Original.RemainingMargin != 2400000 {
+		t.Errorf("Expected version 1 with 2400000 margin, got version %d with %d", resp.PricingVersionID, resp.Original.RemainingMargin)
+	}
+}
+
+func TestSimulatePrepayment_EffectiveReconciles(t *testing.T) {
+	for _, option := range []string{domain.PrepaymentReduceInstallment, domain.PrepaymentReduceTenor} {
+		req := prepaymentRequest(option)
+		req.Method = domain.MethodEffective
+
+		resp, err := settlementUsecase().SimulatePrepayment(req)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", option, err)
+		}
+
+		var principal int64
+		for _, row := range resp.Schedule {
+			principal += row.Principal
+		}
+		if principal != resp.RemainingPrincipal || resp.Schedule[len(resp.Schedule)-1].RemainingBalance != 0 {
+			t.Errorf("%s: expected the schedule to repay %d, got %d", option, resp.RemainingPrincipal, principal)
+		}
+		if resp.Revised.Installment > resp.Original.Installment || resp.MarginSaved <= 0 {
+			t.Errorf("%s: expected a lower or equal installment and margin saved, got %+v -> %+v", option, resp.Original, resp.Revised)
+		}
+	}
+}
+
+func TestSimulatePrepayment_Validation(t *testing.T) {
+	usecase := settlementUsecase()
+
+	req := prepaymentRequest("")
+	req.InstallmentsPaid = 5
+	if _, err := usecase.SimulatePrepayment(req); err == nil {
+		t.Error("Expected validation error when an installment due before the prepayment is unpaid")
+	}
+
+	req = prepaymentRequest("")
+	req.PrepaymentAmount = 6000000
+	if _, err := usecase.SimulatePrepayment(req); err == nil {
+		t.Error("Expected validation error when the prepayment covers the outstanding principal")
+	}
+
+	req = prepaymentRequest("skip_installments")
+	if _, err := usecase.SimulatePrepayment(req); err == nil {
+		t.Error("Expected validation error for unknown option")
+	}
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+// WithPricingHistory prices from the recorded pricing versions instead of
+// the live tenor master and product catalog.
+func WithPricingHistory(repo cicilan.PricingHistoryRepository) Option {
+	return func(u *cicilanUsecase) {
+		u.history = repo
+	}
+}
+
+// pricingVersion resolves the version to price with: the one named by
+// versionID, otherwise the one in effect at the instant at. It is nil
+// without a pricing history or before the first version, in which case the
+// live tenor master and product catalog are used.
+func (u *cicilanUsecase) pricingVersion(versionID int64, at time.Time) (*domain.PricingVersion, error) {
+	if versionID == 0 {
+		if u.history == nil {
+			return nil, nil
+		}
+		return u.history.GetPricingVersion(at)
+	}
+
+	var version *domain.PricingVersion
+	if u.history != nil {
+		var err error
+		if version, err = u.history.GetPricingVersionByID(versionID); err != nil {
+			return nil, err
+		}
+	}
+	if version == nil {
+		return nil, &ValidationError{Field: "pricing_version_id", Message: fmt.Sprintf("pricing version %d does not exist", versionID)}
+	}
+	return version, nil
+}
+
+// tenorsOn returns the tenors available on date as priced by the terms'
+// pricing version.
+func (u *cicilanUsecase) tenorsOn(terms pricingTerms, date time.Time) ([]domain.Tenor, error) {
+	if terms.version == nil {
+		return u.repo.GetAllTenors(date)
+	}
+
+	tenors := make([]domain.Tenor, 0, len(terms.version.Tenors))
+	for _, versioned := range terms.version.Tenors {
+		if tenor := versioned.Tenor(); tenor.AvailableOn(date) {
+			tenors = append(tenors, tenor)
+		}
+	}
+	return tenors, nil
+}
+
+// findProduct looks the product up in the pricing version. Versions recorded
+// before products were versioned carry none and fall back to the live
+// catalog.
+func (u *cicilanUsecase) findProduct(code string, version *domain.PricingVersion) (*domain.Product, error) {
+	if version == nil || len(version.Products) == 0 {
+		return u.repo.GetProductByCode(code)
+	}
+
+	for _, versioned := range version.Products {
+		if versioned.Code == code {
+			product := versioned.Product()
+			return &product, nil
+		}
+	}
+	return nil, nil
+}
+
+// pricingInstant is the instant whose pricing applies to a date: the close
+// of that day, or now while the day is still open.
+func pricingInstant(date time.Time) time.Time {
+	closing := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1).Add(-time.Millisecond)
+	if now := time.Now(); closing.After(now) {
+		return now
+	}
+	return closing
+}
+
+// parseAsOf reads as_of either as a date, priced at its close, or as an
+// RFC 3339 timestamp, priced at that instant. Empty means now.
+func parseAsOf(value string) (time.Time, time.Time, error) {
+	if value == "" {
+		return today(), time.Now(), nil
+	}
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC), at, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, time.Time{}, &ValidationError{Field: "as_of", Message: "as_of must use the YYYY-MM-DD or RFC 3339 format"}
+	}
+	return date, pricingInstant(date), nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+type MockPricingHistoryRepository struct {
+	versions []domain.PricingVersion
+	at       time.Time
+}
+
+func (m *MockPricingHistoryRepository) GetPricingVersion(at time.Time) (*domain.PricingVersion, error) {
+	m.at = at
+	var found *domain.PricingVersion
+	for i := range m.versions {
+		if m.versions[i].EffectiveAt <= at.UnixMilli() {
+			found = &m.versions[i]
+		}
+	}
+	return found, nil
+}
+
+func (m *MockPricingHistoryRepository) GetPricingVersionByID(id int64) (*domain.PricingVersion, error) {
+	for i := range m.versions {
+		if m.versions[i].ID == id {
+			return &m.versions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func pricingHistory() *MockPricingHistoryRepository {
+	rate20, rate24 := 0.2, 0.24
+	repriced := time.Date(2026, time.October, 1, 9, 30, 0, 0, time.UTC).UnixMilli()
+	return &MockPricingHistoryRepository{versions: []domain.PricingVersion{
+		{ID: 1, EffectiveAt: 0, Tenors: []domain.PricingVersionTenor{
+			{TenorID: 2, TenorValue: 12, FlatMarginRate: &rate20, Active: true},
+		}},
+		{ID: 2, EffectiveAt: repriced, Tenors: []domain.PricingVersionTenor{
+			{TenorID: 2, TenorValue: 12, FlatMarginRate: &rate24, Active: true},
+			{TenorID: 5, TenorValue: 48, FlatMarginRate: &rate24, Active: true, EffectiveFrom: datePtr("2026-11-01")},
+		}, Products: []domain.PricingVersionProduct{
+			{ProductID: 4, Code: "qardh", ContractType: domain.ContractQardh, FeeAmount: 75000},
+		}},
+	}}
+}
+
+func TestCalculateInstallments_AsOfPricingVersion(t *testing.T) {
+	liveRate := 0.3
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 2, TenorValue: 12, FlatMarginRate: &liveRate}}}
+	usecase := NewCicilanUsecase(mockRepo, WithPricingHistory(pricingHistory()))
+
+	tests := []struct {
+		asOf     string
+		request  int64
+		version  int64
+		rate     float64
+		accepted []int
+	}{
+		{"2026-09-30", 0, 1, 0.2, []int{12}},
+		// A date is priced at its close, so the repricing at 09:30 applies
+		// to the whole of 1 October; a timestamp is priced at that instant.
+		{"2026-10-01", 0, 2, 0.24, []int{12}},
+		{"2026-10-01T09:00:00Z", 0, 1, 0.2, []int{12}},
+		{"2026-10-01T16:30:00+07:00", 0, 2, 0.24, []int{12}},
+		{"2026-11-01", 0, 2, 0.24, []int{12, 48}},
+		{"2026-11-01", 1, 1, 0.2, []int{12}},
+	}
+
+	for _, tt := range tests {
+		resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+			Amount:         12000000,
+			AsOf:           tt.asOf,
+			PricingOptions: domain.PricingOptions{PricingVersionID: tt.request},
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.asOf, err)
+		}
+		if resp.PricingVersionID != tt.version || resp.AsOf != tt.asOf[:10] {
+			t.Errorf("%s: expected pricing version %d, got %d as of %s", tt.asOf, tt.version, resp.PricingVersionID, resp.AsOf)
+		}
+		if resp.Calculations[0].AnnualMarginRate != tt.rate {
+			t.Errorf("%s: expected rate %v, got %v", tt.asOf, tt.rate, resp.Calculations[0].AnnualMarginRate)
+		}
+		if len(resp.AcceptedTenors) != len(tt.accepted) {
+			t.Errorf("%s: expected tenors %v, got %v", tt.asOf, tt.accepted, resp.AcceptedTenors)
+		}
+	}
+
+	_, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:         12000000,
+		PricingOptions: domain.PricingOptions{PricingVersionID: 9},
+	})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "pricing_version_id" {
+		t.Errorf("Expected a pricing_version_id validation error for an unknown version, got %v", err)
+	}
+}
+
+func TestCalculateInstallments_VersionedProduct(t *testing.T) {
+	rate := 0.2
+	mockRepo := &MockCicilanRepository{
+		tenors:   []domain.Tenor{{ID: 2, TenorValue: 12, FlatMarginRate: &rate}},
+		products: []domain.Product{{ID: 4, Code: "qardh", ContractType: domain.ContractQardh, FeeAmount: 50000}},
+	}
+	usecase := NewCicilanUsecase(mockRepo, WithPricingHistory(pricingHistory()))
+
+	tests := []struct {
+		asOf string
+		fee  int64
+	}{
+		// Version 1 predates product versioning and uses the live catalog.
+		{"2026-09-30", 50000},
+		{"2026-10-02", 75000},
+	}
+
+	for _, tt := range tests {
+		resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+			Amount:         5000000,
+			AsOf:           tt.asOf,
+			PricingOptions: domain.PricingOptions{ProductCode: "qardh"},
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.asOf, err)
+		}
+		if resp.Calculations[0].Fee != tt.fee {
+			t.Errorf("%s: expected fee %d, got %d", tt.asOf, tt.fee, resp.Calculations[0].Fee)
+		}
+	}
+
+	_, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:         5000000,
+		AsOf:           "2026-10-02",
+		PricingOptions: domain.PricingOptions{ProductCode: "murabahah"},
+	})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected a validation error for a product missing from the version, got %v", err)
+	}
+}
+
+func TestCalculateInstallments_WithoutPricingHistory(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 12000000, AsOf: "2026-09-30"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.PricingVersionID != 0 || mockRepo.asOf.Format(dateLayout) != "2026-09-30" {
+		t.Errorf("Expected the live tenor master as of 2026-09-30 without a version, got version %d as of %s",
+			resp.PricingVersionID, mockRepo.asOf.Format(dateLayout))
+	}
+
+	if _, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 12000000, AsOf: "30/09/2026"}); err == nil {
+		t.Error("Expected validation error for a malformed as_of")
+	}
+}
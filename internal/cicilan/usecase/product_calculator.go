@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"math/big"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+// murabahahCalculator prices a sale with a deferred, marked-up price. It is
+// also used when a request names no product.
+type murabahahCalculator struct{}
+
+func NewMurabahahCalculator() cicilan.ProductCalculator {
+	return murabahahCalculator{}
+}
+
+func (murabahahCalculator) ContractType() string      { return domain.ContractMurabahah }
+func (murabahahCalculator) ProfitType() string        { return domain.ProfitMargin }
+func (murabahahCalculator) SupportsRateReviews() bool { return false }
+
+func (murabahahCalculator) Methods() []string {
+	return []string{domain.MethodFlat, domain.MethodEffective}
+}
+
+func (murabahahCalculator) AnnualRate(tenor domain.Tenor, method string) float64 {
+	return marginRate(tenor, method)
+}
+
+func (murabahahCalculator) BuildSchedule(method cicilan.CalculationMethod, input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	return buildWithGrace(method, input)
+}
+
+// ijarahCalculator prices IMBT. The bank owns the asset and depreciates it
+// straight-line over the lease; ujrah is charged on its unamortised value
+// each period and levelled into equal rents. Ownership passes to the
+// customer by hibah after the last rent.
+type ijarahCalculator struct{}
+
+func NewIjarahCalculator() cicilan.ProductCalculator {
+	return ijarahCalculator{}
+}
+
+func (ijarahCalculator) ContractType() string      { return domain.ContractIjarah }
+func (ijarahCalculator) ProfitType() string        { return domain.ProfitUjrah }
+func (ijarahCalculator) SupportsRateReviews() bool { return false }
+
+func (ijarahCalculator) Methods() []string {
+	return []string{domain.MethodEffective}
+}
+
+func (ijarahCalculator) AnnualRate(tenor domain.Tenor, method string) float64 {
+	return marginRate(tenor, domain.MethodEffective)
+}
+
+func (ijarahCalculator) BuildSchedule(method cicilan.CalculationMethod, input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	return buildWithGrace(ijarahRent{}, input)
+}
+
+// ijarahRent builds IMBT rents: the ujrah of every period on the asset value
+// left after straight-line depreciation, spread over the rents by their
+// weights. A balloon is the value left undepreciated until the last rent.
+type ijarahRent struct{}
+
+func (ijarahRent) Name() string {
+	return domain.MethodEffective
+}
+
+func (ijarahRent) BuildSchedule(input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	count := int64(input.Installments)
+
+	// The asset is worth principal - k*(principal-balloon)/count at the
+	// start of period k+1, so the values sum to count*principal less
+	// (principal-balloon)*(count-1)/2.
+	depreciated := new(big.Rat).Mul(ratFromInt(input.Principal-input.Balloon), big.NewRat(count-1, 2))
+	unamortised := new(big.Rat).Sub(ratFromInt(input.Principal*count), depreciated)
+
+	ujrah := new(big.Rat).Mul(unamortised, ratFromFloat(input.AnnualMarginRate))
+	ujrah.Quo(ujrah, big.NewRat(int64(input.PeriodsPerYear), 1))
+
+	return spreadSchedule(input, roundRat(ujrah, rupiah))
+}
+
+// mmqCalculator prices musyarakah mutanaqisah: the customer buys the bank's
+// share unit by unit and rents the part the bank still owns. The rent may be
+// repriced at agreed review months.
+type mmqCalculator struct{}
+
+func NewMMQCalculator() cicilan.ProductCalculator {
+	return mmqCalculator{}
+}
+
+func (mmqCalculator) ContractType() string      { return domain.ContractMMQ }
+func (mmqCalculator) ProfitType() string        { return domain.ProfitUjrah }
+func (mmqCalculator) SupportsRateReviews() bool { return true }
+
+func (mmqCalculator) Methods() []string {
+	return []string{domain.MethodDiminishing}
+}
+
+func (mmqCalculator) AnnualRate(tenor domain.Tenor, method string) float64 {
+	return marginRate(tenor, domain.MethodEffective)
+}
+
+func (mmqCalculator) BuildSchedule(method cicilan.CalculationMethod, input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	return buildWithGrace(method, input)
+}
+
+// qardhCalculator prices a benevolent loan: the principal is repaid without
+// margin and the product fee is the only charge.
+type qardhCalculator struct{}
+
+func NewQardhCalculator() cicilan.ProductCalculator {
+	return qardhCalculator{}
+}
+
+func (qardhCalculator) ContractType() string      { return domain.ContractQardh }
+func (qardhCalculator) ProfitType() string        { return domain.ProfitNone }
+func (qardhCalculator) SupportsRateReviews() bool { return false }
+
+func (qardhCalculator) Methods() []string {
+	return []string{domain.MethodFlat}
+}
+
+func (qardhCalculator) AnnualRate(tenor domain.Tenor, method string) float64 {
+	return 0
+}
+
+func (qardhCalculator) BuildSchedule(method cicilan.CalculationMethod, input domain.PricingInput) ([]domain.InstallmentScheduleRow, error) {
+	input.AnnualMarginRate = 0
+	return buildWithGrace(method, input)
+}
+
+func defaultProductCalculators() map[string]cicilan.ProductCalculator {
+	calculators := make(map[string]cicilan.ProductCalculator)
+	for _, calculator := range []cicilan.ProductCalculator{
+		NewMurabahahCalculator(), NewIjarahCalculator(), NewMMQCalculator(), NewQardhCalculator(),
+	} {
+		calculators[calculator.ContractType()] = calculator
+	}
+	return calculators
+}
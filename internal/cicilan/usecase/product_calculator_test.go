@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"btpntest/domain"
+)
+
+func productUsecase() *cicilanUsecase {
+	feeRate := 0.01
+	mockRepo := &MockCicilanRepository{
+		tenors: []domain.Tenor{{ID: 1, TenorValue: 12}},
+		products: []domain.Product{
+			{ID: 1, Code: "murabahah", ContractType: domain.ContractMurabahah},
+			{ID: 2, Code: "imbt", ContractType: domain.ContractIjarah, FeeRate: &feeRate},
+			{ID: 3, Code: "mmq", ContractType: domain.ContractMMQ},
+			{ID: 4, Code: "qardh", ContractType: domain.ContractQardh, FeeAmount: 50000},
+			{ID: 5, Code: "istishna", ContractType: "istishna"},
+		},
+	}
+	return NewCicilanUsecase(mockRepo).(*cicilanUsecase)
+}
+
+func quoteProduct(t *testing.T, code, method string) domain.InstallmentCalculation {
+	t.Helper()
+
+	resp, err := productUsecase().CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:         12000000,
+		PricingOptions: domain.PricingOptions{ProductCode: code, Method: method},
+	})
+	if err != nil {
+		t.Fatalf("%s: expected no error, got %v", code, err)
+	}
+	if len(resp.Calculations) != 1 {
+		t.Fatalf("%s: expected 1 calculation, got %d", code, len(resp.Calculations))
+	}
+	return resp.Calculations[0]
+}
+
+func TestCalculateInstallments_DefaultsToMurabahah(t *testing.T) {
+	calc := quoteProduct(t, "", "")
+
+	if calc.Product != "" || calc.ContractType != domain.ContractMurabahah || calc.ProfitType != domain.ProfitMargin {
+		t.Errorf("Expected unnamed murabahah quote, got product %q contract %q profit %q", calc.Product, calc.ContractType, calc.ProfitType)
+	}
+	if calc.Method != domain.MethodFlat || calc.TotalMargin != 2400000 || calc.Fee != 0 {
+		t.Errorf("Expected flat margin 2400000 without fee, got %s/%d/%d", calc.Method, calc.TotalMargin, calc.Fee)
+	}
+}
+
+func TestCalculateInstallments_IjarahRentsDefaultToEffective(t *testing.T) {
+	calc := quoteProduct(t, "imbt", "")
+
+	if calc.Product != "imbt" || calc.ContractType != domain.ContractIjarah || calc.ProfitType != domain.ProfitUjrah {
+		t.Errorf("Expected IMBT ujrah quote, got product %q contract %q profit %q", calc.Product, calc.ContractType, calc.ProfitType)
+	}
+	if calc.Method != domain.MethodEffective {
+		t.Errorf("Expected effective rents by default, got %s", calc.Method)
+	}
+	if calc.Fee != 120000 {
+		t.Errorf("Expected 1%% fee of 120000, got %d", calc.Fee)
+	}
+}
+
+func TestCalculateInstallments_ProductFeeIsPaidUpfront(t *testing.T) {
+	resp, err := productUsecase().CalculateInstallments(&domain.CalculateInstallmentRequest{
+		AssetPrice: 15000000,
+		FinancingComponents: domain.FinancingComponents{
+			DownPayment: 3000000,
+			StampDuty:   &domain.FinancingCharge{Amount: 10000},
+		},
+		PricingOptions: domain.PricingOptions{ProductCode: "imbt"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Down payment, stamp duty and the 1% IMBT fee on 12,000,000.
+	if resp.Financing.ProductFee != 120000 || resp.Financing.UpfrontPayment != 3130000 {
+		t.Errorf("Expected product_fee 120000 and upfront_payment 3130000, got %d and %d", resp.Financing.ProductFee, resp.Financing.UpfrontPayment)
+	}
+	if resp.Calculations[0].Fee != resp.Financing.ProductFee {
+		t.Errorf("Expected the quoted fee %d to match the breakdown, got %d", resp.Calculations[0].Fee, resp.Financing.ProductFee)
+	}
+}
+
+func TestCalculateInstallments_IjarahLevelsRentOnDepreciatedAsset(t *testing.T) {
+	usecase := productUsecase()
+
+	resp, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:         12000000,
+		Tenor:          12,
+		PricingOptions: domain.PricingOptions{ProductCode: "imbt"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Ujrah at 20% on an asset depreciating 1,000,000 a month: the same
+	// total as MMQ rent, but levelled instead of declining.
+	if resp.TotalMargin != 1300000 {
+		t.Errorf("Expected total ujrah 1300000, got %d", resp.TotalMargin)
+	}
+	first, last := resp.Schedule[0], resp.Schedule[11]
+	if first.Installment != 1108333 || first.Margin != 108333 {
+		t.Errorf("Expected level rent 1108333 with ujrah 108333, got %d/%d", first.Installment, first.Margin)
+	}
+	if last.Installment != 1108337 || last.RemainingBalance != 0 {
+		t.Errorf("Expected last rent 1108337 clearing the asset, got %d/%d", last.Installment, last.RemainingBalance)
+	}
+
+	murabahah, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:         12000000,
+		Tenor:          12,
+		PricingOptions: domain.PricingOptions{ProductCode: "murabahah", Method: domain.MethodEffective},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if murabahah.TotalMargin == resp.TotalMargin {
+		t.Errorf("Expected ijarah rent to differ from effective murabahah margin %d", murabahah.TotalMargin)
+	}
+}
+
+func TestCalculateInstallments_MMQBuysOutBankShare(t *testing.T) {
+	usecase := productUsecase()
+
+	resp, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:         12000000,
+		Tenor:          12,
+		PricingOptions: domain.PricingOptions{ProductCode: "mmq"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Method != domain.MethodDiminishing || resp.ContractType != domain.ContractMMQ {
+		t.Errorf("Expected diminishing MMQ schedule, got %s/%s", resp.Method, resp.ContractType)
+	}
+
+	first, last := resp.Schedule[0], resp.Schedule[11]
+	if first.Principal != 1000000 || first.Margin != 200000 {
+		t.Errorf("Expected first acquisition 1000000 and rent 200000, got %d/%d", first.Principal, first.Margin)
+	}
+	if last.Principal != 1000000 || last.Margin != 16667 || last.RemainingBalance != 0 {
+		t.Errorf("Expected last acquisition 1000000, rent 16667 and no balance, got %d/%d/%d", last.Principal, last.Margin, last.RemainingBalance)
+	}
+	if resp.TotalMargin != 1300000 {
+		t.Errorf("Expected total rent 1300000, got %d", resp.TotalMargin)
+	}
+}
+
+func TestCalculateInstallments_QardhChargesOnlyFee(t *testing.T) {
+	calc := quoteProduct(t, "qardh", "")
+
+	if calc.ProfitType != domain.ProfitNone || calc.AnnualMarginRate != 0 {
+		t.Errorf("Expected margin-free quote, got profit %q at rate %v", calc.ProfitType, calc.AnnualMarginRate)
+	}
+	if calc.TotalMargin != 0 || calc.MonthlyInstallment != 1000000 || calc.TotalPayment != 12000000 {
+		t.Errorf("Expected principal-only installments, got margin %d installment %d total %d", calc.TotalMargin, calc.MonthlyInstallment, calc.TotalPayment)
+	}
+	if calc.Fee != 50000 {
+		t.Errorf("Expected fee 50000, got %d", calc.Fee)
+	}
+	// Repaying 12,000,000 on 11,950,000 disbursed after the fee.
+	if math.Abs(calc.EffectiveAnnualRate-0.0077) > 0.0001 {
+		t.Errorf("Expected the fee to cost about 0.0077 a year, got %v", calc.EffectiveAnnualRate)
+	}
+}
+
+func TestCalculateInstallments_QardhFeeRateIsMisconfiguration(t *testing.T) {
+	feeRate := 0.01
+	usecase := NewCicilanUsecase(&MockCicilanRepository{
+		tenors:   []domain.Tenor{{ID: 1, TenorValue: 12}},
+		products: []domain.Product{{ID: 1, Code: "qardh", ContractType: domain.ContractQardh, FeeAmount: 50000, FeeRate: &feeRate}},
+	})
+
+	_, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:         12000000,
+		PricingOptions: domain.PricingOptions{ProductCode: "qardh"},
+	})
+	if err == nil {
+		t.Fatal("Expected error for a qardh fee rate, got nil")
+	}
+	if _, ok := err.(*ValidationError); ok {
+		t.Errorf("Expected catalog misconfiguration to be an internal error, got validation error %v", err)
+	}
+}
+
+func TestCalculateInstallments_ProductErrors(t *testing.T) {
+	usecase := productUsecase()
+
+	for _, options := range []domain.PricingOptions{
+		{ProductCode: "tawarruq"},
+		{ProductCode: "mmq", Method: domain.MethodFlat},
+		{ProductCode: "imbt", Method: domain.MethodFlat},
+		{ProductCode: "murabahah", Method: domain.MethodDiminishing},
+	} {
+		_, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 12000000, PricingOptions: options})
+		if _, ok := err.(*ValidationError); !ok {
+			t.Errorf("%+v: expected validation error, got %v", options, err)
+		}
+	}
+
+	_, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount:         12000000,
+		PricingOptions: domain.PricingOptions{ProductCode: "istishna"},
+	})
+	if err == nil {
+		t.Fatal("Expected error for unsupported contract type, got nil")
+	}
+	if _, ok := err.(*ValidationError); ok {
+		t.Errorf("Expected catalog misconfiguration to be an internal error, got validation error %v", err)
+	}
+}
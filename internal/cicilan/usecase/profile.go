@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"btpntest/domain"
+)
+
+// resolveProfile validates the installment profile and returns its type.
+func resolveProfile(profile *domain.InstallmentProfile) (string, error) {
+	if profile == nil || profile.Type == "" || profile.Type == domain.ProfileLevel {
+		return domain.ProfileLevel, nil
+	}
+
+	switch profile.Type {
+	case domain.ProfileStepUp:
+		if profile.StepRate <= 0 || profile.StepRate > 1 {
+			return "", &ValidationError{Message: "step_rate must be greater than 0 and at most 1"}
+		}
+		if profile.StepMonths <= 0 {
+			return "", &ValidationError{Message: "step_months must be greater than 0"}
+		}
+	case domain.ProfileSeasonal:
+		if len(profile.HighMonths) == 0 && len(profile.LowMonths) == 0 {
+			return "", &ValidationError{Message: "seasonal profiles need high_months or low_months"}
+		}
+		if len(profile.HighMonths) > 0 && profile.HighFactor <= 1 {
+			return "", &ValidationError{Message: "high_factor must be greater than 1"}
+		}
+		if len(profile.LowMonths) > 0 && (profile.LowFactor <= 0 || profile.LowFactor >= 1) {
+			return "", &ValidationError{Message: "low_factor must be between 0 and 1"}
+		}
+
+		seen := make(map[int]bool)
+		for _, month := range append(append([]int{}, profile.HighMonths...), profile.LowMonths...) {
+			if month < 1 || month > 12 {
+				return "", &ValidationError{Message: fmt.Sprintf("seasonal month %d must be between 1 and 12", month)}
+			}
+			if seen[month] {
+				return "", &ValidationError{Message: fmt.Sprintf("seasonal month %d is listed more than once", month)}
+			}
+			seen[month] = true
+		}
+	case domain.ProfileBalloon:
+		if profile.BalloonRate <= 0 || profile.BalloonRate >= 1 {
+			return "", &ValidationError{Message: "balloon_rate must be between 0 and 1"}
+		}
+	default:
+		return "", &ValidationError{Message: fmt.Sprintf("unsupported installment profile: %s", profile.Type)}
+	}
+
+	return profile.Type, nil
+}
+
+// profileWeights returns the relative size of each of the count
+// installments, or nil for level installments. Seasonal weights follow the
+// calendar month each installment actually falls due in, once dates has
+// placed it on its payment day and moved it off non-business days.
+func profileWeights(profile *domain.InstallmentProfile, count int, frequency string, startDate time.Time, dates dueDateRule) []float64 {
+	if profile == nil {
+		return nil
+	}
+
+	switch profile.Type {
+	case domain.ProfileStepUp:
+		weights := make([]float64, count)
+		for i := range weights {
+			month := ((i+1)*12 + periodsPerYear[frequency] - 1) / periodsPerYear[frequency]
+			weights[i] = math.Pow(1+profile.StepRate, float64((month-1)/profile.StepMonths))
+		}
+		return weights
+	case domain.ProfileSeasonal:
+		factors := make(map[time.Month]float64)
+		for _, month := range profile.HighMonths {
+			factors[time.Month(month)] = profile.HighFactor
+		}
+		for _, month := range profile.LowMonths {
+			factors[time.Month(month)] = profile.LowFactor
+		}
+
+		weights := make([]float64, count)
+		for i := range weights {
+			weights[i] = 1
+			if factor, ok := factors[dates.dueDate(startDate, frequency, i+1).Month()]; ok {
+				weights[i] = factor
+			}
+		}
+		return weights
+	default:
+		return nil
+	}
+}
+
+func balloonAmount(profile *domain.InstallmentProfile, principal int64) int64 {
+	if profile == nil || profile.Type != domain.ProfileBalloon {
+		return 0
+	}
+	return roundRat(new(big.Rat).Mul(ratFromInt(principal), ratFromFloat(profile.BalloonRate)), rupiah)
+}
+
+// weightRats converts the installment weights of input to exact rationals,
+// defaulting to level installments.
+func weightRats(input domain.PricingInput) ([]*big.Rat, *big.Rat) {
+	weights := make([]*big.Rat, input.Installments)
+	total := new(big.Rat)
+	for i := range weights {
+		weights[i] = big.NewRat(1, 1)
+		if input.Weights != nil {
+			weights[i] = ratFromFloat(input.Weights[i])
+		}
+		total.Add(total, weights[i])
+	}
+	return weights, total
+}
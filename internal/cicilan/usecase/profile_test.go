@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+func TestBuildSchedule_StepUp(t *testing.T) {
+	weights := profileWeights(&domain.InstallmentProfile{Type: domain.ProfileStepUp, StepRate: 0.1, StepMonths: 12}, 24, domain.FrequencyMonthly, time.Time{}, dueDateRule{})
+
+	rows, err := NewFlatMethod().BuildSchedule(domain.PricingInput{
+		Principal: 12000000, Installments: 24, PeriodsPerYear: 12, AnnualMarginRate: 0.2, Rounding: rupiah,
+		Weights: weights,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if rows[0].Installment != 666667 || rows[11].Installment != 666667 {
+		t.Errorf("Expected first-year installments of 666667, got %d and %d", rows[0].Installment, rows[11].Installment)
+	}
+	if rows[12].Installment != 733333 {
+		t.Errorf("Expected second-year installment of 733333, got %d", rows[12].Installment)
+	}
+
+	calc := summarize(24, domain.MethodFlat, 0.2, rows)
+	if calc.TotalMargin != 4800000 {
+		t.Errorf("Expected flat margin to be unchanged at 4800000, got %d", calc.TotalMargin)
+	}
+	assertReconciles(t, 12000000, rows, calc)
+}
+
+func TestCalculateSchedule_Seasonal(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	resp, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:    12000000,
+		Tenor:     12,
+		StartDate: "2026-01-15",
+		PricingOptions: domain.PricingOptions{
+			Method: domain.MethodFlat,
+			Profile: &domain.InstallmentProfile{
+				Type:       domain.ProfileSeasonal,
+				HighMonths: []int{12}, HighFactor: 2,
+				LowMonths: []int{2}, LowFactor: 0.5,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Profile != domain.ProfileSeasonal {
+		t.Errorf("Expected profile %s, got %q", domain.ProfileSeasonal, resp.Profile)
+	}
+
+	checks := map[int]int64{0: 576000, 1: 1152000, 10: 2304000}
+	for index, expected := range checks {
+		row := resp.Schedule[index]
+		if row.Installment != expected {
+			t.Errorf("Installment %d due %s: expected %d, got %d", row.InstallmentNumber, row.DueDate, expected, row.Installment)
+		}
+	}
+	if resp.TotalPayment != 14400000 {
+		t.Errorf("Expected total_payment 14400000, got %d", resp.TotalPayment)
+	}
+}
+
+func TestCalculateSchedule_SeasonalFollowsAdjustedDueDates(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	// 31 January 2026 is a Saturday and rolls into February; 28 February is
+	// a Saturday too and rolls into March.
+	resp, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:    12000000,
+		Tenor:     12,
+		StartDate: "2025-12-31",
+		DueDateOptions: domain.DueDateOptions{
+			PaymentDay:            31,
+			BusinessDayConvention: domain.BusinessDayFollowing,
+		},
+		PricingOptions: domain.PricingOptions{
+			Method: domain.MethodFlat,
+			Profile: &domain.InstallmentProfile{
+				Type:      domain.ProfileSeasonal,
+				LowMonths: []int{2}, LowFactor: 0.5,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	first, second := resp.Schedule[0], resp.Schedule[1]
+	if first.DueDate != "2026-02-02" || second.DueDate != "2026-03-02" {
+		t.Fatalf("Expected due dates 2026-02-02 and 2026-03-02, got %s and %s", first.DueDate, second.DueDate)
+	}
+	if first.Installment*2 != second.Installment {
+		t.Errorf("Expected the February installment to be half of March's, got %d and %d", first.Installment, second.Installment)
+	}
+}
+
+func TestBuildSchedule_Balloon(t *testing.T) {
+	profile := &domain.InstallmentProfile{Type: domain.ProfileBalloon, BalloonRate: 0.3}
+	balloon := balloonAmount(profile, 12000000)
+	if balloon != 3600000 {
+		t.Fatalf("Expected balloon 3600000, got %d", balloon)
+	}
+
+	for _, method := range []string{domain.MethodFlat, domain.MethodEffective, domain.MethodDiminishing} {
+		rows, err := defaultMethods()[method].BuildSchedule(domain.PricingInput{
+			Principal: 12000000, Installments: 12, PeriodsPerYear: 12, AnnualMarginRate: 0.2, Rounding: rupiah,
+			Balloon: balloon,
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", method, err)
+		}
+
+		last := rows[11]
+		if last.Balloon != balloon || last.Installment <= balloon || last.Installment <= 2*rows[0].Installment {
+			t.Errorf("%s: expected a final installment carrying the 3600000 balloon, got %+v", method, last)
+		}
+		for _, row := range rows[:11] {
+			if row.Balloon != 0 {
+				t.Errorf("%s row %d: expected no balloon, got %d", method, row.InstallmentNumber, row.Balloon)
+			}
+		}
+
+		assertReconciles(t, 12000000, rows, summarize(12, method, 0.2, rows))
+	}
+}
+
+func TestBuildWithGrace_StepUpReconciles(t *testing.T) {
+	weights := profileWeights(&domain.InstallmentProfile{Type: domain.ProfileStepUp, StepRate: 0.05, StepMonths: 6}, 24, domain.FrequencyMonthly, time.Time{}, dueDateRule{})
+
+	for _, method := range []string{domain.MethodFlat, domain.MethodEffective, domain.MethodDiminishing} {
+		rows, err := buildWithGrace(defaultMethods()[method], domain.PricingInput{
+			Principal: 15000000, Installments: 24, PeriodsPerYear: 12, AnnualMarginRate: 0.18, Rounding: rupiah,
+			GracePeriods: 3, GraceMode: domain.GraceMarginOnly, Weights: weights,
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", method, err)
+		}
+
+		// MMQ steps up the acquisitions; the rent still runs off the balance.
+		first, last := rows[3].Installment, rows[23].Installment
+		if method == domain.MethodDiminishing {
+			first, last = rows[3].Principal, rows[23].Principal
+		}
+		if first >= last {
+			t.Errorf("%s: expected installments to step up, got %d then %d", method, first, last)
+		}
+		assertReconciles(t, 15000000, rows, summarize(24, method, 0.18, rows))
+	}
+}
+
+func TestResolveProfile(t *testing.T) {
+	if profile, err := resolveProfile(nil); err != nil || profile != domain.ProfileLevel {
+		t.Errorf("Expected default level profile, got %s (%v)", profile, err)
+	}
+
+	invalid := []domain.InstallmentProfile{
+		{Type: "graduated"},
+		{Type: domain.ProfileStepUp, StepRate: 0.1},
+		{Type: domain.ProfileStepUp, StepRate: 1.5, StepMonths: 12},
+		{Type: domain.ProfileSeasonal},
+		{Type: domain.ProfileSeasonal, HighMonths: []int{12}, HighFactor: 0.8},
+		{Type: domain.ProfileSeasonal, LowMonths: []int{13}, LowFactor: 0.5},
+		{Type: domain.ProfileSeasonal, HighMonths: []int{6}, LowMonths: []int{6}, HighFactor: 2, LowFactor: 0.5},
+		{Type: domain.ProfileBalloon, BalloonRate: 1},
+	}
+	for _, profile := range invalid {
+		if _, err := resolveProfile(&profile); err == nil {
+			t.Errorf("Expected validation error for %+v", profile)
+		}
+	}
+}
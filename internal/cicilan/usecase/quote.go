@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+const (
+	DefaultQuoteTTL           = 24 * time.Hour
+	DefaultQuoteListLimit     = 100
+	MaxQuoteListLimit         = 500
+	DefaultQuoteCleanupPeriod = time.Hour
+)
+
+// WithQuotes lets calculate-installments requests save their result as a
+// quote that expires after ttl.
+func WithQuotes(repo cicilan.QuoteRepository, ttl time.Duration) Option {
+	return func(u *cicilanUsecase) {
+		u.quotes = repo
+		u.quoteTTL = ttl
+	}
+}
+
+// saveQuote stores req and response as a quote and references it from the
+// response. The stored result is the response as it was before the
+// reference was added.
+func (u *cicilanUsecase) saveQuote(req *domain.CalculateInstallmentRequest, response *domain.CalculateInstallmentResponse) error {
+	if u.quotes == nil {
+		return &ValidationError{Field: "save_quote", Message: "saving quotes is not enabled"}
+	}
+
+	requestBody, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resultBody, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	id, err := newQuoteID()
+	if err != nil {
+		return err
+	}
+
+	ttl := u.quoteTTL
+	if ttl <= 0 {
+		ttl = DefaultQuoteTTL
+	}
+	now := time.Now()
+	quote := domain.Quote{
+		ID:               id,
+		Channel:          req.Channel,
+		FinancedAmount:   response.Financing.FinancedAmount,
+		PricingVersionID: response.PricingVersionID,
+		RequestBody:      string(requestBody),
+		ResultBody:       string(resultBody),
+		CreatedAt:        now.UnixMilli(),
+		ExpiresAt:        now.Add(ttl).UnixMilli(),
+	}
+	if err := u.quotes.CreateQuote(&quote); err != nil {
+		return err
+	}
+
+	response.Quote = &domain.QuoteReference{QuoteID: quote.ID, ExpiresAt: formatMillis(quote.ExpiresAt)}
+	return nil
+}
+
+type quoteUsecase struct {
+	repo cicilan.QuoteRepository
+	now  func() time.Time
+}
+
+func NewQuoteUsecase(repo cicilan.QuoteRepository) cicilan.QuoteUsecase {
+	return &quoteUsecase{repo: repo, now: time.Now}
+}
+
+// GetQuote returns a saved quote. Expired quotes are reported as not found
+// even before the cleanup removes them.
+func (u *quoteUsecase) GetQuote(id string) (*domain.QuoteResponse, error) {
+	quote, err := u.repo.GetQuote(id)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil || quote.ExpiresAt <= u.now().UnixMilli() {
+		return nil, &NotFoundError{Message: fmt.Sprintf("quote %s not found", id)}
+	}
+
+	response := &domain.QuoteResponse{
+		QuoteID:          quote.ID,
+		Channel:          quote.Channel,
+		PricingVersionID: quote.PricingVersionID,
+		CreatedAt:        formatMillis(quote.CreatedAt),
+		ExpiresAt:        formatMillis(quote.ExpiresAt),
+	}
+	if err := json.Unmarshal([]byte(quote.RequestBody), &response.Request); err != nil {
+		return nil, fmt.Errorf("decode quote %s request: %w", id, err)
+	}
+	if err := json.Unmarshal([]byte(quote.ResultBody), &response.Result); err != nil {
+		return nil, fmt.Errorf("decode quote %s result: %w", id, err)
+	}
+	return response, nil
+}
+
+// ListQuotes returns the newest unexpired quotes created between from and
+// to, both inclusive dates, optionally for one channel.
+func (u *quoteUsecase) ListQuotes(req *domain.ListQuotesRequest) (*domain.ListQuotesResponse, error) {
+	from, err := parseDate(req.From, "from", time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDate(req.To, "to", time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, &ValidationError{Field: "to", Message: "to must not be before from"}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultQuoteListLimit
+	}
+	if limit > MaxQuoteListLimit {
+		return nil, &ValidationError{Field: "limit", Message: fmt.Sprintf("limit must not exceed %d", MaxQuoteListLimit)}
+	}
+
+	filter := domain.QuoteFilter{Channel: req.Channel, Now: u.now().UnixMilli(), Limit: limit}
+	if !from.IsZero() {
+		filter.From = from.UnixMilli()
+	}
+	if !to.IsZero() {
+		filter.To = to.AddDate(0, 0, 1).UnixMilli()
+	}
+
+	quotes, err := u.repo.ListQuotes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.ListQuotesResponse{Quotes: make([]domain.QuoteSummary, 0, len(quotes))}
+	for _, quote := range quotes {
+		response.Quotes = append(response.Quotes, domain.QuoteSummary{
+			QuoteID:          quote.ID,
+			Channel:          quote.Channel,
+			FinancedAmount:   quote.FinancedAmount,
+			PricingVersionID: quote.PricingVersionID,
+			CreatedAt:        formatMillis(quote.CreatedAt),
+			ExpiresAt:        formatMillis(quote.ExpiresAt),
+		})
+	}
+	return response, nil
+}
+
+// PurgeExpiredQuotes deletes expired quotes and returns how many were
+// deleted.
+func (u *quoteUsecase) PurgeExpiredQuotes() (int64, error) {
+	return u.repo.DeleteExpiredQuotes(u.now().UnixMilli())
+}
+
+// newQuoteID returns a random (version 4) UUID.
+func newQuoteID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+func formatMillis(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
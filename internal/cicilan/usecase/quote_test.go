@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"btpntest/domain"
+)
+
+type MockQuoteRepository struct {
+	quotes []domain.Quote
+	filter domain.QuoteFilter
+}
+
+func (m *MockQuoteRepository) CreateQuote(quote *domain.Quote) error {
+	m.quotes = append(m.quotes, *quote)
+	return nil
+}
+
+func (m *MockQuoteRepository) GetQuote(id string) (*domain.Quote, error) {
+	for _, quote := range m.quotes {
+		if quote.ID == id {
+			return &quote, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockQuoteRepository) ListQuotes(filter domain.QuoteFilter) ([]domain.Quote, error) {
+	m.filter = filter
+	return m.quotes, nil
+}
+
+func (m *MockQuoteRepository) DeleteExpiredQuotes(now int64) (int64, error) {
+	kept := m.quotes[:0]
+	for _, quote := range m.quotes {
+		if quote.ExpiresAt > now {
+			kept = append(kept, quote)
+		}
+	}
+	deleted := int64(len(m.quotes) - len(kept))
+	m.quotes = kept
+	return deleted, nil
+}
+
+func TestCalculateInstallments_SaveQuote(t *testing.T) {
+	quotes := &MockQuoteRepository{}
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}, {ID: 2, TenorValue: 12}}},
+		WithQuotes(quotes, 2*time.Hour))
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000, SaveQuote: true, Channel: "mobile"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Quote == nil || len(quotes.quotes) != 1 {
+		t.Fatalf("Expected one saved quote referenced from the response, got %+v", resp.Quote)
+	}
+
+	saved := quotes.quotes[0]
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(saved.ID) || resp.Quote.QuoteID != saved.ID {
+		t.Errorf("Expected a UUID quote ID, got %q", saved.ID)
+	}
+	if saved.ExpiresAt-saved.CreatedAt != (2 * time.Hour).Milliseconds() {
+		t.Errorf("Expected the quote to expire after 2 hours, got %d ms", saved.ExpiresAt-saved.CreatedAt)
+	}
+	if saved.Channel != "mobile" || saved.FinancedAmount != 10000000 {
+		t.Errorf("Expected channel and financed amount to be stored, got %+v", saved)
+	}
+
+	quoteUsecase := NewQuoteUsecase(quotes)
+	quote, err := quoteUsecase.GetQuote(saved.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if quote.Request.Amount != 10000000 || !quote.Request.SaveQuote || len(quote.Result.Calculations) != 2 {
+		t.Errorf("Expected the stored request and calculations, got %+v", quote)
+	}
+	if quote.Result.Quote != nil {
+		t.Error("Expected the stored result without its own quote reference")
+	}
+	if quote.ExpiresAt != resp.Quote.ExpiresAt {
+		t.Errorf("Expected expiry %s, got %s", resp.Quote.ExpiresAt, quote.ExpiresAt)
+	}
+}
+
+func TestCalculateInstallments_SaveQuoteDisabled(t *testing.T) {
+	usecase := NewCicilanUsecase(&MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 6}}})
+
+	_, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 10000000, SaveQuote: true})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected ValidationError without a quote store, got %v", err)
+	}
+}
+
+func TestGetQuote_ExpiredOrMissing(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	repo := &MockQuoteRepository{quotes: []domain.Quote{
+		{ID: "expired", RequestBody: "{}", ResultBody: "{}", CreatedAt: now.Add(-25 * time.Hour).UnixMilli(), ExpiresAt: now.Add(-time.Hour).UnixMilli()},
+	}}
+	usecase := &quoteUsecase{repo: repo, now: func() time.Time { return now }}
+
+	for _, id := range []string{"expired", "missing"} {
+		if _, err := usecase.GetQuote(id); err == nil {
+			t.Errorf("%s: expected an error", id)
+		} else if _, ok := err.(*NotFoundError); !ok {
+			t.Errorf("%s: expected NotFoundError, got %T", id, err)
+		}
+	}
+}
+
+func TestListQuotes_Filter(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	repo := &MockQuoteRepository{quotes: []domain.Quote{
+		{ID: "a", Channel: "web", FinancedAmount: 5000000, CreatedAt: now.UnixMilli(), ExpiresAt: now.Add(time.Hour).UnixMilli()},
+	}}
+	usecase := &quoteUsecase{repo: repo, now: func() time.Time { return now }}
+
+	resp, err := usecase.ListQuotes(&domain.ListQuotesRequest{From: "2026-10-01", To: "2026-10-18", Channel: "web"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := domain.QuoteFilter{
+		From:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC).UnixMilli(),
+		To:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC).UnixMilli(),
+		Channel: "web",
+		Now:     now.UnixMilli(),
+		Limit:   DefaultQuoteListLimit,
+	}
+	if repo.filter != expected {
+		t.Errorf("Expected filter %+v, got %+v", expected, repo.filter)
+	}
+	if len(resp.Quotes) != 1 || resp.Quotes[0].CreatedAt != "2026-10-18T09:00:00Z" {
+		t.Errorf("Expected one quote created at 2026-10-18T09:00:00Z, got %+v", resp.Quotes)
+	}
+
+	requests := []domain.ListQuotesRequest{
+		{From: "2026-10-18", To: "2026-10-01"},
+		{From: "18-10-2026"},
+		{Limit: MaxQuoteListLimit + 1},
+	}
+	for _, req := range requests {
+		if _, err := usecase.ListQuotes(&req); err == nil {
+			t.Errorf("Expected validation error for %+v", req)
+		}
+	}
+}
+
+func TestPurgeExpiredQuotes(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	repo := &MockQuoteRepository{quotes: []domain.Quote{
+		{ID: "expired", ExpiresAt: now.Add(-time.Minute).UnixMilli()},
+		{ID: "live", ExpiresAt: now.Add(time.Minute).UnixMilli()},
+	}}
+	usecase := &quoteUsecase{repo: repo, now: func() time.Time { return now }}
+
+	deleted, err := usecase.PurgeExpiredQuotes()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if deleted != 1 || len(repo.quotes) != 1 || repo.quotes[0].ID != "live" {
+		t.Errorf("Expected only the expired quote deleted, got %d deleted and %+v kept", deleted, repo.quotes)
+	}
+}
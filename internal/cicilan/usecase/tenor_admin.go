@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
+)
+
+type tenorAdminUsecase struct {
+	repo cicilan.TenorRepository
+}
+
+func NewTenorAdminUsecase(repo cicilan.TenorRepository) cicilan.TenorAdminUsecase {
+	return &tenorAdminUsecase{repo: repo}
+}
+
+// ListTenors returns the tenors available on as_of (today by default) or,
+// with include_inactive, the whole tenor master.
+func (u *tenorAdminUsecase) ListTenors(req *domain.ListTenorsRequest) (*domain.ListTenorsResponse, error) {
+	asOf, err := parseDate(req.AsOf, "as_of", today())
+	if err != nil {
+		return nil, err
+	}
+
+	tenors, err := u.repo.ListTenors()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.ListTenorsResponse{Tenors: []domain.TenorResponse{}}
+	if !req.IncludeInactive {
+		response.AsOf = asOf.Format(dateLayout)
+	}
+	for _, tenor := range tenors {
+		if req.IncludeInactive || tenor.AvailableOn(asOf) {
+			response.Tenors = append(response.Tenors, tenorResponse(tenor))
+		}
+	}
+	return response, nil
+}
+
+func (u *tenorAdminUsecase) CreateTenor(req *domain.TenorRequest) (*domain.TenorResponse, error) {
+	tenor := domain.Tenor{Active: true}
+	if err := u.applyTenorRequest(&tenor, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.CreateTenor(&tenor); err != nil {
+		if errors.Is(err, cicilan.ErrDuplicate) {
+			return nil, tenorExists(tenor.TenorValue)
+		}
+		return nil, err
+	}
+	response := tenorResponse(tenor)
+	return &response, nil
+}
+
+// UpdateTenor replaces every field of the tenor; active is kept when omitted.
+func (u *tenorAdminUsecase) UpdateTenor(id int64, req *domain.TenorRequest) (*domain.TenorResponse, error) {
+	tenor, err := u.findTenor(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyTenorRequest(tenor, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.UpdateTenor(tenor); err != nil {
+		if errors.Is(err, cicilan.ErrDuplicate) {
+			return nil, tenorExists(tenor.TenorValue)
+		}
+		return nil, err
+	}
+	response := tenorResponse(*tenor)
+	return &response, nil
+}
+
+// DeactivateTenor withdraws a tenor from new calculations. The row is kept
+// so it can be reactivated and so existing contracts stay explainable.
+func (u *tenorAdminUsecase) DeactivateTenor(id int64) (*domain.TenorResponse, error) {
+	tenor, err := u.findTenor(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenor.Active {
+		tenor.Active = false
+		if err := u.repo.UpdateTenor(tenor); err != nil {
+			return nil, err
+		}
+	}
+	response := tenorResponse(*tenor)
+	return &response, nil
+}
+
+func (u *tenorAdminUsecase) findTenor(id int64) (*domain.Tenor, error) {
+	tenor, err := u.repo.GetTenorByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if tenor == nil {
+		return nil, &NotFoundError{Message: fmt.Sprintf("tenor %d not found", id)}
+	}
+	return tenor, nil
+}
+
+// applyTenorRequest validates req and copies it onto tenor. Both margin rates
+// are required, so that no tenor is silently priced at the default rate.
+func (u *tenorAdminUsecase) applyTenorRequest(tenor *domain.Tenor, req *domain.TenorRequest) error {
+	if req.TenorValue <= 0 {
+		return &ValidationError{Field: "tenor_value", Message: "tenor_value must be greater than 0"}
+	}
+	if req.FlatMarginRate == nil || req.EffectiveMarginRate == nil {
+		return &ValidationError{Field: "margin_rate", Message: "flat_margin_rate and effective_margin_rate are required"}
+	}
+	for _, rate := range []*float64{req.FlatMarginRate, req.EffectiveMarginRate} {
+		if rate != nil && (*rate < 0 || *rate > 1) {
+			return &ValidationError{Field: "margin_rate", Message: "margin rates must be between 0 and 1"}
+		}
+	}
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return &ValidationError{Field: "min_amount", Message: "min_amount must not exceed max_amount"}
+	}
+
+	frequencies := make([]string, 0, len(req.PaymentFrequencies))
+	for _, frequency := range req.PaymentFrequencies {
+		if _, ok := periodsPerYear[frequency]; !ok {
+			return &ValidationError{Field: "payment_frequencies", Message: fmt.Sprintf("unsupported payment frequency: %s", frequency)}
+		}
+		frequencies = append(frequencies, frequency)
+	}
+
+	effectiveFrom, err := parseOptionalDate(req.EffectiveFrom, "effective_from")
+	if err != nil {
+		return err
+	}
+	effectiveTo, err := parseOptionalDate(req.EffectiveTo, "effective_to")
+	if err != nil {
+		return err
+	}
+	if effectiveFrom != nil && effectiveTo != nil && effectiveTo.Before(*effectiveFrom) {
+		return &ValidationError{Field: "effective_to", Message: "effective_to must not be before effective_from"}
+	}
+
+	active := tenor.Active
+	if req.Active != nil {
+		active = *req.Active
+	}
+	if active {
+		tenors, err := u.repo.ListTenors()
+		if err != nil {
+			return err
+		}
+		for _, existing := range tenors {
+			if existing.ID != tenor.ID && existing.Active && existing.TenorValue == req.TenorValue &&
+				windowsOverlap(existing.EffectiveFrom, existing.EffectiveTo, effectiveFrom, effectiveTo) {
+				return tenorExists(req.TenorValue)
+			}
+		}
+	}
+
+	tenor.TenorValue = req.TenorValue
+	tenor.FlatMarginRate = req.FlatMarginRate
+	tenor.EffectiveMarginRate = req.EffectiveMarginRate
+	tenor.PaymentFrequencies = strings.Join(frequencies, ",")
+	tenor.MinAmount = req.MinAmount
+	tenor.MaxAmount = req.MaxAmount
+	tenor.EffectiveFrom = effectiveFrom
+	tenor.EffectiveTo = effectiveTo
+	if req.Active != nil {
+		tenor.Active = *req.Active
+	}
+	return nil
+}
+
+// windowsOverlap reports whether two effective windows share a day; a nil
+// bound is open-ended.
+func windowsOverlap(fromA, toA, fromB, toB *time.Time) bool {
+	startsBeforeEnd := func(from, to *time.Time) bool {
+		return from == nil || to == nil || !from.After(*to)
+	}
+	return startsBeforeEnd(fromA, toB) && startsBeforeEnd(fromB, toA)
+}
+
+// tenorExists reports a tenor whose length is already offered in an
+// overlapping window.
+func tenorExists(tenorValue int) error {
+	return &ConflictError{
+		Field:   "tenor_value",
+		Code:    domain.CodeTenorExists,
+		Message: fmt.Sprintf("tenor %d is already offered in an overlapping effective window", tenorValue),
+	}
+}
+
+func parseOptionalDate(value, field string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := parseDate(value, field, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func tenorResponse(tenor domain.Tenor) domain.TenorResponse {
+	response := domain.TenorResponse{
+		ID:                  tenor.ID,
+		TenorValue:          tenor.TenorValue,
+		FlatMarginRate:      tenor.FlatMarginRate,
+		EffectiveMarginRate: tenor.EffectiveMarginRate,
+		PaymentFrequencies:  []string{domain.FrequencyMonthly},
+		MinAmount:           tenor.MinAmount,
+		MaxAmount:           tenor.MaxAmount,
+		Active:              tenor.Active,
+	}
+	if tenor.PaymentFrequencies != "" {
+		response.PaymentFrequencies = strings.Split(tenor.PaymentFrequencies, ",")
+	}
+	if tenor.EffectiveFrom != nil {
+		response.EffectiveFrom = tenor.EffectiveFrom.Format(dateLayout)
+	}
+	if tenor.EffectiveTo != nil {
+		response.EffectiveTo = tenor.EffectiveTo.Format(dateLayout)
+	}
+	return response
+}
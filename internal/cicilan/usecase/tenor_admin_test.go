@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"btpntest/domain"
+	"btpntest/internal/cicilan"
+)
+
+type MockTenorRepository struct {
+	tenors []domain.Tenor
+	nextID int64
+	err    error
+}
+
+func (m *MockTenorRepository) ListTenors() ([]domain.Tenor, error) {
+	return m.tenors, nil
+}
+
+func (m *MockTenorRepository) GetTenorByID(id int64) (*domain.Tenor, error) {
+	for _, tenor := range m.tenors {
+		if tenor.ID == id {
+			return &tenor, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockTenorRepository) CreateTenor(tenor *domain.Tenor) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.nextID++
+	tenor.ID = m.nextID
+	m.tenors = append(m.tenors, *tenor)
+	return nil
+}
+
+func (m *MockTenorRepository) UpdateTenor(tenor *domain.Tenor) error {
+	for i := range m.tenors {
+		if m.tenors[i].ID == tenor.ID {
+			m.tenors[i] = *tenor
+		}
+	}
+	return nil
+}
+
+func datePtr(value string) *time.Time {
+	date, _ := time.Parse(dateLayout, value)
+	return &date
+}
+
+func tenorMaster() *MockTenorRepository {
+	return &MockTenorRepository{nextID: 4, tenors: []domain.Tenor{
+		{ID: 1, TenorValue: 6, Active: true},
+		{ID: 2, TenorValue: 12, Active: true, EffectiveTo: datePtr("2026-12-31")},
+		{ID: 3, TenorValue: 24, Active: false},
+		{ID: 4, TenorValue: 48, Active: true, EffectiveFrom: datePtr("2026-11-01")},
+	}}
+}
+
+func TestListTenors_AsOf(t *testing.T) {
+	usecase := NewTenorAdminUsecase(tenorMaster())
+
+	tests := []struct {
+		asOf     string
+		expected []int
+	}{
+		{"2026-10-31", []int{6, 12}},
+		{"2026-11-01", []int{6, 12, 48}},
+		{"2026-12-31", []int{6, 12, 48}},
+		{"2027-01-01", []int{6, 48}},
+	}
+
+	for _, tt := range tests {
+		resp, err := usecase.ListTenors(&domain.ListTenorsRequest{AsOf: tt.asOf})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.asOf, err)
+		}
+		if resp.AsOf != tt.asOf || len(resp.Tenors) != len(tt.expected) {
+			t.Fatalf("%s: expected tenors %v, got %+v", tt.asOf, tt.expected, resp)
+		}
+		for i, tenor := range tt.expected {
+			if resp.Tenors[i].TenorValue != tenor {
+				t.Errorf("%s at index %d: expected tenor %d, got %d", tt.asOf, i, tenor, resp.Tenors[i].TenorValue)
+			}
+		}
+	}
+
+	resp, err := usecase.ListTenors(&domain.ListTenorsRequest{IncludeInactive: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Tenors) != 4 || resp.AsOf != "" {
+		t.Errorf("Expected the whole master without a date, got %+v", resp)
+	}
+}
+
+func TestCreateTenor(t *testing.T) {
+	repo := tenorMaster()
+	usecase := NewTenorAdminUsecase(repo)
+
+	rate := 0.25
+	resp, err := usecase.CreateTenor(&domain.TenorRequest{
+		TenorValue:          60,
+		FlatMarginRate:      &rate,
+		EffectiveMarginRate: &rate,
+		PaymentFrequencies:  []string{domain.FrequencyMonthly, domain.FrequencyBiWeekly},
+		EffectiveFrom:       "2027-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.ID != 5 || !resp.Active || resp.EffectiveFrom != "2027-01-01" || resp.EffectiveTo != "" {
+		t.Errorf("Expected an active tenor 5 from 2027-01-01, got %+v", resp)
+	}
+	if repo.tenors[4].PaymentFrequencies != "monthly,biweekly" {
+		t.Errorf("Expected frequencies stored as monthly,biweekly, got %s", repo.tenors[4].PaymentFrequencies)
+	}
+
+	// Without its rates the tenor would be priced at the default rate.
+	_, err = usecase.CreateTenor(&domain.TenorRequest{TenorValue: 72, FlatMarginRate: &rate})
+	if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "margin_rate" {
+		t.Errorf("Expected a margin_rate validation error for a tenor without rates, got %v", err)
+	}
+
+	_, err = usecase.CreateTenor(&domain.TenorRequest{TenorValue: 12, FlatMarginRate: &rate, EffectiveMarginRate: &rate})
+	if conflictErr, ok := err.(*ConflictError); !ok || conflictErr.Code != domain.CodeTenorExists {
+		t.Errorf("Expected %s for a duplicate tenor, got %v", domain.CodeTenorExists, err)
+	}
+}
+
+func TestCreateTenor_DatedSuccessor(t *testing.T) {
+	repo := tenorMaster()
+	usecase := NewTenorAdminUsecase(repo)
+	rate := 0.21
+
+	// Tenor 12 ends on 2026-12-31, so its successor may start the next day.
+	resp, err := usecase.CreateTenor(&domain.TenorRequest{
+		TenorValue: 12, FlatMarginRate: &rate, EffectiveMarginRate: &rate, EffectiveFrom: "2027-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Expected the successor to be created, got %v", err)
+	}
+	if resp.TenorValue != 12 || resp.EffectiveFrom != "2027-01-01" {
+		t.Errorf("Expected tenor 12 from 2027-01-01, got %+v", resp)
+	}
+
+	overlapping := []domain.TenorRequest{
+		{TenorValue: 12, EffectiveFrom: "2026-12-31"},
+		{TenorValue: 12, EffectiveTo: "2027-06-30"},
+		{TenorValue: 48, EffectiveFrom: "2030-01-01"},
+		{TenorValue: 48, EffectiveTo: "2026-11-01"},
+	}
+	for _, req := range overlapping {
+		req.FlatMarginRate, req.EffectiveMarginRate = &rate, &rate
+		_, err := usecase.CreateTenor(&req)
+		if conflictErr, ok := err.(*ConflictError); !ok || conflictErr.Code != domain.CodeTenorExists {
+			t.Errorf("Expected %s for tenor %d from %q to %q, got %v", domain.CodeTenorExists, req.TenorValue, req.EffectiveFrom, req.EffectiveTo, err)
+		}
+	}
+
+	// An inactive tenor does not block a new one of the same length,
+	if _, err := usecase.CreateTenor(&domain.TenorRequest{TenorValue: 24, FlatMarginRate: &rate, EffectiveMarginRate: &rate}); err != nil {
+		t.Errorf("Expected a tenor replacing an inactive one to be created, got %v", err)
+	}
+	// Nor does one that ends the day before the existing tenor starts.
+	if _, err := usecase.CreateTenor(&domain.TenorRequest{TenorValue: 48, FlatMarginRate: &rate, EffectiveMarginRate: &rate, EffectiveTo: "2026-10-31"}); err != nil {
+		t.Errorf("Expected a predecessor of tenor 48 to be created, got %v", err)
+	}
+}
+
+func TestCreateTenor_DuplicateRace(t *testing.T) {
+	repo := tenorMaster()
+	repo.err = cicilan.ErrDuplicate
+	usecase := NewTenorAdminUsecase(repo)
+	rate := 0.25
+
+	// A concurrent create passed the overlap check first; the database rejects this one.
+	_, err := usecase.CreateTenor(&domain.TenorRequest{TenorValue: 60, FlatMarginRate: &rate, EffectiveMarginRate: &rate})
+	if conflictErr, ok := err.(*ConflictError); !ok || conflictErr.Code != domain.CodeTenorExists {
+		t.Errorf("Expected %s for a duplicate rejected by the database, got %v", domain.CodeTenorExists, err)
+	}
+}
+
+func TestUpdateAndDeactivateTenor(t *testing.T) {
+	repo := tenorMaster()
+	usecase := NewTenorAdminUsecase(repo)
+
+	active, rate := true, 0.22
+	resp, err := usecase.UpdateTenor(3, &domain.TenorRequest{
+		TenorValue:          24,
+		FlatMarginRate:      &rate,
+		EffectiveMarginRate: &rate,
+		Active:              &active,
+		EffectiveTo:         "2027-06-30",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !resp.Active || resp.EffectiveTo != "2027-06-30" || !repo.tenors[2].Active {
+		t.Errorf("Expected tenor 24 reactivated until 2027-06-30, got %+v", resp)
+	}
+	if resp.PaymentFrequencies[0] != domain.FrequencyMonthly {
+		t.Errorf("Expected monthly payments when none are listed, got %v", resp.PaymentFrequencies)
+	}
+
+	resp, err = usecase.DeactivateTenor(1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Active || repo.tenors[0].Active {
+		t.Error("Expected tenor 6 to be deactivated")
+	}
+
+	if _, err := usecase.DeactivateTenor(99); err == nil {
+		t.Error("Expected an error for an unknown tenor")
+	} else if _, ok := err.(*NotFoundError); !ok {
+		t.Errorf("Expected NotFoundError, got %T", err)
+	}
+}
+
+func TestTenorRequestValidation(t *testing.T) {
+	usecase := NewTenorAdminUsecase(tenorMaster())
+
+	minAmount, maxAmount, rate := int64(5000000), int64(1000000), 0.24
+	requests := []domain.TenorRequest{
+		{TenorValue: 60, PaymentFrequencies: []string{"daily"}},
+		{TenorValue: 60, MinAmount: &minAmount, MaxAmount: &maxAmount},
+		{TenorValue: 60, EffectiveFrom: "2027-01-01", EffectiveTo: "2026-12-31"},
+		{TenorValue: 60, EffectiveFrom: "01-01-2027"},
+	}
+
+	for _, req := range requests {
+		req.FlatMarginRate, req.EffectiveMarginRate = &rate, &rate
+		if _, err := usecase.UpdateTenor(4, &req); err == nil {
+			t.Errorf("Expected validation error for %+v", req)
+		}
+	}
+
+	// Omitting a rate is rejected rather than falling back to the default
+	// rate.
+	for _, req := range []domain.TenorRequest{
+		{TenorValue: 48},
+		{TenorValue: 48, FlatMarginRate: &rate},
+		{TenorValue: 48, EffectiveMarginRate: &rate},
+	} {
+		_, err := usecase.UpdateTenor(4, &req)
+		if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "margin_rate" {
+			t.Errorf("Expected a margin_rate validation error for %+v, got %v", req, err)
+		}
+	}
+
+	// Keeping the tenor's own length is not a duplicate.
+	if _, err := usecase.UpdateTenor(4, &domain.TenorRequest{TenorValue: 48, FlatMarginRate: &rate, EffectiveMarginRate: &rate}); err != nil {
+		t.Errorf("Expected no error updating tenor 48 in place, got %v", err)
+	}
+}
+
+func TestCalculateSchedule_TenorsAsOfStartDate(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+
+	_, err := NewCicilanUsecase(mockRepo).CalculateSchedule(&domain.CalculateScheduleRequest{
+		Amount:    12000000,
+		Tenor:     12,
+		StartDate: "2027-02-01",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mockRepo.asOf.Format(dateLayout) != "2027-02-01" {
+		t.Errorf("Expected tenors as of the start date, got %s", mockRepo.asOf.Format(dateLayout))
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"btpntest/domain"
+)
+
+// tenorRange lets requests ask for tenors outside the tenor master, from min
+// to max months in steps of step months. A zero step disables the range.
+type tenorRange struct {
+	min, max, step int
+}
+
+// WithTenorRange allows tenors of minMonths to maxMonths months in steps of
+// stepMonths besides those in the tenor master.
+func WithTenorRange(minMonths, maxMonths, stepMonths int) Option {
+	return func(u *cicilanUsecase) {
+		u.tenorRange = tenorRange{min: minMonths, max: maxMonths, step: stepMonths}
+	}
+}
+
+// resolveTenor returns the master tenor of the given length or, when the
+// configured range allows it, a tenor priced like the next longer master
+// tenor (or the longest one).
+func (u *cicilanUsecase) resolveTenor(tenors []domain.Tenor, tenorValue int) (domain.Tenor, error) {
+	if tenor, ok := findTenor(tenors, tenorValue); ok {
+		return tenor, nil
+	}
+
+	allowed := u.tenorRange
+	if allowed.step <= 0 || len(tenors) == 0 {
+		return domain.Tenor{}, &ValidationError{
+			Field:   "tenor",
+			Code:    domain.CodeTenorNotAvailable,
+			Message: fmt.Sprintf("tenor %d is not available", tenorValue),
+		}
+	}
+	if tenorValue < allowed.min || tenorValue > allowed.max {
+		return domain.Tenor{}, &ValidationError{
+			Field:   "tenor",
+			Code:    domain.CodeTenorOutOfRange,
+			Message: fmt.Sprintf("tenor %d is outside the allowed range of %d to %d months", tenorValue, allowed.min, allowed.max),
+		}
+	}
+	if (tenorValue-allowed.min)%allowed.step != 0 {
+		return domain.Tenor{}, &ValidationError{
+			Field:   "tenor",
+			Code:    domain.CodeTenorOffStep,
+			Message: fmt.Sprintf("tenor %d must be %d months plus a multiple of %d months", tenorValue, allowed.min, allowed.step),
+		}
+	}
+
+	var next, longest *domain.Tenor
+	for i := range tenors {
+		candidate := &tenors[i]
+		if candidate.TenorValue > tenorValue && (next == nil || candidate.TenorValue < next.TenorValue) {
+			next = candidate
+		}
+		if longest == nil || candidate.TenorValue > longest.TenorValue {
+			longest = candidate
+		}
+	}
+
+	pricing := next
+	if pricing == nil {
+		pricing = longest
+	}
+
+	tenor := *pricing
+	tenor.ID = 0
+	tenor.TenorValue = tenorValue
+	return tenor, nil
+}
+
+// selectTenors narrows the tenor master to the requested tenors, in request
+// order and without duplicates. Requested tenors that cannot be resolved are
+// returned as exclusions; an empty request selects the whole master.
+func (u *cicilanUsecase) selectTenors(tenors []domain.Tenor, requested []int) ([]domain.Tenor, []domain.ExcludedTenor, error) {
+	excluded := []domain.ExcludedTenor{}
+	if len(requested) == 0 {
+		return tenors, excluded, nil
+	}
+
+	selected := make([]domain.Tenor, 0, len(requested))
+	seen := make(map[int]bool)
+	var reasons []string
+
+	for _, tenorValue := range requested {
+		if seen[tenorValue] {
+			continue
+		}
+		seen[tenorValue] = true
+
+		tenor, err := u.resolveTenor(tenors, tenorValue)
+		if err != nil {
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				return nil, nil, err
+			}
+			excluded = append(excluded, domain.ExcludedTenor{Tenor: tenorValue, Code: validationErr.Code, Reason: validationErr.Message})
+			reasons = append(reasons, validationErr.Message)
+			continue
+		}
+		selected = append(selected, tenor)
+	}
+
+	if len(selected) == 0 {
+		return nil, nil, &ValidationError{
+			Field:   "tenors",
+			Code:    domain.CodeTenorNotAvailable,
+			Message: fmt.Sprintf("none of the requested tenors is available: %s", strings.Join(reasons, "; ")),
+		}
+	}
+	return selected, excluded, nil
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"testing"
+
+	"btpntest/domain"
+)
+
+func rangedUsecase() *cicilanUsecase {
+	flat12, flat18, flat36 := 0.2, 0.21, 0.24
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{
+		{ID: 1, TenorValue: 12, FlatMarginRate: &flat12},
+		{ID: 2, TenorValue: 18, FlatMarginRate: &flat18},
+		{ID: 3, TenorValue: 36, FlatMarginRate: &flat36},
+	}}
+	return NewCicilanUsecase(mockRepo, WithTenorRange(6, 48, 3)).(*cicilanUsecase)
+}
+
+func TestCalculateInstallments_RequestedTenors(t *testing.T) {
+	usecase := rangedUsecase()
+
+	resp, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{
+		Amount: 12000000,
+		Tenors: []int{18, 15, 18, 16, 60, 42},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedAccepted := []int{18, 15, 42}
+	if len(resp.AcceptedTenors) != len(expectedAccepted) {
+		t.Fatalf("Expected accepted tenors %v, got %v", expectedAccepted, resp.AcceptedTenors)
+	}
+	for i, tenor := range expectedAccepted {
+		if resp.AcceptedTenors[i] != tenor || resp.Calculations[i].Tenor != tenor {
+			t.Errorf("At index %d: expected tenor %d, got %d/%d", i, tenor, resp.AcceptedTenors[i], resp.Calculations[i].Tenor)
+		}
+	}
+
+	// 15 months is priced like the next longer master tenor, 42 like the longest.
+	if resp.Calculations[1].AnnualMarginRate != 0.21 || resp.Calculations[1].InstallmentCount != 15 {
+		t.Errorf("Expected tenor 15 at 0.21 over 15 installments, got %+v", resp.Calculations[1])
+	}
+	if resp.Calculations[2].AnnualMarginRate != 0.24 {
+		t.Errorf("Expected tenor 42 at 0.24, got %v", resp.Calculations[2].AnnualMarginRate)
+	}
+
+	expectedRejected := []struct {
+		tenor int
+		code  string
+	}{
+		{16, domain.CodeTenorOffStep},
+		{60, domain.CodeTenorOutOfRange},
+	}
+	if len(resp.ExcludedTenors) != len(expectedRejected) {
+		t.Fatalf("Expected rejected tenors %v, got %+v", expectedRejected, resp.ExcludedTenors)
+	}
+	for i, expected := range expectedRejected {
+		if resp.ExcludedTenors[i].Tenor != expected.tenor || resp.ExcludedTenors[i].Code != expected.code {
+			t.Errorf("At index %d: expected tenor %d rejected with %s, got %+v", i, expected.tenor, expected.code, resp.ExcludedTenors[i])
+		}
+	}
+}
+
+func TestCalculateInstallments_NoRequestedTenorAvailable(t *testing.T) {
+	mockRepo := &MockCicilanRepository{tenors: []domain.Tenor{{ID: 1, TenorValue: 12}}}
+	usecase := NewCicilanUsecase(mockRepo)
+
+	_, err := usecase.CalculateInstallments(&domain.CalculateInstallmentRequest{Amount: 12000000, Tenors: []int{15}})
+
+	validationErr, ok := err.(*ValidationError)
+	if !ok || validationErr.Code != domain.CodeTenorNotAvailable {
+		t.Fatalf("Expected %s validation error, got %v", domain.CodeTenorNotAvailable, err)
+	}
+}
+
+func TestCalculateSchedule_RangeTenor(t *testing.T) {
+	usecase := rangedUsecase()
+
+	resp, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{Amount: 12000000, Tenor: 15})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Schedule) != 15 || resp.AnnualMarginRate != 0.21 {
+		t.Errorf("Expected 15 installments at 0.21, got %d at %v", len(resp.Schedule), resp.AnnualMarginRate)
+	}
+
+	if _, err := usecase.CalculateSchedule(&domain.CalculateScheduleRequest{Amount: 12000000, Tenor: 17}); err == nil {
+		t.Error("Expected validation error for a tenor off the range step")
+	}
+}
@@ -0,0 +1,152 @@
+package migration
+
+import "gorm.io/gorm"
+
+func migrateApplicationsForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Application{}) {
+		return nil
+	}
+
+	applicationsSQL := `
+	CREATE TABLE IF NOT EXISTS applications (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		quote_id VARCHAR(36) NOT NULL,
+		channel VARCHAR(32) NULL,
+		tenor INT NOT NULL,
+		pricing_version_id BIGINT NULL,
+		financed_amount BIGINT NOT NULL,
+		monthly_installment BIGINT NOT NULL,
+		total_payment BIGINT NOT NULL,
+		status VARCHAR(16) NOT NULL,
+		created_by VARCHAR(64) NOT NULL,
+		created_at BIGINT NOT NULL,
+		updated_at BIGINT NOT NULL,
+		KEY idx_applications_quote_id (quote_id),
+		KEY idx_applications_status (status)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	transitionsSQL := `
+	CREATE TABLE IF NOT EXISTS application_transitions (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		application_id BIGINT NOT NULL,
+		from_status VARCHAR(16) NULL,
+		to_status VARCHAR(16) NOT NULL,
+		actor VARCHAR(64) NOT NULL,
+		note VARCHAR(255) NULL,
+		created_at BIGINT NOT NULL,
+		KEY idx_application_transitions_application (application_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	return execAll(db, applicationsSQL, transitionsSQL)
+}
+
+func migrateApplicationsForPostgreSQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Application{}) {
+		return nil
+	}
+
+	applicationsSQL := `
+	CREATE TABLE IF NOT EXISTS applications (
+		id BIGSERIAL PRIMARY KEY,
+		quote_id VARCHAR(36) NOT NULL,
+		channel VARCHAR(32) NULL,
+		tenor INT NOT NULL,
+		pricing_version_id BIGINT NULL,
+		financed_amount BIGINT NOT NULL,
+		monthly_installment BIGINT NOT NULL,
+		total_payment BIGINT NOT NULL,
+		status VARCHAR(16) NOT NULL,
+		created_by VARCHAR(64) NOT NULL,
+		created_at BIGINT NOT NULL,
+		updated_at BIGINT NOT NULL
+	);
+	`
+	transitionsSQL := `
+	CREATE TABLE IF NOT EXISTS application_transitions (
+		id BIGSERIAL PRIMARY KEY,
+		application_id BIGINT NOT NULL,
+		from_status VARCHAR(16) NULL,
+		to_status VARCHAR(16) NOT NULL,
+		actor VARCHAR(64) NOT NULL,
+		note VARCHAR(255) NULL,
+		created_at BIGINT NOT NULL
+	);
+	`
+	return execAll(db,
+		applicationsSQL,
+		`CREATE INDEX IF NOT EXISTS idx_applications_quote_id ON applications (quote_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_applications_status ON applications (status);`,
+		transitionsSQL,
+		`CREATE INDEX IF NOT EXISTS idx_application_transitions_application ON application_transitions (application_id);`,
+	)
+}
+
+func migrateApplicationsForSQLServer(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Application{}) {
+		return nil
+	}
+
+	applicationsSQL := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='applications' AND xtype='U')
+	CREATE TABLE applications (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		quote_id VARCHAR(36) NOT NULL,
+		channel VARCHAR(32) NULL,
+		tenor INT NOT NULL,
+		pricing_version_id BIGINT NULL,
+		financed_amount BIGINT NOT NULL,
+		monthly_installment BIGINT NOT NULL,
+		total_payment BIGINT NOT NULL,
+		status VARCHAR(16) NOT NULL,
+		created_by VARCHAR(64) NOT NULL,
+		created_at BIGINT NOT NULL,
+		updated_at BIGINT NOT NULL,
+		INDEX idx_applications_quote_id (quote_id),
+		INDEX idx_applications_status (status)
+	);
+	`
+	transitionsSQL := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='application_transitions' AND xtype='U')
+	CREATE TABLE application_transitions (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		application_id BIGINT NOT NULL,
+		from_status VARCHAR(16) NULL,
+		to_status VARCHAR(16) NOT NULL,
+		actor VARCHAR(64) NOT NULL,
+		note VARCHAR(255) NULL,
+		created_at BIGINT NOT NULL,
+		INDEX idx_application_transitions_application (application_id)
+	);
+	`
+	return execAll(db, applicationsSQL, transitionsSQL)
+}
+
+// execAll runs the statements in order, one per call, since not every
+// driver accepts several statements at once.
+func execAll(db *gorm.DB, statements ...string) error {
+	for _, sql := range statements {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Application struct {
+	ID                 int64  `gorm:"primaryKey"`
+	QuoteID            string `gorm:"column:quote_id;not null"`
+	Channel            string `gorm:"column:channel"`
+	Tenor              int    `gorm:"column:tenor;not null"`
+	PricingVersionID   int64  `gorm:"column:pricing_version_id"`
+	FinancedAmount     int64  `gorm:"column:financed_amount;not null"`
+	MonthlyInstallment int64  `gorm:"column:monthly_installment;not null"`
+	TotalPayment       int64  `gorm:"column:total_payment;not null"`
+	Status             string `gorm:"column:status;not null"`
+	CreatedBy          string `gorm:"column:created_by;not null"`
+	CreatedAt          int64  `gorm:"column:created_at;not null"`
+	UpdatedAt          int64  `gorm:"column:updated_at;not null"`
+}
+
+func (Application) TableName() string {
+	return "applications"
+}
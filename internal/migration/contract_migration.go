@@ -0,0 +1,253 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var contractColumnUpgrades = []tableColumn{
+	{name: "capitalized_margin", definition: "BIGINT NOT NULL DEFAULT 0"},
+}
+
+// upgradeContractTable adds missing columns. Ledgers opened before
+// capitalized margin was recorded kept it as negative principal on the
+// grace installments, so it is moved to the contract.
+func upgradeContractTable(db *gorm.DB, addColumnFormat string) error {
+	added, err := addMissingColumns(db, &Contract{}, contractColumnUpgrades, addColumnFormat)
+	if err != nil || !added["capitalized_margin"] {
+		return err
+	}
+
+	backfillCapitalizedSQL := `
+	UPDATE contracts SET capitalized_margin = (
+		SELECT COALESCE(-SUM(principal_due), 0) FROM contract_installments
+		WHERE contract_installments.contract_id = contracts.id AND principal_due < 0
+	);
+	`
+	// margin_due is set first: MySQL assigns left to right.
+	clearGraceSQL := `
+	UPDATE contract_installments SET margin_due = margin_due + principal_due, principal_due = 0
+	WHERE principal_due < 0;
+	`
+	return execAll(db, backfillCapitalizedSQL, clearGraceSQL)
+}
+
+func migrateContractsForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Contract{}) {
+		return upgradeContractTable(db, "ALTER TABLE contracts ADD COLUMN %s %s")
+	}
+
+	contractsSQL := `
+	CREATE TABLE IF NOT EXISTS contracts (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		application_id BIGINT NOT NULL,
+		allocation_order VARCHAR(32) NOT NULL,
+		disbursement_date DATE NOT NULL,
+		financed_amount BIGINT NOT NULL,
+		capitalized_margin BIGINT NOT NULL DEFAULT 0,
+		credit_balance BIGINT NOT NULL DEFAULT 0,
+		status VARCHAR(16) NOT NULL,
+		version BIGINT NOT NULL DEFAULT 0,
+		created_at BIGINT NOT NULL,
+		updated_at BIGINT NOT NULL,
+		UNIQUE KEY unique_contract_application (application_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	installmentsSQL := `
+	CREATE TABLE IF NOT EXISTS contract_installments (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		contract_id BIGINT NOT NULL,
+		installment_number INT NOT NULL,
+		due_date DATE NOT NULL,
+		principal_due BIGINT NOT NULL,
+		margin_due BIGINT NOT NULL,
+		charge_due BIGINT NOT NULL DEFAULT 0,
+		principal_paid BIGINT NOT NULL DEFAULT 0,
+		margin_paid BIGINT NOT NULL DEFAULT 0,
+		charge_paid BIGINT NOT NULL DEFAULT 0,
+		paid_on DATE NULL,
+		UNIQUE KEY unique_contract_installment (contract_id, installment_number)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	paymentsSQL := `
+	CREATE TABLE IF NOT EXISTS contract_payments (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		contract_id BIGINT NOT NULL,
+		reference VARCHAR(64) NOT NULL,
+		amount BIGINT NOT NULL,
+		paid_on DATE NOT NULL,
+		allocated BIGINT NOT NULL,
+		excess BIGINT NOT NULL,
+		actor VARCHAR(64) NOT NULL,
+		created_at BIGINT NOT NULL,
+		UNIQUE KEY unique_contract_payment_reference (contract_id, reference)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	allocationsSQL := `
+	CREATE TABLE IF NOT EXISTS payment_allocations (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		payment_id BIGINT NOT NULL,
+		installment_number INT NOT NULL,
+		component VARCHAR(16) NOT NULL,
+		amount BIGINT NOT NULL,
+		KEY idx_payment_allocations_payment (payment_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	return execAll(db, contractsSQL, installmentsSQL, paymentsSQL, allocationsSQL)
+}
+
+func migrateContractsForPostgreSQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Contract{}) {
+		return upgradeContractTable(db, "ALTER TABLE contracts ADD COLUMN IF NOT EXISTS %s %s")
+	}
+
+	contractsSQL := `
+	CREATE TABLE IF NOT EXISTS contracts (
+		id BIGSERIAL PRIMARY KEY,
+		application_id BIGINT NOT NULL,
+		allocation_order VARCHAR(32) NOT NULL,
+		disbursement_date DATE NOT NULL,
+		financed_amount BIGINT NOT NULL,
+		capitalized_margin BIGINT NOT NULL DEFAULT 0,
+		credit_balance BIGINT NOT NULL DEFAULT 0,
+		status VARCHAR(16) NOT NULL,
+		version BIGINT NOT NULL DEFAULT 0,
+		created_at BIGINT NOT NULL,
+		updated_at BIGINT NOT NULL,
+		UNIQUE (application_id)
+	);
+	`
+	installmentsSQL := `
+	CREATE TABLE IF NOT EXISTS contract_installments (
+		id BIGSERIAL PRIMARY KEY,
+		contract_id BIGINT NOT NULL,
+		installment_number INT NOT NULL,
+		due_date DATE NOT NULL,
+		principal_due BIGINT NOT NULL,
+		margin_due BIGINT NOT NULL,
+		charge_due BIGINT NOT NULL DEFAULT 0,
+		principal_paid BIGINT NOT NULL DEFAULT 0,
+		margin_paid BIGINT NOT NULL DEFAULT 0,
+		charge_paid BIGINT NOT NULL DEFAULT 0,
+		paid_on DATE NULL,
+		UNIQUE (contract_id, installment_number)
+	);
+	`
+	paymentsSQL := `
+	CREATE TABLE IF NOT EXISTS contract_payments (
+		id BIGSERIAL PRIMARY KEY,
+		contract_id BIGINT NOT NULL,
+		reference VARCHAR(64) NOT NULL,
+		amount BIGINT NOT NULL,
+		paid_on DATE NOT NULL,
+		allocated BIGINT NOT NULL,
+		excess BIGINT NOT NULL,
+		actor VARCHAR(64) NOT NULL,
+		created_at BIGINT NOT NULL,
+		UNIQUE (contract_id, reference)
+	);
+	`
+	allocationsSQL := `
+	CREATE TABLE IF NOT EXISTS payment_allocations (
+		id BIGSERIAL PRIMARY KEY,
+		payment_id BIGINT NOT NULL,
+		installment_number INT NOT NULL,
+		component VARCHAR(16) NOT NULL,
+		amount BIGINT NOT NULL
+	);
+	`
+	return execAll(db,
+		contractsSQL,
+		installmentsSQL,
+		paymentsSQL,
+		allocationsSQL,
+		`CREATE INDEX IF NOT EXISTS idx_payment_allocations_payment ON payment_allocations (payment_id);`,
+	)
+}
+
+func migrateContractsForSQLServer(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Contract{}) {
+		return upgradeContractTable(db, "ALTER TABLE contracts ADD %s %s")
+	}
+
+	contractsSQL := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='contracts' AND xtype='U')
+	CREATE TABLE contracts (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		application_id BIGINT NOT NULL,
+		allocation_order VARCHAR(32) NOT NULL,
+		disbursement_date DATE NOT NULL,
+		financed_amount BIGINT NOT NULL,
+		capitalized_margin BIGINT NOT NULL DEFAULT 0,
+		credit_balance BIGINT NOT NULL DEFAULT 0,
+		status VARCHAR(16) NOT NULL,
+		version BIGINT NOT NULL DEFAULT 0,
+		created_at BIGINT NOT NULL,
+		updated_at BIGINT NOT NULL,
+		CONSTRAINT unique_contract_application UNIQUE (application_id)
+	);
+	`
+	installmentsSQL := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='contract_installments' AND xtype='U')
+	CREATE TABLE contract_installments (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		contract_id BIGINT NOT NULL,
+		installment_number INT NOT NULL,
+		due_date DATE NOT NULL,
+		principal_due BIGINT NOT NULL,
+		margin_due BIGINT NOT NULL,
+		charge_due BIGINT NOT NULL DEFAULT 0,
+		principal_paid BIGINT NOT NULL DEFAULT 0,
+		margin_paid BIGINT NOT NULL DEFAULT 0,
+		charge_paid BIGINT NOT NULL DEFAULT 0,
+		paid_on DATE NULL,
+		CONSTRAINT unique_contract_installment UNIQUE (contract_id, installment_number)
+	);
+	`
+	paymentsSQL := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='contract_payments' AND xtype='U')
+	CREATE TABLE contract_payments (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		contract_id BIGINT NOT NULL,
+		reference VARCHAR(64) NOT NULL,
+		amount BIGINT NOT NULL,
+		paid_on DATE NOT NULL,
+		allocated BIGINT NOT NULL,
+		excess BIGINT NOT NULL,
+		actor VARCHAR(64) NOT NULL,
+		created_at BIGINT NOT NULL,
+		CONSTRAINT unique_contract_payment_reference UNIQUE (contract_id, reference)
+	);
+	`
+	allocationsSQL := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='payment_allocations' AND xtype='U')
+	CREATE TABLE payment_allocations (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		payment_id BIGINT NOT NULL,
+		installment_number INT NOT NULL,
+		component VARCHAR(16) NOT NULL,
+		amount BIGINT NOT NULL,
+		INDEX idx_payment_allocations_payment (payment_id)
+	);
+	`
+	return execAll(db, contractsSQL, installmentsSQL, paymentsSQL, allocationsSQL)
+}
+
+type Contract struct {
+	ID                int64     `gorm:"primaryKey"`
+	ApplicationID     int64     `gorm:"column:application_id;not null"`
+	AllocationOrder   string    `gorm:"column:allocation_order;not null"`
+	DisbursementDate  time.Time `gorm:"column:disbursement_date;type:date;not null"`
+	FinancedAmount    int64     `gorm:"column:financed_amount;not null"`
+	CapitalizedMargin int64     `gorm:"column:capitalized_margin;not null"`
+	CreditBalance     int64     `gorm:"column:credit_balance;not null"`
+	Status            string    `gorm:"column:status;not null"`
+	Version           int64     `gorm:"column:version;not null"`
+	CreatedAt         int64     `gorm:"column:created_at;not null"`
+	UpdatedAt         int64     `gorm:"column:updated_at;not null"`
+}
+
+func (Contract) TableName() string {
+	return "contracts"
+}
@@ -6,45 +6,140 @@ import (
 	"gorm.io/gorm"
 )
 
+type tableColumn struct {
+	name       string
+	definition string
+}
+
+var tenorColumnUpgrades = []tableColumn{
+	{name: "flat_margin_rate", definition: "DECIMAL(7,4) NULL"},
+	{name: "effective_margin_rate", definition: "DECIMAL(7,4) NULL"},
+	{name: "payment_frequencies", definition: "VARCHAR(64) NULL"},
+	{name: "min_amount", definition: "BIGINT NULL"},
+	{name: "max_amount", definition: "BIGINT NULL"},
+	{name: "effective_from", definition: "DATE NULL"},
+	{name: "effective_to", definition: "DATE NULL"},
+}
+
 func RunMigrationForMySQL(db *gorm.DB) error {
+	if err := migrateTenorsForMySQL(db); err != nil {
+		return err
+	}
+	if err := migratePricingHistoryForMySQL(db); err != nil {
+		return err
+	}
+	if err := migrateLateChargeRulesForMySQL(db); err != nil {
+		return err
+	}
+	if err := migrateHolidaysForMySQL(db); err != nil {
+		return err
+	}
+	if err := migrateProductsForMySQL(db); err != nil {
+		return err
+	}
+	if err := migratePricingVersionProductsForMySQL(db); err != nil {
+		return err
+	}
+	if err := migrateQuotesForMySQL(db); err != nil {
+		return err
+	}
+	if err := migrateApplicationsForMySQL(db); err != nil {
+		return err
+	}
+	return migrateContractsForMySQL(db)
+}
+
+func migrateTenorsForMySQL(db *gorm.DB) error {
 	if db.Migrator().HasTable(&Tenor{}) {
-		return nil
+		if err := upgradeTenorTable(db, "ALTER TABLE tenors ADD COLUMN %s %s", "BOOLEAN NOT NULL DEFAULT TRUE"); err != nil {
+			return err
+		}
+		return migrateTenorKeyForMySQL(db)
 	}
 
 	sql := `
 	CREATE TABLE IF NOT EXISTS tenors (
 		id BIGINT AUTO_INCREMENT PRIMARY KEY,
 		tenor_value INT NOT NULL,
+		flat_margin_rate DECIMAL(7,4) NULL,
+		effective_margin_rate DECIMAL(7,4) NULL,
+		payment_frequencies VARCHAR(64) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		active BOOLEAN NOT NULL DEFAULT TRUE,
+		effective_from DATE NULL,
+		effective_to DATE NULL,
 		created_at BIGINT DEFAULT 0,
-		updated_at BIGINT DEFAULT 0,
-		UNIQUE KEY unique_tenor_value (tenor_value)
+		updated_at BIGINT DEFAULT 0
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 	`
 	if err := db.Exec(sql).Error; err != nil {
 		return err
 	}
+	if err := migrateTenorKeyForMySQL(db); err != nil {
+		return err
+	}
 
 	seedSQL := `
-	INSERT IGNORE INTO tenors (tenor_value, created_at, updated_at) VALUES
-	(6, 0, 0),
-	(12, 0, 0),
-	(18, 0, 0),
-	(24, 0, 0),
-	(30, 0, 0),
-	(36, 0, 0);
+	INSERT INTO tenors (tenor_value, flat_margin_rate, effective_margin_rate, payment_frequencies, min_amount, created_at, updated_at) VALUES
+	(6, 0.18, 0.18, 'monthly,biweekly,weekly', NULL, 0, 0),
+	(12, 0.20, 0.20, 'monthly,biweekly,weekly', NULL, 0, 0),
+	(18, 0.20, 0.20, 'monthly,biweekly', 3000000, 0, 0),
+	(24, 0.22, 0.22, 'monthly,biweekly', 3000000, 0, 0),
+	(30, 0.22, 0.22, 'monthly', 3000000, 0, 0),
+	(36, 0.24, 0.24, 'monthly', 3000000, 0, 0);
 	`
 	return db.Exec(seedSQL).Error
 }
 
 func RunMigrationForPostgreSQL(db *gorm.DB) error {
+	if err := migrateTenorsForPostgreSQL(db); err != nil {
+		return err
+	}
+	if err := migratePricingHistoryForPostgreSQL(db); err != nil {
+		return err
+	}
+	if err := migrateLateChargeRulesForPostgreSQL(db); err != nil {
+		return err
+	}
+	if err := migrateHolidaysForPostgreSQL(db); err != nil {
+		return err
+	}
+	if err := migrateProductsForPostgreSQL(db); err != nil {
+		return err
+	}
+	if err := migratePricingVersionProductsForPostgreSQL(db); err != nil {
+		return err
+	}
+	if err := migrateQuotesForPostgreSQL(db); err != nil {
+		return err
+	}
+	if err := migrateApplicationsForPostgreSQL(db); err != nil {
+		return err
+	}
+	return migrateContractsForPostgreSQL(db)
+}
+
+func migrateTenorsForPostgreSQL(db *gorm.DB) error {
 	if db.Migrator().HasTable(&Tenor{}) {
-		return nil
+		if err := upgradeTenorTable(db, "ALTER TABLE tenors ADD COLUMN IF NOT EXISTS %s %s", "BOOLEAN NOT NULL DEFAULT TRUE"); err != nil {
+			return err
+		}
+		return migrateTenorKeyForPostgreSQL(db)
 	}
 
 	sql := `
 	CREATE TABLE IF NOT EXISTS tenors (
 		id BIGSERIAL PRIMARY KEY,
-		tenor_value INT NOT NULL UNIQUE,
+		tenor_value INT NOT NULL,
+		flat_margin_rate NUMERIC(7,4) NULL,
+		effective_margin_rate NUMERIC(7,4) NULL,
+		payment_frequencies VARCHAR(64) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		active BOOLEAN NOT NULL DEFAULT TRUE,
+		effective_from DATE NULL,
+		effective_to DATE NULL,
 		created_at BIGINT DEFAULT 0,
 		updated_at BIGINT DEFAULT 0
 	);
@@ -52,30 +147,71 @@ func RunMigrationForPostgreSQL(db *gorm.DB) error {
 	if err := db.Exec(sql).Error; err != nil {
 		return err
 	}
+	if err := migrateTenorKeyForPostgreSQL(db); err != nil {
+		return err
+	}
 
 	seedSQL := `
-	INSERT INTO tenors (tenor_value, created_at, updated_at) VALUES
-	(6, 0, 0),
-	(12, 0, 0),
-	(18, 0, 0),
-	(24, 0, 0),
-	(30, 0, 0),
-	(36, 0, 0)
-	ON CONFLICT (tenor_value) DO NOTHING;
+	INSERT INTO tenors (tenor_value, flat_margin_rate, effective_margin_rate, payment_frequencies, min_amount, created_at, updated_at) VALUES
+	(6, 0.18, 0.18, 'monthly,biweekly,weekly', NULL, 0, 0),
+	(12, 0.20, 0.20, 'monthly,biweekly,weekly', NULL, 0, 0),
+	(18, 0.20, 0.20, 'monthly,biweekly', 3000000, 0, 0),
+	(24, 0.22, 0.22, 'monthly,biweekly', 3000000, 0, 0),
+	(30, 0.22, 0.22, 'monthly', 3000000, 0, 0),
+	(36, 0.24, 0.24, 'monthly', 3000000, 0, 0);
 	`
 	return db.Exec(seedSQL).Error
 }
 
 func RunMigrationForSQLServer(db *gorm.DB) error {
+	if err := migrateTenorsForSQLServer(db); err != nil {
+		return err
+	}
+	if err := migratePricingHistoryForSQLServer(db); err != nil {
+		return err
+	}
+	if err := migrateLateChargeRulesForSQLServer(db); err != nil {
+		return err
+	}
+	if err := migrateHolidaysForSQLServer(db); err != nil {
+		return err
+	}
+	if err := migrateProductsForSQLServer(db); err != nil {
+		return err
+	}
+	if err := migratePricingVersionProductsForSQLServer(db); err != nil {
+		return err
+	}
+	if err := migrateQuotesForSQLServer(db); err != nil {
+		return err
+	}
+	if err := migrateApplicationsForSQLServer(db); err != nil {
+		return err
+	}
+	return migrateContractsForSQLServer(db)
+}
+
+func migrateTenorsForSQLServer(db *gorm.DB) error {
 	if db.Migrator().HasTable(&Tenor{}) {
-		return nil
+		if err := upgradeTenorTable(db, "ALTER TABLE tenors ADD %s %s", "BIT NOT NULL DEFAULT 1"); err != nil {
+			return err
+		}
+		return migrateTenorKeyForSQLServer(db)
 	}
 
 	sql := `
 	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='tenors' AND xtype='U')
 	CREATE TABLE tenors (
 		id BIGINT PRIMARY KEY IDENTITY(1,1),
-		tenor_value INT NOT NULL UNIQUE,
+		tenor_value INT NOT NULL,
+		flat_margin_rate DECIMAL(7,4) NULL,
+		effective_margin_rate DECIMAL(7,4) NULL,
+		payment_frequencies VARCHAR(64) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		active BIT NOT NULL DEFAULT 1,
+		effective_from DATE NULL,
+		effective_to DATE NULL,
 		created_at BIGINT DEFAULT 0,
 		updated_at BIGINT DEFAULT 0
 	);
@@ -83,18 +219,142 @@ func RunMigrationForSQLServer(db *gorm.DB) error {
 	if err := db.Exec(sql).Error; err != nil {
 		return err
 	}
+	if err := migrateTenorKeyForSQLServer(db); err != nil {
+		return err
+	}
 
 	seedSQL := `
 	MERGE INTO tenors AS target
-	USING (VALUES (6), (12), (18), (24), (30), (36)) AS source(tenor_value)
+	USING (VALUES
+		(6, 0.18, 0.18, 'monthly,biweekly,weekly', NULL),
+		(12, 0.20, 0.20, 'monthly,biweekly,weekly', NULL),
+		(18, 0.20, 0.20, 'monthly,biweekly', 3000000),
+		(24, 0.22, 0.22, 'monthly,biweekly', 3000000),
+		(30, 0.22, 0.22, 'monthly', 3000000),
+		(36, 0.24, 0.24, 'monthly', 3000000))
+		AS source(tenor_value, flat_margin_rate, effective_margin_rate, payment_frequencies, min_amount)
 	ON target.tenor_value = source.tenor_value
 	WHEN NOT MATCHED THEN
-		INSERT (tenor_value, created_at, updated_at)
-		VALUES (source.tenor_value, 0, 0);
+		INSERT (tenor_value, flat_margin_rate, effective_margin_rate, payment_frequencies, min_amount, created_at, updated_at)
+		VALUES (source.tenor_value, source.flat_margin_rate, source.effective_margin_rate, source.payment_frequencies, source.min_amount, 0, 0);
 	`
 	return db.Exec(seedSQL).Error
 }
 
+// A tenor length may have dated successors, so tenor_value alone is not a
+// key. Tenors of the same length starting on the same day are still
+// rejected by the database; overlapping windows are checked when tenors are
+// saved. Databases created before successors existed lose their key on
+// tenor_value.
+
+func migrateTenorKeyForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&Tenor{}, "unique_tenor_value") {
+		if err := db.Exec("ALTER TABLE tenors DROP INDEX unique_tenor_value").Error; err != nil {
+			return err
+		}
+	}
+	if db.Migrator().HasIndex(&Tenor{}, "unique_tenor_start") {
+		return nil
+	}
+	return db.Exec("CREATE UNIQUE INDEX unique_tenor_start ON tenors (tenor_value, effective_from)").Error
+}
+
+func migrateTenorKeyForPostgreSQL(db *gorm.DB) error {
+	return execAll(db,
+		"ALTER TABLE tenors DROP CONSTRAINT IF EXISTS tenors_tenor_value_key",
+		"CREATE UNIQUE INDEX IF NOT EXISTS unique_tenor_start ON tenors (tenor_value, effective_from)",
+	)
+}
+
+// migrateTenorKeyForSQLServer finds the key on tenor_value by its column,
+// as SQL Server generated its name. Unlike the other databases, SQL Server
+// treats NULLs as equal in a unique index, so open-started tenors are left
+// to the overlap check.
+func migrateTenorKeyForSQLServer(db *gorm.DB) error {
+	dropKeySQL := `
+	DECLARE @key NVARCHAR(128);
+	SELECT @key = kc.name
+	FROM sys.key_constraints kc
+	JOIN sys.index_columns ic ON ic.object_id = kc.parent_object_id AND ic.index_id = kc.unique_index_id
+	JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+	WHERE kc.parent_object_id = OBJECT_ID('tenors') AND kc.type = 'UQ' AND c.name = 'tenor_value';
+	IF @key IS NOT NULL EXEC('ALTER TABLE tenors DROP CONSTRAINT ' + @key);
+	`
+	createKeySQL := `
+	IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'unique_tenor_start' AND object_id = OBJECT_ID('tenors'))
+	CREATE UNIQUE INDEX unique_tenor_start ON tenors (tenor_value, effective_from) WHERE effective_from IS NOT NULL;
+	`
+	return execAll(db, dropKeySQL, createKeySQL)
+}
+
+// addMissingColumns adds the columns the table does not have yet and returns
+// the names of those it added.
+func addMissingColumns(db *gorm.DB, model interface{}, columns []tableColumn, addColumnFormat string) (map[string]bool, error) {
+	added := make(map[string]bool)
+	for _, column := range columns {
+		if db.Migrator().HasColumn(model, column.name) {
+			continue
+		}
+		if err := db.Exec(fmt.Sprintf(addColumnFormat, column.name, column.definition)).Error; err != nil {
+			return nil, err
+		}
+		added[column.name] = true
+	}
+	return added, nil
+}
+
+// upgradeTenorTable adds missing columns; the active flag's boolean type
+// differs per database, so its definition is passed in. Existing tenors
+// become active with open-ended effective dates.
+func upgradeTenorTable(db *gorm.DB, addColumnFormat, activeDefinition string) error {
+	columns := append([]tableColumn{}, tenorColumnUpgrades...)
+	columns = append(columns, tableColumn{name: "active", definition: activeDefinition})
+
+	added, err := addMissingColumns(db, &Tenor{}, columns, addColumnFormat)
+	if err != nil {
+		return err
+	}
+
+	// Backfills only run with the column they fill: once tenors are
+	// maintained through the admin endpoints, NULL is a deliberate value.
+	if added["flat_margin_rate"] || added["effective_margin_rate"] {
+		// Existing tenors were priced at the former flat 20%, so they keep
+		// that rate rather than taking the seeded per-tenor rates.
+		backfillRatesSQL := `
+		UPDATE tenors SET flat_margin_rate = 0.20, effective_margin_rate = 0.20
+		WHERE flat_margin_rate IS NULL
+			AND effective_margin_rate IS NULL;
+		`
+		if err := db.Exec(backfillRatesSQL).Error; err != nil {
+			return err
+		}
+	}
+
+	if added["payment_frequencies"] {
+		backfillFrequenciesSQL := `
+		UPDATE tenors SET
+			payment_frequencies = CASE tenor_value
+				WHEN 6 THEN 'monthly,biweekly,weekly' WHEN 12 THEN 'monthly,biweekly,weekly'
+				WHEN 18 THEN 'monthly,biweekly' WHEN 24 THEN 'monthly,biweekly'
+				ELSE 'monthly' END
+		WHERE payment_frequencies IS NULL;
+		`
+		if err := db.Exec(backfillFrequenciesSQL).Error; err != nil {
+			return err
+		}
+	}
+
+	// NULL bands mean "no limit".
+	if !added["min_amount"] {
+		return nil
+	}
+	backfillBandsSQL := `
+	UPDATE tenors SET min_amount = 3000000
+	WHERE tenor_value IN (18, 24, 30, 36);
+	`
+	return db.Exec(backfillBandsSQL).Error
+}
+
 func DetectDatabaseType(db *gorm.DB) string {
 	if db.Dialector.Name() == "mysql" {
 		return "mysql"
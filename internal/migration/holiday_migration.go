@@ -0,0 +1,120 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// National holidays and cuti bersama for 2026 as set by the joint ministerial
+// decree (SKB 3 Menteri). The same dates ship in conf/holidays.json.
+const seedHolidaysSQL = `
+	INSERT INTO holidays (holiday_date, name, holiday_type, created_at, updated_at) VALUES
+	('2026-01-01', 'Tahun Baru 2026 Masehi', 'national', 0, 0),
+	('2026-01-16', 'Isra Mikraj Nabi Muhammad SAW', 'national', 0, 0),
+	('2026-02-16', 'Cuti Bersama Tahun Baru Imlek', 'cuti_bersama', 0, 0),
+	('2026-02-17', 'Tahun Baru Imlek 2577 Kongzili', 'national', 0, 0),
+	('2026-03-18', 'Cuti Bersama Hari Suci Nyepi', 'cuti_bersama', 0, 0),
+	('2026-03-19', 'Hari Suci Nyepi Tahun Baru Saka 1948', 'national', 0, 0),
+	('2026-03-20', 'Cuti Bersama Idul Fitri 1447 H', 'cuti_bersama', 0, 0),
+	('2026-03-21', 'Idul Fitri 1447 H', 'national', 0, 0),
+	('2026-03-22', 'Idul Fitri 1447 H', 'national', 0, 0),
+	('2026-03-23', 'Cuti Bersama Idul Fitri 1447 H', 'cuti_bersama', 0, 0),
+	('2026-03-24', 'Cuti Bersama Idul Fitri 1447 H', 'cuti_bersama', 0, 0),
+	('2026-04-03', 'Wafat Yesus Kristus', 'national', 0, 0),
+	('2026-04-05', 'Kebangkitan Yesus Kristus (Paskah)', 'national', 0, 0),
+	('2026-05-01', 'Hari Buruh Internasional', 'national', 0, 0),
+	('2026-05-14', 'Kenaikan Yesus Kristus', 'national', 0, 0),
+	('2026-05-15', 'Cuti Bersama Kenaikan Yesus Kristus', 'cuti_bersama', 0, 0),
+	('2026-05-27', 'Idul Adha 1447 H', 'national', 0, 0),
+	('2026-05-28', 'Cuti Bersama Idul Adha 1447 H', 'cuti_bersama', 0, 0),
+	('2026-05-31', 'Hari Raya Waisak 2570 BE', 'national', 0, 0),
+	('2026-06-01', 'Hari Lahir Pancasila', 'national', 0, 0),
+	('2026-06-16', 'Tahun Baru Islam 1448 H', 'national', 0, 0),
+	('2026-08-17', 'Hari Kemerdekaan Republik Indonesia', 'national', 0, 0),
+	('2026-08-25', 'Maulid Nabi Muhammad SAW', 'national', 0, 0),
+	('2026-12-24', 'Cuti Bersama Hari Raya Natal', 'cuti_bersama', 0, 0),
+	('2026-12-25', 'Hari Raya Natal', 'national', 0, 0);
+	`
+
+func migrateHolidaysForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Holiday{}) {
+		return nil
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS holidays (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		holiday_date DATE NOT NULL,
+		name VARCHAR(128) NOT NULL,
+		holiday_type VARCHAR(16) NOT NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0,
+		UNIQUE KEY unique_holiday_date (holiday_date)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedHolidaysSQL).Error
+}
+
+func migrateHolidaysForPostgreSQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Holiday{}) {
+		return nil
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS holidays (
+		id BIGSERIAL PRIMARY KEY,
+		holiday_date DATE NOT NULL UNIQUE,
+		name VARCHAR(128) NOT NULL,
+		holiday_type VARCHAR(16) NOT NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0
+	);
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedHolidaysSQL).Error
+}
+
+func migrateHolidaysForSQLServer(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Holiday{}) {
+		return nil
+	}
+
+	sql := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='holidays' AND xtype='U')
+	CREATE TABLE holidays (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		holiday_date DATE NOT NULL,
+		name VARCHAR(128) NOT NULL,
+		holiday_type VARCHAR(16) NOT NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0,
+		CONSTRAINT unique_holiday_date UNIQUE (holiday_date)
+	);
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedHolidaysSQL).Error
+}
+
+type Holiday struct {
+	ID        int64     `gorm:"primaryKey"`
+	Date      time.Time `gorm:"column:holiday_date;type:date;not null"`
+	Name      string    `gorm:"column:name;not null"`
+	Type      string    `gorm:"column:holiday_type;not null"`
+	CreatedAt int64     `gorm:"autoCreateTime:milli"`
+	UpdatedAt int64     `gorm:"autoUpdateTime:milli"`
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
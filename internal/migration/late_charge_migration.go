@@ -0,0 +1,115 @@
+package migration
+
+import "gorm.io/gorm"
+
+// The default rule set charges a fixed ta'widh covering the collection cost,
+// and a daily ta'zir on the overdue installment after three days, capped at
+// 5% of that installment.
+const seedLateChargeRulesSQL = `
+	INSERT INTO late_charge_rules (rule_set, charge_type, grace_days, flat_amount, daily_rate, cap_amount, cap_rate, description, created_at, updated_at) VALUES
+	('default', 'tawidh', 0, 25000, NULL, 25000, NULL, 'Actual collection cost', 0, 0),
+	('default', 'tazir', 3, 0, 0.001, 0, 0.05, 'Daily penalty channelled to the social fund', 0, 0);
+	`
+
+func migrateLateChargeRulesForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&LateChargeRule{}) {
+		return nil
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS late_charge_rules (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		rule_set VARCHAR(32) NOT NULL,
+		charge_type VARCHAR(16) NOT NULL,
+		grace_days INT NOT NULL DEFAULT 0,
+		flat_amount BIGINT NOT NULL DEFAULT 0,
+		daily_rate DECIMAL(9,6) NULL,
+		cap_amount BIGINT NOT NULL DEFAULT 0,
+		cap_rate DECIMAL(7,4) NULL,
+		description VARCHAR(255) NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0,
+		UNIQUE KEY unique_rule_charge (rule_set, charge_type)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedLateChargeRulesSQL).Error
+}
+
+func migrateLateChargeRulesForPostgreSQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&LateChargeRule{}) {
+		return nil
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS late_charge_rules (
+		id BIGSERIAL PRIMARY KEY,
+		rule_set VARCHAR(32) NOT NULL,
+		charge_type VARCHAR(16) NOT NULL,
+		grace_days INT NOT NULL DEFAULT 0,
+		flat_amount BIGINT NOT NULL DEFAULT 0,
+		daily_rate NUMERIC(9,6) NULL,
+		cap_amount BIGINT NOT NULL DEFAULT 0,
+		cap_rate NUMERIC(7,4) NULL,
+		description VARCHAR(255) NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0,
+		UNIQUE (rule_set, charge_type)
+	);
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedLateChargeRulesSQL).Error
+}
+
+func migrateLateChargeRulesForSQLServer(db *gorm.DB) error {
+	if db.Migrator().HasTable(&LateChargeRule{}) {
+		return nil
+	}
+
+	sql := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='late_charge_rules' AND xtype='U')
+	CREATE TABLE late_charge_rules (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		rule_set VARCHAR(32) NOT NULL,
+		charge_type VARCHAR(16) NOT NULL,
+		grace_days INT NOT NULL DEFAULT 0,
+		flat_amount BIGINT NOT NULL DEFAULT 0,
+		daily_rate DECIMAL(9,6) NULL,
+		cap_amount BIGINT NOT NULL DEFAULT 0,
+		cap_rate DECIMAL(7,4) NULL,
+		description VARCHAR(255) NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0,
+		CONSTRAINT unique_rule_charge UNIQUE (rule_set, charge_type)
+	);
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedLateChargeRulesSQL).Error
+}
+
+type LateChargeRule struct {
+	ID          int64    `gorm:"primaryKey"`
+	RuleSet     string   `gorm:"column:rule_set;not null"`
+	ChargeType  string   `gorm:"column:charge_type;not null"`
+	GraceDays   int      `gorm:"column:grace_days;not null"`
+	FlatAmount  int64    `gorm:"column:flat_amount;not null"`
+	DailyRate   *float64 `gorm:"column:daily_rate"`
+	CapAmount   int64    `gorm:"column:cap_amount;not null"`
+	CapRate     *float64 `gorm:"column:cap_rate"`
+	Description string   `gorm:"column:description"`
+	CreatedAt   int64    `gorm:"autoCreateTime:milli"`
+	UpdatedAt   int64    `gorm:"autoUpdateTime:milli"`
+}
+
+func (LateChargeRule) TableName() string {
+	return "late_charge_rules"
+}
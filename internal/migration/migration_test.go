@@ -40,7 +40,20 @@ func TestTenorModelFields(t *testing.T) {
 		t.Fatal("Tenor model TableName() not properly defined")
 	}
 
-	t.Log("Tenor model fields: ID, TenorValue, CreatedAt, UpdatedAt")
+	t.Log("Tenor model fields: ID, TenorValue, FlatMarginRate, EffectiveMarginRate, PaymentFrequencies, MinAmount, MaxAmount, Active, EffectiveFrom, EffectiveTo, CreatedAt, UpdatedAt")
+}
+
+func TestTenorMarginRates(t *testing.T) {
+	flatRate := 0.18
+	tenor := Tenor{TenorValue: 6, FlatMarginRate: &flatRate}
+
+	if tenor.FlatMarginRate == nil || *tenor.FlatMarginRate != 0.18 {
+		t.Errorf("Expected flat margin rate 0.18, got %v", tenor.FlatMarginRate)
+	}
+
+	if tenor.EffectiveMarginRate != nil {
+		t.Errorf("Expected unset effective margin rate to be nil, got %v", *tenor.EffectiveMarginRate)
+	}
 }
 
 func TestTenorDefaults(t *testing.T) {
@@ -120,3 +133,86 @@ func TestTenorValues(t *testing.T) {
 
 	t.Log("✓ All tenor values validated successfully")
 }
+
+func TestTenorColumnUpgrades(t *testing.T) {
+	expected := []string{"flat_margin_rate", "effective_margin_rate", "payment_frequencies", "min_amount", "max_amount", "effective_from", "effective_to"}
+
+	if len(tenorColumnUpgrades) != len(expected) {
+		t.Fatalf("Expected %d upgrade columns, got %d", len(expected), len(tenorColumnUpgrades))
+	}
+
+	for i, column := range tenorColumnUpgrades {
+		if column.name != expected[i] {
+			t.Errorf("At index %d: expected column %s, got %s", i, expected[i], column.name)
+		}
+		if column.definition == "" {
+			t.Errorf("Column %s has no definition", column.name)
+		}
+	}
+}
+
+func TestLateChargeRuleModel(t *testing.T) {
+	dailyRate := 0.001
+	rule := LateChargeRule{RuleSet: "default", ChargeType: "tazir", GraceDays: 3, DailyRate: &dailyRate}
+
+	if rule.TableName() != "late_charge_rules" {
+		t.Errorf("Expected table name 'late_charge_rules', got '%s'", rule.TableName())
+	}
+
+	if rule.CapRate != nil {
+		t.Errorf("Expected unset cap rate to be nil, got %v", *rule.CapRate)
+	}
+}
+
+func TestProductModel(t *testing.T) {
+	product := Product{Code: "qardh", ContractType: "qardh", FeeAmount: 50000}
+
+	if product.TableName() != "products" {
+		t.Errorf("Expected table name 'products', got '%s'", product.TableName())
+	}
+
+	if product.FeeRate != nil {
+		t.Errorf("Expected unset fee rate to be nil, got %v", *product.FeeRate)
+	}
+}
+
+func TestHolidayModel(t *testing.T) {
+	holiday := Holiday{Name: "Idul Fitri 1447 H", Type: "national"}
+
+	if holiday.TableName() != "holidays" {
+		t.Errorf("Expected table name 'holidays', got '%s'", holiday.TableName())
+	}
+}
+
+func TestPricingHistoryModels(t *testing.T) {
+	if (PricingVersion{}).TableName() != "pricing_versions" {
+		t.Errorf("Expected table name 'pricing_versions', got '%s'", PricingVersion{}.TableName())
+	}
+	if (PricingVersionTenor{}).TableName() != "pricing_version_tenors" {
+		t.Errorf("Expected table name 'pricing_version_tenors', got '%s'", PricingVersionTenor{}.TableName())
+	}
+}
+
+func TestQuoteModel(t *testing.T) {
+	quote := Quote{ID: "4f1c6d1e-9a0b-4c55-8d2e-3b7f9e6a1c20", Channel: "mobile"}
+
+	if quote.TableName() != "quotes" {
+		t.Errorf("Expected table name 'quotes', got '%s'", quote.TableName())
+	}
+}
+
+func TestApplicationModel(t *testing.T) {
+	application := Application{QuoteID: "4f1c6d1e-9a0b-4c55-8d2e-3b7f9e6a1c20", Tenor: 12, Status: "draft"}
+
+	if application.TableName() != "applications" {
+		t.Errorf("Expected table name 'applications', got '%s'", application.TableName())
+	}
+}
+
+func TestContractModel(t *testing.T) {
+	contract := Contract{ApplicationID: 1, AllocationOrder: "charges_margin_principal", Status: "active"}
+
+	if contract.TableName() != "contracts" {
+		t.Errorf("Expected table name 'contracts', got '%s'", contract.TableName())
+	}
+}
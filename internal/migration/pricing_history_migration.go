@@ -0,0 +1,267 @@
+package migration
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The baseline version snapshots the tenor master as migrated. It takes
+// effect at the epoch so that every as-of date finds a version.
+const (
+	seedBaselineVersionSQL = `
+	INSERT INTO pricing_versions (effective_at, note, created_at) VALUES (0, 'baseline', 0);
+	`
+	seedBaselineVersionTenorsSQL = `
+	INSERT INTO pricing_version_tenors (pricing_version_id, tenor_id, tenor_value, flat_margin_rate, effective_margin_rate, payment_frequencies, min_amount, max_amount, active, effective_from, effective_to)
+	SELECT (SELECT MAX(id) FROM pricing_versions), id, tenor_value, flat_margin_rate, effective_margin_rate, payment_frequencies, min_amount, max_amount, active, effective_from, effective_to
+	FROM tenors;
+	`
+	// Products join the history after the product catalog exists; the
+	// latest version adopts the catalog as migrated, which is what it has
+	// been priced with so far.
+	seedVersionProductsSQL = `
+	INSERT INTO pricing_version_products (pricing_version_id, product_id, code, name, contract_type, fee_amount, fee_rate, min_amount, max_amount)
+	SELECT (SELECT MAX(id) FROM pricing_versions), id, code, name, contract_type, fee_amount, fee_rate, min_amount, max_amount
+	FROM products;
+	`
+)
+
+func migratePricingHistoryForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&PricingVersion{}) {
+		return nil
+	}
+
+	versionsSQL := `
+	CREATE TABLE IF NOT EXISTS pricing_versions (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		effective_at BIGINT NOT NULL,
+		note VARCHAR(255) NULL,
+		created_at BIGINT DEFAULT 0,
+		KEY idx_pricing_versions_effective_at (effective_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	tenorsSQL := `
+	CREATE TABLE IF NOT EXISTS pricing_version_tenors (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		pricing_version_id BIGINT NOT NULL,
+		tenor_id BIGINT NOT NULL,
+		tenor_value INT NOT NULL,
+		flat_margin_rate DECIMAL(7,4) NULL,
+		effective_margin_rate DECIMAL(7,4) NULL,
+		payment_frequencies VARCHAR(64) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		active BOOLEAN NOT NULL,
+		effective_from DATE NULL,
+		effective_to DATE NULL,
+		KEY idx_pricing_version_tenors_version (pricing_version_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	return createPricingHistory(db, versionsSQL, tenorsSQL)
+}
+
+func migratePricingHistoryForPostgreSQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&PricingVersion{}) {
+		return nil
+	}
+
+	versionsSQL := `
+	CREATE TABLE IF NOT EXISTS pricing_versions (
+		id BIGSERIAL PRIMARY KEY,
+		effective_at BIGINT NOT NULL,
+		note VARCHAR(255) NULL,
+		created_at BIGINT DEFAULT 0
+	);
+	`
+	versionsIndexSQL := `
+	CREATE INDEX IF NOT EXISTS idx_pricing_versions_effective_at ON pricing_versions (effective_at);
+	`
+	tenorsSQL := `
+	CREATE TABLE IF NOT EXISTS pricing_version_tenors (
+		id BIGSERIAL PRIMARY KEY,
+		pricing_version_id BIGINT NOT NULL,
+		tenor_id BIGINT NOT NULL,
+		tenor_value INT NOT NULL,
+		flat_margin_rate NUMERIC(7,4) NULL,
+		effective_margin_rate NUMERIC(7,4) NULL,
+		payment_frequencies VARCHAR(64) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		active BOOLEAN NOT NULL,
+		effective_from DATE NULL,
+		effective_to DATE NULL
+	);
+	`
+	tenorsIndexSQL := `
+	CREATE INDEX IF NOT EXISTS idx_pricing_version_tenors_version ON pricing_version_tenors (pricing_version_id);
+	`
+	return createPricingHistory(db, versionsSQL, versionsIndexSQL, tenorsSQL, tenorsIndexSQL)
+}
+
+func migratePricingHistoryForSQLServer(db *gorm.DB) error {
+	if db.Migrator().HasTable(&PricingVersion{}) {
+		return nil
+	}
+
+	versionsSQL := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='pricing_versions' AND xtype='U')
+	CREATE TABLE pricing_versions (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		effective_at BIGINT NOT NULL,
+		note VARCHAR(255) NULL,
+		created_at BIGINT DEFAULT 0,
+		INDEX idx_pricing_versions_effective_at (effective_at)
+	);
+	`
+	tenorsSQL := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='pricing_version_tenors' AND xtype='U')
+	CREATE TABLE pricing_version_tenors (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		pricing_version_id BIGINT NOT NULL,
+		tenor_id BIGINT NOT NULL,
+		tenor_value INT NOT NULL,
+		flat_margin_rate DECIMAL(7,4) NULL,
+		effective_margin_rate DECIMAL(7,4) NULL,
+		payment_frequencies VARCHAR(64) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		active BIT NOT NULL,
+		effective_from DATE NULL,
+		effective_to DATE NULL,
+		INDEX idx_pricing_version_tenors_version (pricing_version_id)
+	);
+	`
+	return createPricingHistory(db, versionsSQL, tenorsSQL)
+}
+
+// createPricingHistory runs the table statements in order and records the
+// baseline version from the current tenor master.
+func createPricingHistory(db *gorm.DB, tableStatements ...string) error {
+	statements := append(tableStatements, seedBaselineVersionSQL, seedBaselineVersionTenorsSQL)
+	for _, sql := range statements {
+		if err := db.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migratePricingVersionProductsForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&PricingVersionProduct{}) {
+		return nil
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS pricing_version_products (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		pricing_version_id BIGINT NOT NULL,
+		product_id BIGINT NOT NULL,
+		code VARCHAR(32) NOT NULL,
+		name VARCHAR(128) NOT NULL,
+		contract_type VARCHAR(16) NOT NULL,
+		fee_amount BIGINT NOT NULL DEFAULT 0,
+		fee_rate DECIMAL(7,4) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		KEY idx_pricing_version_products_version (pricing_version_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	return execAll(db, sql, seedVersionProductsSQL)
+}
+
+func migratePricingVersionProductsForPostgreSQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&PricingVersionProduct{}) {
+		return nil
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS pricing_version_products (
+		id BIGSERIAL PRIMARY KEY,
+		pricing_version_id BIGINT NOT NULL,
+		product_id BIGINT NOT NULL,
+		code VARCHAR(32) NOT NULL,
+		name VARCHAR(128) NOT NULL,
+		contract_type VARCHAR(16) NOT NULL,
+		fee_amount BIGINT NOT NULL DEFAULT 0,
+		fee_rate NUMERIC(7,4) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL
+	);
+	`
+	indexSQL := `
+	CREATE INDEX IF NOT EXISTS idx_pricing_version_products_version ON pricing_version_products (pricing_version_id);
+	`
+	return execAll(db, sql, indexSQL, seedVersionProductsSQL)
+}
+
+func migratePricingVersionProductsForSQLServer(db *gorm.DB) error {
+	if db.Migrator().HasTable(&PricingVersionProduct{}) {
+		return nil
+	}
+
+	sql := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='pricing_version_products' AND xtype='U')
+	CREATE TABLE pricing_version_products (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		pricing_version_id BIGINT NOT NULL,
+		product_id BIGINT NOT NULL,
+		code VARCHAR(32) NOT NULL,
+		name VARCHAR(128) NOT NULL,
+		contract_type VARCHAR(16) NOT NULL,
+		fee_amount BIGINT NOT NULL DEFAULT 0,
+		fee_rate DECIMAL(7,4) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		INDEX idx_pricing_version_products_version (pricing_version_id)
+	);
+	`
+	return execAll(db, sql, seedVersionProductsSQL)
+}
+
+type PricingVersion struct {
+	ID          int64  `gorm:"primaryKey"`
+	EffectiveAt int64  `gorm:"column:effective_at;not null"`
+	Note        string `gorm:"column:note"`
+	CreatedAt   int64  `gorm:"autoCreateTime:milli"`
+}
+
+func (PricingVersion) TableName() string {
+	return "pricing_versions"
+}
+
+type PricingVersionTenor struct {
+	ID                  int64      `gorm:"primaryKey"`
+	PricingVersionID    int64      `gorm:"column:pricing_version_id;not null"`
+	TenorID             int64      `gorm:"column:tenor_id;not null"`
+	TenorValue          int        `gorm:"column:tenor_value;not null"`
+	FlatMarginRate      *float64   `gorm:"column:flat_margin_rate"`
+	EffectiveMarginRate *float64   `gorm:"column:effective_margin_rate"`
+	PaymentFrequencies  string     `gorm:"column:payment_frequencies"`
+	MinAmount           *int64     `gorm:"column:min_amount"`
+	MaxAmount           *int64     `gorm:"column:max_amount"`
+	Active              bool       `gorm:"column:active;not null"`
+	EffectiveFrom       *time.Time `gorm:"column:effective_from;type:date"`
+	EffectiveTo         *time.Time `gorm:"column:effective_to;type:date"`
+}
+
+func (PricingVersionTenor) TableName() string {
+	return "pricing_version_tenors"
+}
+
+type PricingVersionProduct struct {
+	ID               int64    `gorm:"primaryKey"`
+	PricingVersionID int64    `gorm:"column:pricing_version_id;not null"`
+	ProductID        int64    `gorm:"column:product_id;not null"`
+	Code             string   `gorm:"column:code;not null"`
+	Name             string   `gorm:"column:name;not null"`
+	ContractType     string   `gorm:"column:contract_type;not null"`
+	FeeAmount        int64    `gorm:"column:fee_amount;not null"`
+	FeeRate          *float64 `gorm:"column:fee_rate"`
+	MinAmount        *int64   `gorm:"column:min_amount"`
+	MaxAmount        *int64   `gorm:"column:max_amount"`
+}
+
+func (PricingVersionProduct) TableName() string {
+	return "pricing_version_products"
+}
@@ -0,0 +1,131 @@
+package migration
+
+import "gorm.io/gorm"
+
+const seedProductsSQL = `
+	INSERT INTO products (code, name, contract_type, fee_amount, fee_rate, min_amount, max_amount, created_at, updated_at) VALUES
+	('murabahah', 'Murabahah', 'murabahah', 0, NULL, NULL, NULL, 0, 0),
+	('imbt', 'Ijarah Muntahiyah Bittamlik', 'ijarah', 0, NULL, NULL, NULL, 0, 0),
+	('mmq', 'Musyarakah Mutanaqisah', 'mmq', 0, NULL, 50000000, NULL, 0, 0),
+	('qardh', 'Qardh', 'qardh', 50000, NULL, NULL, 10000000, 0, 0);
+	`
+
+var productColumnUpgrades = []tableColumn{
+	{name: "min_amount", definition: "BIGINT NULL"},
+	{name: "max_amount", definition: "BIGINT NULL"},
+}
+
+func upgradeProductTable(db *gorm.DB, addColumnFormat string) error {
+	added, err := addMissingColumns(db, &Product{}, productColumnUpgrades, addColumnFormat)
+	if err != nil {
+		return err
+	}
+	if !added["min_amount"] {
+		return nil
+	}
+
+	backfillPlafondSQL := `
+	UPDATE products SET
+		min_amount = CASE code WHEN 'mmq' THEN 50000000 END,
+		max_amount = CASE code WHEN 'qardh' THEN 10000000 END
+	WHERE code IN ('mmq', 'qardh');
+	`
+	return db.Exec(backfillPlafondSQL).Error
+}
+
+func migrateProductsForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Product{}) {
+		return upgradeProductTable(db, "ALTER TABLE products ADD COLUMN %s %s")
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS products (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		code VARCHAR(32) NOT NULL,
+		name VARCHAR(128) NOT NULL,
+		contract_type VARCHAR(16) NOT NULL,
+		fee_amount BIGINT NOT NULL DEFAULT 0,
+		fee_rate DECIMAL(7,4) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0,
+		UNIQUE KEY unique_product_code (code)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedProductsSQL).Error
+}
+
+func migrateProductsForPostgreSQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Product{}) {
+		return upgradeProductTable(db, "ALTER TABLE products ADD COLUMN IF NOT EXISTS %s %s")
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS products (
+		id BIGSERIAL PRIMARY KEY,
+		code VARCHAR(32) NOT NULL UNIQUE,
+		name VARCHAR(128) NOT NULL,
+		contract_type VARCHAR(16) NOT NULL,
+		fee_amount BIGINT NOT NULL DEFAULT 0,
+		fee_rate NUMERIC(7,4) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0
+	);
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedProductsSQL).Error
+}
+
+func migrateProductsForSQLServer(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Product{}) {
+		return upgradeProductTable(db, "ALTER TABLE products ADD %s %s")
+	}
+
+	sql := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='products' AND xtype='U')
+	CREATE TABLE products (
+		id BIGINT PRIMARY KEY IDENTITY(1,1),
+		code VARCHAR(32) NOT NULL UNIQUE,
+		name VARCHAR(128) NOT NULL,
+		contract_type VARCHAR(16) NOT NULL,
+		fee_amount BIGINT NOT NULL DEFAULT 0,
+		fee_rate DECIMAL(7,4) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		created_at BIGINT DEFAULT 0,
+		updated_at BIGINT DEFAULT 0
+	);
+	`
+	if err := db.Exec(sql).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(seedProductsSQL).Error
+}
+
+type Product struct {
+	ID           int64    `gorm:"primaryKey"`
+	Code         string   `gorm:"column:code;not null"`
+	Name         string   `gorm:"column:name;not null"`
+	ContractType string   `gorm:"column:contract_type;not null"`
+	FeeAmount    int64    `gorm:"column:fee_amount;not null"`
+	FeeRate      *float64 `gorm:"column:fee_rate"`
+	MinAmount    *int64   `gorm:"column:min_amount"`
+	MaxAmount    *int64   `gorm:"column:max_amount"`
+	CreatedAt    int64    `gorm:"autoCreateTime:milli"`
+	UpdatedAt    int64    `gorm:"autoUpdateTime:milli"`
+}
+
+func (Product) TableName() string {
+	return "products"
+}
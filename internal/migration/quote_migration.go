@@ -0,0 +1,90 @@
+package migration
+
+import "gorm.io/gorm"
+
+func migrateQuotesForMySQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Quote{}) {
+		return nil
+	}
+
+	sql := `
+	CREATE TABLE IF NOT EXISTS quotes (
+		id VARCHAR(36) PRIMARY KEY,
+		channel VARCHAR(32) NULL,
+		financed_amount BIGINT NOT NULL,
+		pricing_version_id BIGINT NULL,
+		request_body MEDIUMTEXT NOT NULL,
+		result_body MEDIUMTEXT NOT NULL,
+		created_at BIGINT NOT NULL,
+		expires_at BIGINT NOT NULL,
+		KEY idx_quotes_created_at (created_at),
+		KEY idx_quotes_channel_created_at (channel, created_at),
+		KEY idx_quotes_expires_at (expires_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+	return db.Exec(sql).Error
+}
+
+func migrateQuotesForPostgreSQL(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Quote{}) {
+		return nil
+	}
+
+	return execAll(db, `
+	CREATE TABLE IF NOT EXISTS quotes (
+		id VARCHAR(36) PRIMARY KEY,
+		channel VARCHAR(32) NULL,
+		financed_amount BIGINT NOT NULL,
+		pricing_version_id BIGINT NULL,
+		request_body TEXT NOT NULL,
+		result_body TEXT NOT NULL,
+		created_at BIGINT NOT NULL,
+		expires_at BIGINT NOT NULL
+	);
+	`, `
+	CREATE INDEX IF NOT EXISTS idx_quotes_created_at ON quotes (created_at);
+	`, `
+	CREATE INDEX IF NOT EXISTS idx_quotes_channel_created_at ON quotes (channel, created_at);
+	`, `
+	CREATE INDEX IF NOT EXISTS idx_quotes_expires_at ON quotes (expires_at);
+	`)
+}
+
+func migrateQuotesForSQLServer(db *gorm.DB) error {
+	if db.Migrator().HasTable(&Quote{}) {
+		return nil
+	}
+
+	sql := `
+	IF NOT EXISTS (SELECT * FROM sysobjects WHERE name='quotes' AND xtype='U')
+	CREATE TABLE quotes (
+		id VARCHAR(36) PRIMARY KEY,
+		channel VARCHAR(32) NULL,
+		financed_amount BIGINT NOT NULL,
+		pricing_version_id BIGINT NULL,
+		request_body NVARCHAR(MAX) NOT NULL,
+		result_body NVARCHAR(MAX) NOT NULL,
+		created_at BIGINT NOT NULL,
+		expires_at BIGINT NOT NULL,
+		INDEX idx_quotes_created_at (created_at),
+		INDEX idx_quotes_channel_created_at (channel, created_at),
+		INDEX idx_quotes_expires_at (expires_at)
+	);
+	`
+	return db.Exec(sql).Error
+}
+
+type Quote struct {
+	ID               string `gorm:"primaryKey;column:id"`
+	Channel          string `gorm:"column:channel"`
+	FinancedAmount   int64  `gorm:"column:financed_amount;not null"`
+	PricingVersionID int64  `gorm:"column:pricing_version_id"`
+	RequestBody      string `gorm:"column:request_body;not null"`
+	ResultBody       string `gorm:"column:result_body;not null"`
+	CreatedAt        int64  `gorm:"column:created_at;not null"`
+	ExpiresAt        int64  `gorm:"column:expires_at;not null"`
+}
+
+func (Quote) TableName() string {
+	return "quotes"
+}
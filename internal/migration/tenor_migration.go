@@ -1,12 +1,12 @@
 package migration
 
-import "gorm.io/gorm"
+import (
+	"time"
 
-func RunMigration(db *gorm.DB) error {
-	if db.Migrator().HasTable(&Tenor{}) {
-		return nil
-	}
+	"gorm.io/gorm"
+)
 
+func RunMigration(db *gorm.DB) error {
 	return RunMigrationAuto(db)
 }
 
@@ -19,9 +19,17 @@ func CreateTenorTable(db *gorm.DB) error {
 	CREATE TABLE IF NOT EXISTS tenors (
 		id BIGINT AUTO_INCREMENT PRIMARY KEY,
 		tenor_value INT NOT NULL,
+		flat_margin_rate DECIMAL(7,4) NULL,
+		effective_margin_rate DECIMAL(7,4) NULL,
+		payment_frequencies VARCHAR(64) NULL,
+		min_amount BIGINT NULL,
+		max_amount BIGINT NULL,
+		active BOOLEAN NOT NULL DEFAULT TRUE,
+		effective_from DATE NULL,
+		effective_to DATE NULL,
 		created_at BIGINT DEFAULT 0,
 		updated_at BIGINT DEFAULT 0,
-		UNIQUE KEY unique_tenor_value (tenor_value)
+		UNIQUE KEY unique_tenor_start (tenor_value, effective_from)
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 	`
 	return db.Exec(sql).Error
@@ -38,22 +46,30 @@ func SeedTenorData(db *gorm.DB) error {
 	}
 
 	sql := `
-	INSERT IGNORE INTO tenors (tenor_value, created_at, updated_at) VALUES
-	(6, 0, 0),
-	(12, 0, 0),
-	(18, 0, 0),
-	(24, 0, 0),
-	(30, 0, 0),
-	(36, 0, 0);
+	INSERT IGNORE INTO tenors (tenor_value, flat_margin_rate, effective_margin_rate, payment_frequencies, min_amount, created_at, updated_at) VALUES
+	(6, 0.18, 0.18, 'monthly,biweekly,weekly', NULL, 0, 0),
+	(12, 0.20, 0.20, 'monthly,biweekly,weekly', NULL, 0, 0),
+	(18, 0.20, 0.20, 'monthly,biweekly', 3000000, 0, 0),
+	(24, 0.22, 0.22, 'monthly,biweekly', 3000000, 0, 0),
+	(30, 0.22, 0.22, 'monthly', 3000000, 0, 0),
+	(36, 0.24, 0.24, 'monthly', 3000000, 0, 0);
 	`
 	return db.Exec(sql).Error
 }
 
 type Tenor struct {
-	ID         int64 `gorm:"primaryKey"`
-	TenorValue int   `gorm:"column:tenor_value;not null"`
-	CreatedAt  int64 `gorm:"autoCreateTime:milli"`
-	UpdatedAt  int64 `gorm:"autoUpdateTime:milli"`
+	ID                  int64      `gorm:"primaryKey"`
+	TenorValue          int        `gorm:"column:tenor_value;not null"`
+	FlatMarginRate      *float64   `gorm:"column:flat_margin_rate"`
+	EffectiveMarginRate *float64   `gorm:"column:effective_margin_rate"`
+	PaymentFrequencies  string     `gorm:"column:payment_frequencies"`
+	MinAmount           *int64     `gorm:"column:min_amount"`
+	MaxAmount           *int64     `gorm:"column:max_amount"`
+	Active              bool       `gorm:"column:active;not null"`
+	EffectiveFrom       *time.Time `gorm:"column:effective_from;type:date"`
+	EffectiveTo         *time.Time `gorm:"column:effective_to;type:date"`
+	CreatedAt           int64      `gorm:"autoCreateTime:milli"`
+	UpdatedAt           int64      `gorm:"autoUpdateTime:milli"`
 }
 
 func (Tenor) TableName() string {
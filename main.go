@@ -5,7 +5,10 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
+	"btpntest/domain"
+	cicilan "btpntest/internal/cicilan"
 	"btpntest/internal/cicilan/delivery/http"
 	"btpntest/internal/cicilan/repository"
 	"btpntest/internal/cicilan/usecase"
@@ -14,6 +17,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"gorm.io/gorm"
 )
 
 func init() {
@@ -97,6 +101,138 @@ func loadDatabaseConfig() databases.Config {
 	}
 }
 
+func loadMaxDSR() float64 {
+	value := os.Getenv("MAX_DSR")
+	if value == "" {
+		return usecase.DefaultMaxDSR
+	}
+
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil || ratio <= 0 || ratio > 1 {
+		log.Printf("Warning: Invalid MAX_DSR %q, using %.2f\n", value, usecase.DefaultMaxDSR)
+		return usecase.DefaultMaxDSR
+	}
+	return ratio
+}
+
+// loadAmountLimits reads the service-wide plafond for products that do not
+// define their own.
+func loadAmountLimits() (int64, int64) {
+	minAmount := loadAmount("MIN_FINANCING_AMOUNT", usecase.DefaultMinAmount)
+	maxAmount := loadAmount("MAX_FINANCING_AMOUNT", usecase.DefaultMaxAmount)
+	if maxAmount > 0 && maxAmount < minAmount {
+		log.Printf("Warning: MAX_FINANCING_AMOUNT %d is below MIN_FINANCING_AMOUNT %d, using defaults\n", maxAmount, minAmount)
+		return usecase.DefaultMinAmount, usecase.DefaultMaxAmount
+	}
+	return minAmount, maxAmount
+}
+
+func loadAmount(key string, fallback int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || amount < 0 {
+		log.Printf("Warning: Invalid %s %q, using %d\n", key, value, fallback)
+		return fallback
+	}
+	return amount
+}
+
+// loadTenorRange reads the range of non-master tenors requests may ask for.
+// The range stays disabled unless all three settings are valid.
+func loadTenorRange() (int, int, int) {
+	values := make([]int, 0, 3)
+	for _, key := range []string{"TENOR_MIN_MONTHS", "TENOR_MAX_MONTHS", "TENOR_STEP_MONTHS"} {
+		value := os.Getenv(key)
+		if value == "" {
+			return 0, 0, 0
+		}
+
+		months, err := strconv.Atoi(value)
+		if err != nil || months <= 0 {
+			log.Printf("Warning: Invalid %s %q, only master tenors are allowed\n", key, value)
+			return 0, 0, 0
+		}
+		values = append(values, months)
+	}
+
+	if values[1] < values[0] {
+		log.Printf("Warning: TENOR_MAX_MONTHS %d is below TENOR_MIN_MONTHS %d, only master tenors are allowed\n", values[1], values[0])
+		return 0, 0, 0
+	}
+	return values[0], values[1], values[2]
+}
+
+// loadHolidayCalendar reads holidays from HOLIDAY_FILE when it is set and
+// from the holidays table otherwise.
+func loadHolidayCalendar(db *gorm.DB) cicilan.HolidayRepository {
+	if path := os.Getenv("HOLIDAY_FILE"); path != "" {
+		return repository.NewHolidayFileRepository(path)
+	}
+	return repository.NewHolidayRepository(db)
+}
+
+// loadAllocationOrder reads how payments are allocated on contracts opened
+// without their own allocation order.
+func loadAllocationOrder() string {
+	value := os.Getenv("PAYMENT_ALLOCATION_ORDER")
+	switch value {
+	case "":
+		return domain.AllocationChargesMarginPrincipal
+	case domain.AllocationChargesMarginPrincipal, domain.AllocationOldestInstallment:
+		return value
+	}
+	log.Printf("Warning: Invalid PAYMENT_ALLOCATION_ORDER %q, using %s\n", value, domain.AllocationChargesMarginPrincipal)
+	return domain.AllocationChargesMarginPrincipal
+}
+
+// loadDuration reads a positive whole number of units, such as hours, from
+// key.
+func loadDuration(key string, unit, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	count, err := strconv.Atoi(value)
+	if err != nil || count <= 0 {
+		log.Printf("Warning: Invalid %s %q, using %s\n", key, value, fallback)
+		return fallback
+	}
+	return time.Duration(count) * unit
+}
+
+// loadAdminAPIKey reads the key the tenor admin endpoints that change data
+// require. Without one those endpoints reject every request.
+func loadAdminAPIKey() string {
+	key := os.Getenv("ADMIN_API_KEY")
+	if key == "" {
+		log.Println("Warning: ADMIN_API_KEY is not set, tenor changes are disabled")
+	}
+	return key
+}
+
+// runQuoteCleanup deletes expired quotes every interval for the life of the
+// process.
+func runQuoteCleanup(quotes cicilan.QuoteUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := quotes.PurgeExpiredQuotes()
+		if err != nil {
+			log.Printf("Warning: Quote cleanup failed: %v\n", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Deleted %d expired quotes\n", deleted)
+		}
+	}
+}
+
 func main() {
 	dbConfig := loadDatabaseConfig()
 
@@ -113,17 +249,47 @@ func main() {
 		}
 
 		cicilanRepo := repository.NewCicilanRepository(db)
-		cicilanUsecase := usecase.NewCicilanUsecase(cicilanRepo)
+		quoteRepo := repository.NewQuoteRepository(db)
+		cicilanUsecase := usecase.NewCicilanUsecase(cicilanRepo,
+			usecase.WithMaxDSR(loadMaxDSR()),
+			usecase.WithAmountLimits(loadAmountLimits()),
+			usecase.WithTenorRange(loadTenorRange()),
+			usecase.WithHolidayCalendar(loadHolidayCalendar(db)),
+			usecase.WithPricingHistory(repository.NewPricingHistoryRepository(db)),
+			usecase.WithQuotes(quoteRepo, loadDuration("QUOTE_TTL_HOURS", time.Hour, usecase.DefaultQuoteTTL)),
+		)
 		cicilanHandler := http.NewCicilanHandler(cicilanUsecase)
 
+		lateChargeRepo := repository.NewLateChargeRepository(db)
+		lateChargeUsecase := usecase.NewLateChargeUsecase(lateChargeRepo)
+		lateChargeHandler := http.NewLateChargeHandler(lateChargeUsecase)
+
+		tenorRepo := repository.NewTenorRepository(db)
+		tenorAdminHandler := http.NewTenorAdminHandler(usecase.NewTenorAdminUsecase(tenorRepo), loadAdminAPIKey())
+
+		quoteUsecase := usecase.NewQuoteUsecase(quoteRepo)
+		quoteHandler := http.NewQuoteHandler(quoteUsecase)
+		go runQuoteCleanup(quoteUsecase, loadDuration("QUOTE_CLEANUP_INTERVAL_MINUTES", time.Minute, usecase.DefaultQuoteCleanupPeriod))
+
+		applicationRepo := repository.NewApplicationRepository(db)
+		applicationHandler := http.NewApplicationHandler(usecase.NewApplicationUsecase(applicationRepo, quoteRepo))
+
+		contractUsecase := usecase.NewContractUsecase(repository.NewContractRepository(db), applicationRepo, quoteRepo, cicilanUsecase, loadAllocationOrder())
+		contractHandler := http.NewContractHandler(contractUsecase)
+
 		router := gin.Default()
 
-		router.POST("/btpn/*path", func(c *gin.Context) {
+		router.Any("/btpn/*path", func(c *gin.Context) {
 			c.Request.URL.Path = c.Param("path")
-			cicilanHandler.CalculateInstallments(c)
+			router.HandleContext(c)
 		})
 
 		cicilanHandler.RegisterRoutes(router)
+		lateChargeHandler.RegisterRoutes(router)
+		tenorAdminHandler.RegisterRoutes(router)
+		quoteHandler.RegisterRoutes(router)
+		applicationHandler.RegisterRoutes(router)
+		contractHandler.RegisterRoutes(router)
 
 		server := fmt.Sprintf(":%s", os.Getenv("APP_PORT"))
 		if server == ":" {
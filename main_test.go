@@ -2,6 +2,7 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	"btpntest/domain"
 	"btpntest/internal/cicilan/usecase"
@@ -18,7 +19,7 @@ func TestLoggingImports(t *testing.T) {
 
 type MockRepository struct{}
 
-func (m *MockRepository) GetAllTenors() ([]domain.Tenor, error) {
+func (m *MockRepository) GetAllTenors(asOf time.Time) ([]domain.Tenor, error) {
 	return []domain.Tenor{
 		{ID: 1, TenorValue: 6},
 		{ID: 2, TenorValue: 12},
@@ -26,6 +27,10 @@ func (m *MockRepository) GetAllTenors() ([]domain.Tenor, error) {
 	}, nil
 }
 
+func (m *MockRepository) GetProductByCode(code string) (*domain.Product, error) {
+	return nil, nil
+}
+
 func TestValidationLogic(t *testing.T) {
 	mockRepo := &MockRepository{}
 	useCase := usecase.NewCicilanUsecase(mockRepo)
@@ -49,7 +54,7 @@ func TestValidationLogic(t *testing.T) {
 func TestRepositoryInitialization(t *testing.T) {
 	mockRepo := &MockRepository{}
 
-	tenors, err := mockRepo.GetAllTenors()
+	tenors, err := mockRepo.GetAllTenors(time.Now())
 
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
@@ -54,7 +54,7 @@ func Connect(config Config) (*gorm.DB, error) {
 
 func connectMySQL(dsn string) (*gorm.DB, error) {
 	log.Println("Connecting to MySQL...")
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
 	}
@@ -64,7 +64,7 @@ func connectMySQL(dsn string) (*gorm.DB, error) {
 
 func connectPostgreSQL(dsn string) (*gorm.DB, error) {
 	log.Println("Connecting to PostgreSQL...")
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
@@ -74,7 +74,7 @@ func connectPostgreSQL(dsn string) (*gorm.DB, error) {
 
 func connectSQLServer(dsn string) (*gorm.DB, error) {
 	log.Println("Connecting to SQL Server...")
-	db, err := gorm.Open(sqlserver.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(sqlserver.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to SQL Server: %w", err)
 	}